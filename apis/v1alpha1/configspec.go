@@ -92,6 +92,12 @@ type ConfigDeployment struct {
 	// +kubebuilder:validation:Enum=disabled;critical;warn;info;debug
 	// +optional
 	LauncherLogLevel string `json:"launcherLogLevel,omitempty"`
+	// LauncherLogFormat sets the launcher clabernetes worker log format -- "text" (the default) for
+	// humans or "json" for log pipelines. Note: omitempty because empty str does not satisfy enum
+	// of course.
+	// +kubebuilder:validation:Enum=text;json
+	// +optional
+	LauncherLogFormat string `json:"launcherLogFormat,omitempty"`
 	// ExtraEnv is a list of additional environment variables to set on the launcher container. The
 	// values here are applied to *all* launchers since this is the global config after all!
 	// +optional
@@ -27,7 +27,13 @@ type ConnectivitySpec struct {
 }
 
 // ConnectivityStatus is the status for a Connectivity resource.
-type ConnectivityStatus struct{}
+type ConnectivityStatus struct {
+	// PointToPointTunnels holds the state of the point-to-point tunnels as reported by the
+	// launcher pods. The mapping mirrors the spec's PointToPointTunnels -- nodeName (i.e. srl1) ->
+	// p2p tunnel status data, with each launcher only ever reporting on its own tunnels.
+	// +optional
+	PointToPointTunnels map[string][]*PointToPointTunnelStatus `json:"pointToPointTunnels,omitempty"` //nolint:lll
+}
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
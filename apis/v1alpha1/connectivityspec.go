@@ -1,5 +1,9 @@
 package v1alpha1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // PointToPointTunnel holds information necessary for creating a tunnel between two interfaces on
 // different nodes of a clabernetes Topology. This connection is established using VXLAN tunnels.
 type PointToPointTunnel struct {
@@ -19,4 +23,41 @@ type PointToPointTunnel struct {
 	// can properly align tunnels (and ids!) between nodes; basically to know which tunnels are
 	// "paired up".
 	RemoteInterface string `json:"remoteInterface"`
+	// Impairment holds the network impairment settings the launcher should apply to this tunnel,
+	// if any.
+	// +optional
+	Impairment *Impairment `json:"impairment,omitempty"`
+	// AdminState is the administrative state of the link carried by this tunnel, "up" or "down".
+	// An unset admin state means the link is up.
+	// +kubebuilder:validation:Enum=up;down
+	// +optional
+	AdminState string `json:"adminState,omitempty"`
+}
+
+// PointToPointTunnelStatus holds the state of a single PointToPointTunnel as reported by the
+// launcher pod that handles the local side of the tunnel.
+type PointToPointTunnelStatus struct {
+	// TunnelID is the id number of the tunnel this status belongs to.
+	TunnelID int `json:"tunnelID"`
+	// LocalNode is the name (in the clabernetes topology) of the local node for this side of the
+	// tunnel.
+	LocalNode string `json:"localNode"`
+	// LocalInterface is the local termination of this tunnel.
+	LocalInterface string `json:"localInterface"`
+	// State is the state of the tunnel, "created" once the launcher has set the tunnel up, or
+	// "failed" if it could not do so.
+	// +kubebuilder:validation:Enum=created;failed
+	State string `json:"state"`
+	// AdminState is the administrative state the launcher last set on the link of this tunnel.
+	// +kubebuilder:validation:Enum=up;down
+	// +optional
+	AdminState string `json:"adminState,omitempty"`
+	// ResolvedRemote is the address the tunnel destination (service) resolved to.
+	// +optional
+	ResolvedRemote string `json:"resolvedRemote,omitempty"`
+	// LastError is the last error the launcher encountered setting up this tunnel, if any.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastUpdateTime is the last time the launcher updated the state of this tunnel.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
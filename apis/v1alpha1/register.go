@@ -49,5 +49,7 @@ func GetAPIs() (apimachineryscheme.GroupVersion, []apimachineryruntime.Object) {
 		&ImageRequestList{},
 		&Topology{},
 		&TopologyList{},
+		&TopologySnapshot{},
+		&TopologySnapshotList{},
 	}
 }
@@ -61,10 +61,40 @@ type TopologySpec struct {
 	// +kubebuilder:default=global
 	Naming string `json:"naming"`
 	// Connectivity defines the type of connectivity to use between nodes in the topology. The
-	// default behavior is to use vxlan tunnels.
-	// +kubebuilder:validation:Enum=vxlan
+	// default behavior is to use vxlan tunnels, alternatively geneve tunnels can be used -- in
+	// either case the tunnels are terminated in the launcher pods. For clusters that do not pass
+	// encapsulated udp traffic between pods there is also the "slurpeeth" flavor that carries
+	// frames over tcp streams between the launcher pods. Finally, on clusters running multus, the
+	// "multus" flavor carries links over multus network attachments (see the Multus field) instead
+	// of tunnels between the launcher pods.
+	// +kubebuilder:validation:Enum=vxlan;geneve;slurpeeth;multus
 	// +kubebuilder:default=vxlan
 	Connectivity string `json:"connectivity,omitempty"`
+	// Multus holds the settings of the "multus" connectivity flavor, it is ignored for any other
+	// connectivity flavor.
+	// +optional
+	Multus Multus `json:"multus"`
+	// LinkImpairments holds network impairment settings (delay, jitter, loss, rate) for links in
+	// the topology. Impairments are applied (via netem) by the launchers on both sides of links
+	// that span launchers, that is, links that are carried via the connectivity tunnels -- links
+	// within a launcher cannot be impaired.
+	// +listType=atomic
+	// +optional
+	LinkImpairments []LinkImpairment `json:"linkImpairments,omitempty"`
+	// LinkAdminStates sets the administrative state of links in the topology, so links can be
+	// taken down (and brought back up) at runtime without touching the definition, and therefore
+	// without restarting any nodes. Like impairments, admin states apply to links that span
+	// launchers and are acted upon by the launchers on both sides of the link.
+	// +listType=atomic
+	// +optional
+	LinkAdminStates []LinkAdminState `json:"linkAdminStates,omitempty"`
+	// Paused scales all node deployments of the topology down to zero replicas while leaving
+	// every other resource (config, connectivity, services, persistent volume claims and the
+	// allocated tunnel ids) in place. Setting this back to false scales the deployments back up,
+	// and the nodes come back exactly as they were -- with persistence enabled this includes the
+	// contents of the nodes' lab directories.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
 }
 
 // TopologyStatus is the status for a Topology resource.
@@ -90,9 +120,23 @@ type TopologyStatus struct {
 	// by the k8s startup/readiness probe (which is in turn managed by the status probe
 	// configuration of the topology). The possible values are "notready" and "ready", "unknown".
 	NodeReadiness map[string]string `json:"nodeReadiness"`
+	// NodeProbeResults holds the results of the status probes of each node as reported by the
+	// launchers. The mapping is nodeName (i.e. srl1) -> results of the probes of that node. Only
+	// nodes that have status probes configured (and a running launcher) show up here.
+	// +optional
+	NodeProbeResults map[string][]*ProbeResult `json:"nodeProbeResults,omitempty"`
 	// TopologyReady indicates if all nodes in the topology have reported ready. This is duplicated
 	// from the conditions so we can easily snag it for print columns!
 	TopologyReady bool `json:"topologyReady"`
+	// RestartProgress holds the progress of restarting nodes after configuration changes, this is
+	// only set while there are nodes waiting to be, or being, restarted.
+	// +optional
+	RestartProgress *RestartProgress `json:"restartProgress,omitempty"`
+	// PendingRestarts holds the nodes that need to be restarted due to configuration changes but
+	// that have not been restarted yet because the restart policy of the topology is "Manual". The
+	// mapping is nodeName (i.e. srl1) -> pending restart for that node.
+	// +optional
+	PendingRestarts map[string]PendingRestart `json:"pendingRestarts,omitempty"`
 	// Conditions is a list of conditions for the topology custom resource.
 	// +listType=atomic
 	Conditions []metav1.Condition `json:"conditions"`
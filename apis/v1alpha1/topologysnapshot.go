@@ -0,0 +1,66 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TopologySnapshot is an object that represents a request to capture the running configuration of
+// all nodes of a Topology. Each launcher of the referenced Topology saves the configuration of its
+// node(s) and stores the saved configuration in a ConfigMap owned by the TopologySnapshot.
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:JSONPath=".spec.topologyName",name=Topology,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.complete",name=Complete,type=boolean
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+type TopologySnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TopologySnapshotSpec   `json:"spec,omitempty"`
+	Status TopologySnapshotStatus `json:"status,omitempty"`
+}
+
+// TopologySnapshotSpec is the spec for a TopologySnapshot resource.
+type TopologySnapshotSpec struct {
+	// TopologyName is the name of the Topology (in the namespace of the TopologySnapshot) to
+	// capture the node configurations of.
+	TopologyName string `json:"topologyName"`
+}
+
+// TopologySnapshotStatus is the status for a TopologySnapshot resource.
+type TopologySnapshotStatus struct {
+	// Nodes holds the result of the configuration capture of each node as reported by the
+	// launcher pods. The mapping is nodeName (i.e. srl1) -> snapshot result for that node.
+	// +optional
+	Nodes map[string]NodeSnapshotStatus `json:"nodes,omitempty"`
+	// Complete indicates that every node of the referenced Topology has reported the result of
+	// its configuration capture.
+	Complete bool `json:"complete"`
+}
+
+// NodeSnapshotStatus holds the result of the configuration capture of a single node.
+type NodeSnapshotStatus struct {
+	// State is the state of the configuration capture of the node, "saved" once the launcher has
+	// stored the configuration of the node, or "failed" if it could not do so.
+	// +kubebuilder:validation:Enum=saved;failed
+	State string `json:"state"`
+	// ConfigMapName is the name of the ConfigMap holding the saved configuration of the node.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// LastError is the error the launcher encountered capturing the configuration of the node, if
+	// any.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastUpdateTime is the last time the launcher updated the state of this node.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TopologySnapshotList is a list of TopologySnapshot objects.
+type TopologySnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TopologySnapshot `json:"items"`
+}
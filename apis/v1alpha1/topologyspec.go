@@ -53,6 +53,69 @@ type Persistence struct {
 	StorageClassName string `json:"storageClassName,omitempty"`
 }
 
+// NodeGrouping holds information about which containerlab nodes should share a launcher pod. Links
+// between nodes in the same group are left as "normal" containerlab veth links, only links that
+// leave the group are tunneled. Each group is handled by a single launcher that is named after the
+// "primary" node of the group -- this node provides the image, resources, node selectors and probe
+// configuration for the launcher, and its name is the one used for the deployment and services.
+// Nodes that are not part of any group are deployed in their own launcher as usual.
+type NodeGrouping struct {
+	// ContainerlabGroups, when true, co-locates all nodes that share the same containerlab "group"
+	// (as set on the node, its kind, or the topology defaults) in a single launcher. The primary
+	// node of each group is the first node of the group in alphabetical order.
+	// +optional
+	ContainerlabGroups bool `json:"containerlabGroups,omitempty"`
+	// Groups is an explicit mapping of group name to the names of the nodes that should be
+	// co-located in a single launcher. The primary node of each group is the first node of the
+	// group in alphabetical order, so the order of the list does not matter. A node may only be a
+	// member of a single group. When set, this takes precedence over ContainerlabGroups.
+	// +optional
+	Groups map[string][]string `json:"groups,omitempty"`
+}
+
+// NativeMode holds information about which nodes run in "native" mode. The image of a native mode
+// node is rendered directly as a container of the launcher pod, the launcher itself shrinks to a
+// sidecar that only plumbs the node interfaces and handles the tunnels to other nodes -- there is
+// no docker daemon in the pod, so no double image storage and much faster startups. Only
+// container native kinds (srl, ceos and linux) can run in native mode, and native mode nodes
+// always get a launcher of their own (they cannot be part of a node group).
+type NativeMode struct {
+	// Nodes is the list of names of the nodes that should run in native mode.
+	// +listType=atomic
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// RestartStrategy holds information about how nodes whose configuration has changed are restarted.
+// Nodes are restarted in batches, the next batch is only restarted once all nodes of the current
+// batch have come back up.
+type RestartStrategy struct {
+	// Policy is the restart policy of the topology. With the "Automatic" policy (the default) nodes
+	// are restarted as soon as their configuration changes. With the "Manual" policy nodes needing
+	// a restart are only reported in the pending restarts of the topology status, the restarts are
+	// carried out once requested by listing the nodes in the "clabernetes/restartNodes" annotation
+	// of the topology. Note: omitempty because empty str does not satisfy enum of course.
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +optional
+	Policy string `json:"policy,omitempty"`
+	// MaxConcurrent is the maximum number of nodes (or rather launchers, when using node grouping)
+	// that are restarted at the same time. When unset (or zero) all nodes needing a restart are
+	// restarted at once.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+	// WaitForReady, when true, waits for the nodes of a batch to report ready before restarting
+	// the next batch, rather than only waiting for their launcher pods to be recreated.
+	// +optional
+	WaitForReady bool `json:"waitForReady,omitempty"`
+	// Order is the order in which nodes should be restarted. Nodes listed here are restarted first
+	// in the order they are listed, any other nodes are restarted afterward in alphabetical order.
+	// When using node grouping, list the primary node of the group.
+	// +listType=atomic
+	// +optional
+	Order []string `json:"order,omitempty"`
+}
+
 // InsecureRegistries is a slice of strings of insecure registries to configure in the launcher
 // pods.
 type InsecureRegistries []string
@@ -164,10 +227,31 @@ type Deployment struct {
 	// larger than the ConfigMap (etcd) 1Mb size limit.
 	// +optional
 	FilesFromURL map[string][]FileFromURL `json:"filesFromURL"`
+	// RestoreFromSnapshot is the name of a TopologySnapshot (in the namespace of the Topology)
+	// whose saved node configurations should be used as the startup-config of the nodes of this
+	// Topology. The saved configurations are copied into ConfigMaps owned by the Topology and
+	// mounted just like FilesFromConfigMap, so the snapshot may be deleted once the Topology is
+	// restored. Nodes the snapshot failed to capture keep their own startup-config (if any). The
+	// Topology is not reconciled until the snapshot is complete.
+	// +optional
+	RestoreFromSnapshot string `json:"restoreFromSnapshot,omitempty"`
 	// Persistence holds configurations relating to persisting each nodes working containerlab
 	// directory.
 	// +optional
 	Persistence Persistence `json:"persistence"`
+	// NodeGrouping holds configurations relating to co-locating multiple containerlab nodes in a
+	// single launcher pod. By default every node gets its own launcher.
+	// +optional
+	NodeGrouping NodeGrouping `json:"nodeGrouping"`
+	// RestartStrategy holds configurations relating to how nodes are restarted when their
+	// configuration changes. By default all nodes needing a restart are restarted at once.
+	// +optional
+	RestartStrategy RestartStrategy `json:"restartStrategy"`
+	// NativeMode holds configurations relating to running nodes directly as containers of their
+	// launcher pod rather than via docker and containerlab inside the launcher. By default all
+	// nodes are launched via containerlab.
+	// +optional
+	NativeMode NativeMode `json:"nativeMode"`
 	// ContainerlabDebug sets the `--debug` flag when invoking containerlab in the launcher pods.
 	// This is disabled by default. If this value is unset, the global config value (default of
 	// "false") will be used.
@@ -205,6 +289,12 @@ type Deployment struct {
 	// +kubebuilder:validation:Enum=disabled;critical;warn;info;debug
 	// +optional
 	LauncherLogLevel string `json:"launcherLogLevel,omitempty"`
+	// LauncherLogFormat sets the launcher clabernetes worker log format ("text" or "json") for this
+	// topology -- this overrides whatever is set in the global config CR. Note: omitempty because
+	// empty str does not satisfy enum of course.
+	// +kubebuilder:validation:Enum=text;json
+	// +optional
+	LauncherLogFormat string `json:"launcherLogFormat,omitempty"`
 	// ExtraEnv is a list of additional environment variables to set on the launcher container. The
 	// values here override any configured global config extra envs!
 	// +optional
@@ -253,7 +343,7 @@ type StatusProbes struct {
 }
 
 // ProbeConfiguration holds information about how to probe a (containerlab) node in a Topology. If
-// both style probes are configured, both will be used and both must succeed in order to report
+// multiple probes are configured, all of them will be used and all must succeed in order to report
 // healthy.
 type ProbeConfiguration struct {
 	// StartupSeconds is the total amount of seconds to allow for the node to start. This defaults
@@ -271,6 +361,18 @@ type ProbeConfiguration struct {
 	// TCPProbeConfiguration defines a TCP probe.
 	// +optional
 	TCPProbeConfiguration *TCPProbeConfiguration `json:"tcpProbeConfiguration,omitempty"`
+	// GNMIProbeConfiguration defines a gNMI probe.
+	// +optional
+	GNMIProbeConfiguration *GNMIProbeConfiguration `json:"gnmiProbeConfiguration,omitempty"`
+	// NETCONFProbe defines a NETCONF probe.
+	// +optional
+	NETCONFProbe *NETCONFProbeConfiguration `json:"netconfProbeConfiguration,omitempty"`
+	// HTTPProbeConfiguration defines an HTTP(S) probe.
+	// +optional
+	HTTPProbeConfiguration *HTTPProbeConfiguration `json:"httpProbeConfiguration,omitempty"`
+	// ExecProbeConfiguration defines an exec probe.
+	// +optional
+	ExecProbeConfiguration *ExecProbeConfiguration `json:"execProbeConfiguration,omitempty"`
 }
 
 // SSHProbeConfiguration defines a "ssh" probe -- the ssh probe just connects using standard go
@@ -278,10 +380,25 @@ type ProbeConfiguration struct {
 // is executed by the launcher and the result is placed into /clabernetes/.nodestatus so the k8s
 // probe can pick it up and reflect the status.
 type SSHProbeConfiguration struct {
-	// Username is the username to use for auth.
+	// Username is the username to use for auth. If not set, the username is taken from the
+	// "username" key of the CredentialsSecret.
+	// +optional
 	Username string `json:"username"`
-	// Password is the password to use for auth.
-	Password string `json:"password"`
+	// Password is the plain text password to use for auth.
+	//
+	// Deprecated: plain text passwords end up in the Topology and the launcher Deployment, use
+	// CredentialsSecret instead. New or updated Topologies setting a password are rejected by the
+	// validating webhook, existing ones get no ssh probe and a warning event instead.
+	// +optional
+	Password string `json:"password,omitempty"`
+	// CredentialsSecret is the name of a secret holding the credentials to use for auth. The
+	// secret *must be present in the namespace of this topology*, and may contain the keys
+	// "username", "password" and "ssh-privatekey" -- that is, the keys of the
+	// "kubernetes.io/basic-auth" and "kubernetes.io/ssh-auth" secret types. The keys are projected
+	// into the launcher as environment variables referencing the secret, so the credentials never
+	// show up in the Topology or the launcher Deployment.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
 	// Port is an optional override (of course default is 22).
 	// +optional
 	Port int `json:"port"`
@@ -298,6 +415,84 @@ type TCPProbeConfiguration struct {
 	Port int `json:"port"`
 }
 
+// GNMIProbeConfiguration defines a "gnmi" probe -- the probe sends a gNMI Capabilities RPC to the
+// node and reports true if the node answers it successfully. Like the other probes, the probe is
+// executed by the launcher and the result is placed into /clabernetes/.nodestatus.
+type GNMIProbeConfiguration struct {
+	// Port is an optional override (default is 57400).
+	// +optional
+	Port int `json:"port"`
+	// Insecure, when true, sends the RPC over a plain text connection rather than TLS. Note that
+	// the certificate of the node is not verified when using TLS.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// Username is the username sent along with the RPC. If not set, the username is taken from
+	// the "username" key of the CredentialsSecret.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// CredentialsSecret is the name of a secret holding the credentials sent along with the RPC.
+	// The secret *must be present in the namespace of this topology*, the "username" and
+	// "password" keys of the secret are used.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// NETCONFProbeConfiguration defines a "netconf" probe -- the probe opens the NETCONF ssh
+// subsystem and reports true if the node sends its hello (we answer with ours before closing the
+// session). Like the other probes, the probe is executed by the launcher and the result is placed
+// into /clabernetes/.nodestatus.
+type NETCONFProbeConfiguration struct {
+	// Port is an optional override (default is 830).
+	// +optional
+	Port int `json:"port"`
+	// Username is the username to use for auth. If not set, the username is taken from the
+	// "username" key of the CredentialsSecret.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// CredentialsSecret is the name of a secret holding the credentials to use for auth. The
+	// secret *must be present in the namespace of this topology*, and may contain the keys
+	// "username", "password" and "ssh-privatekey" -- just like the credentials secret of the ssh
+	// probe.
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// HTTPProbeConfiguration defines a "http" probe -- the probe sends a GET request to the node and
+// reports true if the response has the expected status code and, if configured, a body matching
+// the BodyRegex. Like the other probes, the probe is executed by the launcher and the result is
+// placed into /clabernetes/.nodestatus.
+type HTTPProbeConfiguration struct {
+	// Port is the port to send the request to.
+	Port int `json:"port"`
+	// Path is the path to request, defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Scheme is the scheme to use for the request, defaults to "HTTP". Note that the certificate
+	// of the node is not verified when using "HTTPS".
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+	// StatusCode is the expected status code of the response, defaults to 200.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+	// BodyRegex is an optional regular expression the body of the response must match.
+	// +optional
+	BodyRegex string `json:"bodyRegex,omitempty"`
+}
+
+// ExecProbeConfiguration defines an "exec" probe -- the probe executes a command inside the node
+// container (via docker exec) and reports true if the command exits zero and, if configured, its
+// output matches the OutputRegex. Like the other probes, the probe is executed by the launcher and
+// the result is placed into /clabernetes/.nodestatus.
+type ExecProbeConfiguration struct {
+	// Command is the command (and its arguments) to execute inside the node container.
+	// +listType=atomic
+	Command []string `json:"command"`
+	// OutputRegex is an optional regular expression the (combined stdout and stderr) output of
+	// the command must match.
+	// +optional
+	OutputRegex string `json:"outputRegex,omitempty"`
+}
+
 // ImagePull holds configurations relevant to how clabernetes launcher pods handle pulling
 // images.
 type ImagePull struct {
@@ -335,3 +530,73 @@ type ImagePull struct {
 	// +optional
 	DockerConfig string `json:"dockerConfig,omitempty"`
 }
+
+// LinkImpairment holds network impairment settings for a single link in the topology.
+type LinkImpairment struct {
+	// Endpoint selects the link to impair by one of its endpoints in the containerlab
+	// "node:interface" format, i.e. "srl1:e1-1". The impairment applies to both directions of the
+	// link, so it does not matter which of the link's two endpoints is used here.
+	Endpoint string `json:"endpoint"`
+	// Impairment is the impairment to apply to the link.
+	Impairment Impairment `json:"impairment"`
+}
+
+// Impairment holds the netem settings applied to a link. Unset fields are simply not applied.
+type Impairment struct {
+	// Delay is the delay to add to frames on the link, i.e. "50ms".
+	// +optional
+	Delay string `json:"delay,omitempty"`
+	// Jitter is the variation of the delay, i.e. "5ms". Jitter is only applicable if Delay is set.
+	// +optional
+	Jitter string `json:"jitter,omitempty"`
+	// Loss is the percentage of frames to drop on the link, i.e. "0.5" for half a percent.
+	// +kubebuilder:validation:Pattern=`^(100(\.0+)?|[0-9]{1,2}(\.[0-9]+)?)%?$`
+	// +optional
+	Loss string `json:"loss,omitempty"`
+	// Rate limits the rate of the link, in tc rate notation, i.e. "100mbit".
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([kmgt]?bit|[kmgt]?bps)$`
+	// +optional
+	Rate string `json:"rate,omitempty"`
+}
+
+// LinkAdminState holds the administrative state for a single link in the topology.
+type LinkAdminState struct {
+	// Endpoint selects the link by one of its endpoints in the containerlab "node:interface"
+	// format, i.e. "srl1:e1-1".
+	Endpoint string `json:"endpoint"`
+	// AdminState is the administrative state of the link, "up" or "down".
+	// +kubebuilder:validation:Enum=up;down
+	AdminState string `json:"adminState"`
+}
+
+// Multus holds the settings of the "multus" connectivity flavor -- that is the cni configuration
+// the controller renders into the NetworkAttachmentDefinition it creates for each link that spans
+// launchers. Links are kept apart from each other by their vlan id, which is the (per topology
+// unique) tunnel id of the link.
+type Multus struct {
+	// Type is the cni plugin used for the link attachments. With "vlan" (the default) each
+	// attachment is a vlan interface on the master interface of the kubernetes node. With
+	// "macvlan" each attachment is a macvlan interface on the vlan interface "<master>.<vlan id>",
+	// which must already exist on the kubernetes nodes. With "bridge" each attachment is connected
+	// to the bridge named by master (or the cni default bridge if unset) with the vlan id of the
+	// link as the vlan of the bridge port.
+	// +kubebuilder:validation:Enum=vlan;macvlan;bridge
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Master is the interface of the kubernetes nodes the attachments are created on, or the name
+	// of the bridge when using the "bridge" type.
+	// +optional
+	Master string `json:"master,omitempty"`
+	// MTU is the mtu of the attachments, if unset the cni plugin default is used.
+	// +optional
+	MTU int `json:"mtu,omitempty"`
+	// VLANBase is the vlan id of the link with tunnel id 1, every link gets the vlan id "vlan
+	// base + tunnel id - 1" -- tunnel ids are kept for as long as the link exists, so they are not
+	// necessarily consecutive. Topologies sharing a master interface (or bridge) must use vlan
+	// ranges that do not overlap, the validating webhook rejects vlan bases overlapping the range
+	// of another topology. Defaults to 2 as vlan 1 usually is the default (native) vlan.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=4094
+	// +optional
+	VLANBase int `json:"vlanBase,omitempty"`
+}
@@ -1,5 +1,9 @@
 package v1alpha1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // LinkEndpointElementCount defines the expected element count for a link endpoint slice.
 const LinkEndpointElementCount = 2
 
@@ -44,3 +48,55 @@ type ExposedPorts struct {
 	// +listType=set
 	UDPPorts []int `json:"udpPorts"`
 }
+
+// RestartProgress holds information about the progress of restarting nodes whose configuration
+// has changed.
+type RestartProgress struct {
+	// Pending is the list of nodes waiting to be restarted, in the order they will be restarted.
+	// +listType=atomic
+	// +optional
+	Pending []string `json:"pending,omitempty"`
+	// Restarting is the list of nodes of the current batch, that is, nodes that have been
+	// restarted but have not come back up yet.
+	// +listType=atomic
+	// +optional
+	Restarting []string `json:"restarting,omitempty"`
+	// BatchStartedAt is the time (RFC3339) the current batch was restarted at, this is the value of
+	// the restartedAt annotation set on the deployments of the nodes of the batch.
+	// +optional
+	BatchStartedAt string `json:"batchStartedAt,omitempty"`
+	// Restarted is the number of nodes that have been restarted (and came back up) since the
+	// restarts started.
+	Restarted int `json:"restarted"`
+}
+
+// PendingRestart holds information about a node restart that is waiting to be requested.
+type PendingRestart struct {
+	// Reason is why the node needs to be restarted.
+	Reason string `json:"reason"`
+	// Diff is the (unified) diff of the containerlab configuration of the node since the restart
+	// became pending.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
+// ProbeResult holds the result of a single status probe of a node as reported by its launcher.
+type ProbeResult struct {
+	// Probe is the kind of the status probe this result belongs to.
+	// +kubebuilder:validation:Enum=tcp;ssh;gnmi;netconf;http;exec
+	Probe string `json:"probe"`
+	// Result is the result of the last run of the probe, "success" or "failure".
+	// +kubebuilder:validation:Enum=success;failure
+	Result string `json:"result"`
+	// LastSuccessTime is the last time the probe succeeded, if it ever did. While the probe keeps
+	// succeeding this is only refreshed every few minutes.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// ConsecutiveFailures is the number of times in a row the probe has failed.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+	// LastError is the error of the last failed run of the probe, if any.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastUpdateTime is the last time the launcher updated the result of this probe.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
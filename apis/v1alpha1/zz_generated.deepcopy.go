@@ -245,7 +245,7 @@ func (in *Connectivity) DeepCopyInto(out *Connectivity) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -317,7 +317,7 @@ func (in *ConnectivitySpec) DeepCopyInto(out *ConnectivitySpec) {
 					if (*in)[i] != nil {
 						in, out := &(*in)[i], &(*out)[i]
 						*out = new(PointToPointTunnel)
-						**out = **in
+						(*in).DeepCopyInto(*out)
 					}
 				}
 			}
@@ -340,6 +340,27 @@ func (in *ConnectivitySpec) DeepCopy() *ConnectivitySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectivityStatus) DeepCopyInto(out *ConnectivityStatus) {
 	*out = *in
+	if in.PointToPointTunnels != nil {
+		in, out := &in.PointToPointTunnels, &out.PointToPointTunnels
+		*out = make(map[string][]*PointToPointTunnelStatus, len(*in))
+		for key, val := range *in {
+			var outVal []*PointToPointTunnelStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]*PointToPointTunnelStatus, len(*in))
+				for i := range *in {
+					if (*in)[i] != nil {
+						in, out := &(*in)[i], &(*out)[i]
+						*out = new(PointToPointTunnelStatus)
+						(*in).DeepCopyInto(*out)
+					}
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
@@ -416,6 +437,9 @@ func (in *Deployment) DeepCopyInto(out *Deployment) {
 		}
 	}
 	out.Persistence = in.Persistence
+	in.NodeGrouping.DeepCopyInto(&out.NodeGrouping)
+	in.RestartStrategy.DeepCopyInto(&out.RestartStrategy)
+	in.NativeMode.DeepCopyInto(&out.NativeMode)
 	if in.ContainerlabDebug != nil {
 		in, out := &in.ContainerlabDebug, &out.ContainerlabDebug
 		*out = new(bool)
@@ -441,6 +465,27 @@ func (in *Deployment) DeepCopy() *Deployment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecProbeConfiguration) DeepCopyInto(out *ExecProbeConfiguration) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecProbeConfiguration.
+func (in *ExecProbeConfiguration) DeepCopy() *ExecProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Expose) DeepCopyInto(out *Expose) {
 	*out = *in
@@ -515,6 +560,38 @@ func (in *FileFromURL) DeepCopy() *FileFromURL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GNMIProbeConfiguration) DeepCopyInto(out *GNMIProbeConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GNMIProbeConfiguration.
+func (in *GNMIProbeConfiguration) DeepCopy() *GNMIProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GNMIProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProbeConfiguration) DeepCopyInto(out *HTTPProbeConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbeConfiguration.
+func (in *HTTPProbeConfiguration) DeepCopy() *HTTPProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePull) DeepCopyInto(out *ImagePull) {
 	*out = *in
@@ -639,6 +716,22 @@ func (in *ImageRequestStatus) DeepCopy() *ImageRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Impairment) DeepCopyInto(out *Impairment) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Impairment.
+func (in *Impairment) DeepCopy() *Impairment {
+	if in == nil {
+		return nil
+	}
+	out := new(Impairment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in InsecureRegistries) DeepCopyInto(out *InsecureRegistries) {
 	{
@@ -659,6 +752,22 @@ func (in InsecureRegistries) DeepCopy() InsecureRegistries {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkAdminState) DeepCopyInto(out *LinkAdminState) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkAdminState.
+func (in *LinkAdminState) DeepCopy() *LinkAdminState {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkAdminState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkEndpoint) DeepCopyInto(out *LinkEndpoint) {
 	*out = *in
@@ -675,6 +784,140 @@ func (in *LinkEndpoint) DeepCopy() *LinkEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkImpairment) DeepCopyInto(out *LinkImpairment) {
+	*out = *in
+	out.Impairment = in.Impairment
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkImpairment.
+func (in *LinkImpairment) DeepCopy() *LinkImpairment {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkImpairment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Multus) DeepCopyInto(out *Multus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Multus.
+func (in *Multus) DeepCopy() *Multus {
+	if in == nil {
+		return nil
+	}
+	out := new(Multus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NETCONFProbeConfiguration) DeepCopyInto(out *NETCONFProbeConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NETCONFProbeConfiguration.
+func (in *NETCONFProbeConfiguration) DeepCopy() *NETCONFProbeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NETCONFProbeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NativeMode) DeepCopyInto(out *NativeMode) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NativeMode.
+func (in *NativeMode) DeepCopy() *NativeMode {
+	if in == nil {
+		return nil
+	}
+	out := new(NativeMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGrouping) DeepCopyInto(out *NodeGrouping) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGrouping.
+func (in *NodeGrouping) DeepCopy() *NodeGrouping {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGrouping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSnapshotStatus) DeepCopyInto(out *NodeSnapshotStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSnapshotStatus.
+func (in *NodeSnapshotStatus) DeepCopy() *NodeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRestart) DeepCopyInto(out *PendingRestart) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRestart.
+func (in *PendingRestart) DeepCopy() *PendingRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Persistence) DeepCopyInto(out *Persistence) {
 	*out = *in
@@ -694,6 +937,11 @@ func (in *Persistence) DeepCopy() *Persistence {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PointToPointTunnel) DeepCopyInto(out *PointToPointTunnel) {
 	*out = *in
+	if in.Impairment != nil {
+		in, out := &in.Impairment, &out.Impairment
+		*out = new(Impairment)
+		**out = **in
+	}
 	return
 }
 
@@ -707,6 +955,23 @@ func (in *PointToPointTunnel) DeepCopy() *PointToPointTunnel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PointToPointTunnelStatus) DeepCopyInto(out *PointToPointTunnelStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointToPointTunnelStatus.
+func (in *PointToPointTunnelStatus) DeepCopy() *PointToPointTunnelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PointToPointTunnelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeConfiguration) DeepCopyInto(out *ProbeConfiguration) {
 	*out = *in
@@ -720,6 +985,26 @@ func (in *ProbeConfiguration) DeepCopyInto(out *ProbeConfiguration) {
 		*out = new(TCPProbeConfiguration)
 		**out = **in
 	}
+	if in.GNMIProbeConfiguration != nil {
+		in, out := &in.GNMIProbeConfiguration, &out.GNMIProbeConfiguration
+		*out = new(GNMIProbeConfiguration)
+		**out = **in
+	}
+	if in.NETCONFProbe != nil {
+		in, out := &in.NETCONFProbe, &out.NETCONFProbe
+		*out = new(NETCONFProbeConfiguration)
+		**out = **in
+	}
+	if in.HTTPProbeConfiguration != nil {
+		in, out := &in.HTTPProbeConfiguration, &out.HTTPProbeConfiguration
+		*out = new(HTTPProbeConfiguration)
+		**out = **in
+	}
+	if in.ExecProbeConfiguration != nil {
+		in, out := &in.ExecProbeConfiguration, &out.ExecProbeConfiguration
+		*out = new(ExecProbeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -733,6 +1018,27 @@ func (in *ProbeConfiguration) DeepCopy() *ProbeConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeResult) DeepCopyInto(out *ProbeResult) {
+	*out = *in
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeResult.
+func (in *ProbeResult) DeepCopy() *ProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReconcileHashes) DeepCopyInto(out *ReconcileHashes) {
 	*out = *in
@@ -796,6 +1102,53 @@ func (in ResourceMap) DeepCopy() ResourceMap {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartProgress) DeepCopyInto(out *RestartProgress) {
+	*out = *in
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Restarting != nil {
+		in, out := &in.Restarting, &out.Restarting
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartProgress.
+func (in *RestartProgress) DeepCopy() *RestartProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartStrategy) DeepCopyInto(out *RestartStrategy) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartStrategy.
+func (in *RestartStrategy) DeepCopy() *RestartStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHProbeConfiguration) DeepCopyInto(out *SSHProbeConfiguration) {
 	*out = *in
@@ -948,6 +1301,106 @@ func (in *TopologyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySnapshot) DeepCopyInto(out *TopologySnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySnapshot.
+func (in *TopologySnapshot) DeepCopy() *TopologySnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TopologySnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySnapshotList) DeepCopyInto(out *TopologySnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TopologySnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySnapshotList.
+func (in *TopologySnapshotList) DeepCopy() *TopologySnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TopologySnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySnapshotSpec) DeepCopyInto(out *TopologySnapshotSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySnapshotSpec.
+func (in *TopologySnapshotSpec) DeepCopy() *TopologySnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySnapshotStatus) DeepCopyInto(out *TopologySnapshotStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string]NodeSnapshotStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySnapshotStatus.
+func (in *TopologySnapshotStatus) DeepCopy() *TopologySnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	*out = *in
@@ -956,6 +1409,17 @@ func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	in.Deployment.DeepCopyInto(&out.Deployment)
 	in.StatusProbes.DeepCopyInto(&out.StatusProbes)
 	in.ImagePull.DeepCopyInto(&out.ImagePull)
+	out.Multus = in.Multus
+	if in.LinkImpairments != nil {
+		in, out := &in.LinkImpairments, &out.LinkImpairments
+		*out = make([]LinkImpairment, len(*in))
+		copy(*out, *in)
+	}
+	if in.LinkAdminStates != nil {
+		in, out := &in.LinkAdminStates, &out.LinkAdminStates
+		*out = make([]LinkAdminState, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1007,6 +1471,39 @@ func (in *TopologyStatus) DeepCopyInto(out *TopologyStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodeProbeResults != nil {
+		in, out := &in.NodeProbeResults, &out.NodeProbeResults
+		*out = make(map[string][]*ProbeResult, len(*in))
+		for key, val := range *in {
+			var outVal []*ProbeResult
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]*ProbeResult, len(*in))
+				for i := range *in {
+					if (*in)[i] != nil {
+						in, out := &(*in)[i], &(*out)[i]
+						*out = new(ProbeResult)
+						(*in).DeepCopyInto(*out)
+					}
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.RestartProgress != nil {
+		in, out := &in.RestartProgress, &out.RestartProgress
+		*out = new(RestartProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingRestarts != nil {
+		in, out := &in.PendingRestarts, &out.PendingRestarts
+		*out = make(map[string]PendingRestart, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -15,6 +15,8 @@
   value: {{ .Values.manager.managerLogLevel }}
 - name: CONTROLLER_LOGGER_LEVEL
   value: {{ .Values.manager.controllerLogLevel }}
+- name: MANAGER_LOGGER_FORMAT
+  value: {{ .Values.manager.logFormat }}
 - name: LAUNCHER_IMAGE
   {{- if .Values.globalConfig.deployment.launcherImage }}
   value: {{ .Values.globalConfig.deployment.launcherImage }}
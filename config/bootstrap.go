@@ -28,6 +28,7 @@ type bootstrapConfig struct {
 	launcherImage               string
 	launcherImagePullPolicy     string
 	launcherLogLevel            string
+	launcherLogFormat           string
 	criSockOverride             string
 	criKindOverride             string
 	naming                      string
@@ -45,6 +46,7 @@ func bootstrapFromConfigMap( //nolint:gocyclo,funlen,gocognit
 		launcherImage:           os.Getenv(clabernetesconstants.LauncherImageEnv),
 		launcherImagePullPolicy: clabernetesconstants.KubernetesImagePullIfNotPresent,
 		launcherLogLevel:        clabernetesconstants.Info,
+		launcherLogFormat:       clabernetesconstants.LogFormatText,
 		privilegedLauncher:      true,
 		naming:                  clabernetesconstants.NamingModePrefixed,
 	}
@@ -146,6 +148,11 @@ func bootstrapFromConfigMap( //nolint:gocyclo,funlen,gocognit
 		bc.launcherLogLevel = launcherLogLevel
 	}
 
+	launcherLogFormat, launcherLogFormatOk := inMap["launcherLogFormat"]
+	if launcherLogFormatOk {
+		bc.launcherLogFormat = launcherLogFormat
+	}
+
 	criSockOverride, criSockOverrideOk := inMap["criSockOverride"]
 	if criSockOverrideOk {
 		bc.criSockOverride = criSockOverride
@@ -294,6 +301,10 @@ func mergeFromBootstrapConfigMerge( //nolint:gocyclo
 		config.Spec.Deployment.LauncherLogLevel = bootstrap.launcherLogLevel
 	}
 
+	if config.Spec.Deployment.LauncherLogFormat == "" {
+		config.Spec.Deployment.LauncherLogFormat = bootstrap.launcherLogFormat
+	}
+
 	if config.Spec.ImagePull.CRISockOverride == "" {
 		config.Spec.ImagePull.CRISockOverride = bootstrap.criSockOverride
 	}
@@ -339,6 +350,7 @@ func mergeFromBootstrapConfigReplace(
 			LauncherImage:               bootstrap.launcherImage,
 			LauncherImagePullPolicy:     bootstrap.launcherImagePullPolicy,
 			LauncherLogLevel:            bootstrap.launcherLogLevel,
+			LauncherLogFormat:           bootstrap.launcherLogFormat,
 			ContainerlabVersion:         bootstrap.containerlabVersion,
 			ExtraEnv:                    bootstrap.extraEnv,
 		},
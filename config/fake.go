@@ -125,6 +125,10 @@ func (f fakeManager) GetLauncherLogLevel() string {
 	return clabernetesconstants.Info
 }
 
+func (f fakeManager) GetLauncherLogFormat() string {
+	return clabernetesconstants.LogFormatText
+}
+
 func (f fakeManager) GetExtraEnv() []k8scorev1.EnvVar {
 	return nil
 }
@@ -158,6 +158,13 @@ func (m *manager) GetLauncherLogLevel() string {
 	return m.config.Deployment.LauncherLogLevel
 }
 
+func (m *manager) GetLauncherLogFormat() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.config.Deployment.LauncherLogFormat
+}
+
 func (m *manager) GetExtraEnv() []k8scorev1.EnvVar {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -73,6 +73,7 @@ func InitManager(
 					LauncherImage:           os.Getenv(clabernetesconstants.LauncherImageEnv),
 					LauncherImagePullPolicy: clabernetesconstants.KubernetesImagePullIfNotPresent,
 					LauncherLogLevel:        clabernetesconstants.Info,
+					LauncherLogFormat:       clabernetesconstants.LogFormatText,
 				},
 				ImagePull: clabernetesapisv1alpha1.ConfigImagePull{
 					PullThroughOverride: clabernetesconstants.ImagePullThroughModeAuto,
@@ -150,6 +151,8 @@ type Manager interface { //nolint: interfacebloat
 	GetLauncherImagePullPolicy() string
 	// GetLauncherLogLevel returns the default launcher log level.
 	GetLauncherLogLevel() string
+	// GetLauncherLogFormat returns the default launcher log format.
+	GetLauncherLogFormat() string
 	// GetExtraEnv returns the default extra env vars for setting on launcher containers.
 	GetExtraEnv() []k8scorev1.EnvVar
 	// GetRemoveTopologyPrefix returns true if the topology prefix should be removed from Topology
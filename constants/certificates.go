@@ -13,4 +13,8 @@ const (
 
 	// WebhookCertificateSubDir is the subdirectory where webhook/http certs are stored.
 	WebhookCertificateSubDir = "webhook"
+
+	// LauncherCaptureCertificateDirectory is the directory the capture server certificate the
+	// manager issued for a topology is mounted at in its launchers.
+	LauncherCaptureCertificateDirectory = "/clabernetes/certificates/capture"
 )
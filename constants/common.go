@@ -25,6 +25,13 @@ const (
 	// VXLANServicePort is the port number for vxlan that we use in the kubernetes service.
 	VXLANServicePort = 14789
 
+	// GENEVEServicePort is the port number for geneve that we use in the kubernetes service.
+	GENEVEServicePort = 16081
+
+	// SlurpeethServicePort is the port number for slurpeeth (tcp) tunnels that we use in the
+	// kubernetes service.
+	SlurpeethServicePort = 4799
+
 	// TCP is... TCP.
 	TCP = "TCP"
 
@@ -0,0 +1,42 @@
+package constants
+
+const (
+	// ConditionTopologyReady is the condition type reporting if all nodes in a Topology are ready.
+	ConditionTopologyReady = "TopologyReady"
+
+	// ConditionTopologyTeardown is the condition type reporting the teardown progress of a
+	// Topology that is being deleted.
+	ConditionTopologyTeardown = "TopologyTeardown"
+
+	// ConditionLinksReady is the condition type reporting if all tunnels (links between nodes in
+	// different launchers) of a Topology have been created by the launchers.
+	ConditionLinksReady = "LinksReady"
+)
+
+const (
+	// TeardownReasonScalingDown is the TopologyTeardown condition reason while node deployments
+	// are being scaled down.
+	TeardownReasonScalingDown = "ScalingDown"
+
+	// TeardownReasonComplete is the TopologyTeardown condition reason once all nodes have been
+	// scaled down and namespace resources have been cleaned up.
+	TeardownReasonComplete = "Complete"
+
+	// TeardownReasonTearingDown is the TopologyReady condition reason while a Topology is being
+	// torn down.
+	TeardownReasonTearingDown = "TearingDown"
+)
+
+const (
+	// LinksReadyReasonTunnelsCreated is the LinksReady condition reason once all tunnels report
+	// as created.
+	LinksReadyReasonTunnelsCreated = "TunnelsCreated"
+
+	// LinksReadyReasonTunnelsPending is the LinksReady condition reason while one or more tunnels
+	// have not (yet) been reported by their launcher.
+	LinksReadyReasonTunnelsPending = "TunnelsPending"
+
+	// LinksReadyReasonTunnelsFailed is the LinksReady condition reason when one or more tunnels
+	// report as failed.
+	LinksReadyReasonTunnelsFailed = "TunnelsFailed"
+)
@@ -0,0 +1,52 @@
+package constants
+
+const (
+	// MgmtNetKeyword is the containerlab reserved keyword to define management network link
+	// endpoints in the "brief" link format.
+	MgmtNetKeyword = "mgmt-net"
+
+	// MacVLANKeyword is the containerlab reserved keyword to define macvlan link endpoints in the
+	// "brief" link format.
+	MacVLANKeyword = "macvlan"
+
+	// LinkTypeVeth is the containerlab "veth" link type -- a veth pair between two nodes.
+	LinkTypeVeth = "veth"
+
+	// LinkTypeMgmtNet is the containerlab "mgmt-net" link type -- a link between a node and the
+	// management network bridge.
+	LinkTypeMgmtNet = "mgmt-net"
+
+	// LinkTypeMacVLAN is the containerlab "macvlan" link type -- a macvlan interface in a node
+	// attached to a host interface.
+	LinkTypeMacVLAN = "macvlan"
+
+	// LinkTypeHost is the containerlab "host" link type -- a veth pair between a node and the
+	// host (in clabernetes case the launcher pod) namespace.
+	LinkTypeHost = "host"
+
+	// LinkTypeDummy is the containerlab "dummy" link type -- a dummy interface in a node.
+	LinkTypeDummy = "dummy"
+
+	// LinkTypeVXLAN is the containerlab "vxlan" link type -- a vxlan tunnel from a node to some
+	// remote vtep.
+	LinkTypeVXLAN = "vxlan"
+
+	// LinkTypeVXLANStitch is the containerlab "vxlan-stitch" link type -- a vxlan tunnel from the
+	// host to some remote vtep stitched to a node via a veth pair.
+	LinkTypeVXLANStitch = "vxlan-stitch"
+
+	// KindNokiaSRLinux is the containerlab kind of Nokia SR Linux nodes.
+	KindNokiaSRLinux = "nokia_srlinux"
+
+	// KindSRL is the (short, older) alias of the KindNokiaSRLinux containerlab kind.
+	KindSRL = "srl"
+
+	// KindAristaCEOS is the containerlab kind of Arista cEOS nodes.
+	KindAristaCEOS = "arista_ceos"
+
+	// KindCEOS is the (short, older) alias of the KindAristaCEOS containerlab kind.
+	KindCEOS = "ceos"
+
+	// KindLinux is the containerlab kind of plain linux container nodes.
+	KindLinux = "linux"
+)
@@ -10,6 +10,11 @@ const (
 	// individual controllers.
 	ManagerLoggerLevelEnv = "MANAGER_LOGGER_LEVEL"
 
+	// ManagerLoggerFormatEnv is the environment variable name that can be used to set the
+	// clabernetes manager log format (text or json). Unlike the level, the format applies to *all*
+	// loggers of the manager -- including the controllers and klog.
+	ManagerLoggerFormatEnv = "MANAGER_LOGGER_FORMAT"
+
 	// ControllerLoggerLevelEnv is the environment variable name that can be used to set the
 	// clabernetes controllers logger level.
 	ControllerLoggerLevelEnv = "CONTROLLER_LOGGER_LEVEL"
@@ -45,6 +50,10 @@ const (
 	// clabernetes launcher logger level.
 	LauncherLoggerLevelEnv = "LAUNCHER_LOGGER_LEVEL"
 
+	// LauncherLoggerFormatEnv is the environment variable name that can be used to set the
+	// clabernetes launcher log format (text or json).
+	LauncherLoggerFormatEnv = "LAUNCHER_LOGGER_FORMAT"
+
 	// LauncherContainerlabDebug is the environment variable name that can be used to enable the
 	// debug flag of clabernetes when invoked on the launcher pod.
 	LauncherContainerlabDebug = "LAUNCHER_CONTAINERLAB_DEBUG"
@@ -97,6 +106,21 @@ const (
 	// containerlab to download and use in the launcher.
 	LauncherContainerlabVersion = "LAUNCHER_CONTAINERLAB_VERSION"
 
+	// LauncherWaitFor is the env var that holds the (comma separated) names of the nodes in other
+	// launchers that the node(s) of the launcher wait for (via containerlab wait-for) before
+	// launching.
+	LauncherWaitFor = "LAUNCHER_WAIT_FOR"
+
+	// LauncherCaptureCA is the env var that holds the (pem encoded) certificate authority of the
+	// manager -- the launcher only serves packet captures to clients presenting a certificate
+	// signed by this authority.
+	LauncherCaptureCA = "LAUNCHER_CAPTURE_CA"
+
+	// LauncherNativeModeEnv is the env var that tells the launcher that its node runs in native
+	// mode -- meaning the node runs as a container of the launcher pod and the launcher only plumbs
+	// the node interfaces and handles the tunnels.
+	LauncherNativeModeEnv = "LAUNCHER_NATIVE_MODE"
+
 	// LauncherTCPProbePort is the env var that holds the port to use in the tcp probe (if
 	// configured).
 	LauncherTCPProbePort = "LAUNCHER_TCP_PROBE_PORT"
@@ -112,4 +136,68 @@ const (
 	// LauncherSSHProbePassword is the env var that holds the password to use in the ssh probe (if
 	// configured).
 	LauncherSSHProbePassword = "LAUNCHER_SSH_PROBE_PASSWORD" //nolint:gosec
+
+	// LauncherSSHProbePrivateKey is the env var that holds the private key to use in the ssh probe
+	// (if configured).
+	LauncherSSHProbePrivateKey = "LAUNCHER_SSH_PROBE_PRIVATE_KEY" //nolint:gosec
+
+	// LauncherGNMIProbePort is the env var that holds the port to use in the gnmi probe (if
+	// configured).
+	LauncherGNMIProbePort = "LAUNCHER_GNMI_PROBE_PORT"
+
+	// LauncherGNMIProbeInsecure is the env var that indicates the gnmi probe (if configured) should
+	// use a plain text connection.
+	LauncherGNMIProbeInsecure = "LAUNCHER_GNMI_PROBE_INSECURE"
+
+	// LauncherGNMIProbeUsername is the env var that holds the username to use in the gnmi probe (if
+	// configured).
+	LauncherGNMIProbeUsername = "LAUNCHER_GNMI_PROBE_USERNAME"
+
+	// LauncherGNMIProbePassword is the env var that holds the password to use in the gnmi probe (if
+	// configured).
+	LauncherGNMIProbePassword = "LAUNCHER_GNMI_PROBE_PASSWORD" //nolint:gosec
+
+	// LauncherNETCONFProbePort is the env var that holds the port to use in the netconf probe (if
+	// configured).
+	LauncherNETCONFProbePort = "LAUNCHER_NETCONF_PROBE_PORT"
+
+	// LauncherNETCONFProbeUsername is the env var that holds the username to use in the netconf
+	// probe (if configured).
+	LauncherNETCONFProbeUsername = "LAUNCHER_NETCONF_PROBE_USERNAME"
+
+	// LauncherNETCONFProbePassword is the env var that holds the password to use in the netconf
+	// probe (if configured).
+	LauncherNETCONFProbePassword = "LAUNCHER_NETCONF_PROBE_PASSWORD" //nolint:gosec
+
+	// LauncherNETCONFProbePrivateKey is the env var that holds the private key to use in the
+	// netconf probe (if configured).
+	LauncherNETCONFProbePrivateKey = "LAUNCHER_NETCONF_PROBE_PRIVATE_KEY" //nolint:gosec
+
+	// LauncherHTTPProbePort is the env var that holds the port to use in the http probe (if
+	// configured).
+	LauncherHTTPProbePort = "LAUNCHER_HTTP_PROBE_PORT"
+
+	// LauncherHTTPProbePath is the env var that holds the path to request in the http probe (if
+	// configured).
+	LauncherHTTPProbePath = "LAUNCHER_HTTP_PROBE_PATH"
+
+	// LauncherHTTPProbeScheme is the env var that holds the scheme to use in the http probe (if
+	// configured).
+	LauncherHTTPProbeScheme = "LAUNCHER_HTTP_PROBE_SCHEME"
+
+	// LauncherHTTPProbeStatusCode is the env var that holds the expected status code of the http
+	// probe (if configured).
+	LauncherHTTPProbeStatusCode = "LAUNCHER_HTTP_PROBE_STATUS_CODE"
+
+	// LauncherHTTPProbeBodyRegex is the env var that holds the regex the body of the http probe
+	// response must match (if configured).
+	LauncherHTTPProbeBodyRegex = "LAUNCHER_HTTP_PROBE_BODY_REGEX"
+
+	// LauncherExecProbeCommand is the env var that holds the (json encoded) command to execute in
+	// the exec probe (if configured).
+	LauncherExecProbeCommand = "LAUNCHER_EXEC_PROBE_COMMAND"
+
+	// LauncherExecProbeOutputRegex is the env var that holds the regex the output of the exec
+	// probe command must match (if configured).
+	LauncherExecProbeOutputRegex = "LAUNCHER_EXEC_PROBE_OUTPUT_REGEX"
 )
@@ -0,0 +1,44 @@
+package constants
+
+const (
+	// EventReasonReconcileFailed is the reason of the warning event emitted on a Topology when its
+	// reconciliation failed.
+	EventReasonReconcileFailed = "ReconcileFailed"
+
+	// EventReasonConfigInvalid is the reason of the warning event emitted on a Topology when its
+	// definition could not be processed.
+	EventReasonConfigInvalid = "ConfigInvalid"
+
+	// EventReasonNodeRestarted is the reason of the event emitted on a Topology when the deployment
+	// of one of its nodes has been restarted.
+	EventReasonNodeRestarted = "NodeRestarted"
+
+	// EventReasonNodeRestartFailed is the reason of the warning event emitted on a Topology when
+	// the deployment of one of its nodes failed to restart.
+	EventReasonNodeRestartFailed = "NodeRestartFailed"
+
+	// EventReasonExposeIPRejected is the reason of the warning event emitted on a Topology when the
+	// management address of a node could not be used as the LoadBalancer IP of its expose service.
+	EventReasonExposeIPRejected = "ExposeIPRejected"
+
+	// EventReasonImagePullThroughUnavailable is the reason of the warning event emitted on a
+	// Topology when image pull through is enabled but the cri socket can not be mounted in the
+	// launchers, so the launchers fall back to pulling images themselves.
+	EventReasonImagePullThroughUnavailable = "ImagePullThroughUnavailable"
+
+	// EventReasonImagePullRequested is the reason of the event emitted on a Topology when an image
+	// puller pod has been spawned for one of its nodes.
+	EventReasonImagePullRequested = "ImagePullRequested"
+
+	// EventReasonImagePullFailed is the reason of the warning event emitted on a Topology when an
+	// image requested by one of its nodes could not be pulled.
+	EventReasonImagePullFailed = "ImagePullFailed"
+
+	// EventReasonSnapshotNotReady is the reason of the warning event emitted on a Topology when the
+	// TopologySnapshot it should be restored from does not exist or is not complete yet.
+	EventReasonSnapshotNotReady = "SnapshotNotReady"
+
+	// EventReasonProbePasswordIgnored is the reason of the warning event emitted on a Topology when
+	// one of its ssh probes still sets the (deprecated) plain text password, which is ignored.
+	EventReasonProbePasswordIgnored = "ProbePasswordIgnored"
+)
@@ -12,6 +12,21 @@ const (
 
 	// KubernetesDeployment is a const to use for "deployment".
 	KubernetesDeployment = "deployment"
+
+	// KubernetesNetworkAttachmentDefinition is a const to use for "networkattachmentdefinition".
+	KubernetesNetworkAttachmentDefinition = "networkattachmentdefinition"
+)
+
+const (
+	// MultusGroupVersion is the api group/version of the multus NetworkAttachmentDefinition
+	// resource.
+	MultusGroupVersion = "k8s.cni.cncf.io/v1"
+	// MultusNetworkAttachmentDefinitionKind is the kind of the multus NetworkAttachmentDefinition
+	// resource.
+	MultusNetworkAttachmentDefinitionKind = "NetworkAttachmentDefinition"
+	// MultusNetworksAnnotation is the pod annotation telling multus which network attachments a
+	// pod should get.
+	MultusNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
 )
 
 const (
@@ -45,3 +60,17 @@ const (
 	// pods.
 	LauncherCRISockPath = "/clabernetes/.node"
 )
+
+const (
+	// FinalizerTopologyTeardown is the finalizer clabernetes sets on Topology resources so that the
+	// topology controller can gracefully tear down a Topology -- scaling down node deployments in
+	// order, giving launchers a chance to save configs, and cleaning up namespace resources --
+	// before the Topology is actually removed.
+	FinalizerTopologyTeardown = "clabernetes/teardown"
+)
+
+const (
+	// PersistenceClaimSizeDefault is the default size of the PVC created for a node when
+	// persistence is enabled and no claim size is set.
+	PersistenceClaimSizeDefault = "5Gi"
+)
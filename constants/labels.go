@@ -29,6 +29,12 @@ const (
 	// is -- that is, it is either a "connectivity" service, or an "expose" service; note that
 	// this is strictly a clabernetes concept, obviously not a kubernetes one!
 	LabelTopologyServiceType = "clabernetes/topologyServiceType"
+
+	// LabelTopologySnapshot is the label indicating the TopologySnapshot that a (saved node
+	// configuration) ConfigMap belongs to. Snapshot ConfigMaps deliberately do *not* carry the
+	// LabelTopologyOwner label, they are owned by the snapshot, not the topology -- only the copies
+	// a restored topology owns carry both.
+	LabelTopologySnapshot = "clabernetes/topologySnapshot"
 )
 
 const (
@@ -44,8 +50,8 @@ const (
 
 const (
 	// LabelIgnoreReconcile indicates that controller should ignore reconciling a given topology.
-	// Note that this basically ignored during deletion since our controller doest do anything in
-	// the delete case (owner reference handles clean up).
+	// Note that during deletion this skips the graceful teardown -- the teardown finalizer is
+	// simply removed and owner references handle clean up.
 	LabelIgnoreReconcile = "clabernetes/ignoreReconcile"
 
 	// LabelDisableDeployments indicates that controller should reconcile normally but not create
@@ -53,6 +59,13 @@ const (
 	LabelDisableDeployments = "clabernetes/disableDeployments"
 )
 
+const (
+	// AnnotationRestartNodes holds a comma separated list of nodes of a Topology whose pending
+	// restarts (with the "Manual" restart policy) should be carried out. The controller removes the
+	// annotation once it has queued the restarts.
+	AnnotationRestartNodes = "clabernetes/restartNodes"
+)
+
 const (
 	// LabelPullerImageHash is a label that holds the (shortened) hash of the image tag that the
 	// puller is trying to pull onto a node.
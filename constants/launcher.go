@@ -25,13 +25,98 @@ const (
 	// naming field of a Topology.
 	NamingModeGlobal = "global"
 
+	// RestartPolicyAutomatic is a constant representing the (default) "Automatic" restart policy
+	// of a Topology -- nodes are restarted as soon as their configuration changes.
+	RestartPolicyAutomatic = "Automatic"
+
+	// RestartPolicyManual is a constant representing the "Manual" restart policy of a Topology --
+	// nodes needing a restart are only reported in the Topology status until a restart is
+	// requested via the AnnotationRestartNodes annotation.
+	RestartPolicyManual = "Manual"
+
 	// ConnectivityVXLAN is a constant for the vxlan connectivity flavor.
 	ConnectivityVXLAN = "vxlan"
 
+	// ConnectivityGENEVE is a constant for the geneve connectivity flavor.
+	ConnectivityGENEVE = "geneve"
+
+	// ConnectivitySlurpeeth is a constant for the (userspace, tcp tunnel) slurpeeth connectivity
+	// flavor.
+	ConnectivitySlurpeeth = "slurpeeth"
+
+	// ConnectivityMultus is a constant for the multus connectivity flavor -- links are carried over
+	// multus network attachments rather than tunnels between the launcher pods.
+	ConnectivityMultus = "multus"
+
+	// MultusTypeVLAN is a constant for the (default) "vlan" cni type of the multus connectivity
+	// flavor.
+	MultusTypeVLAN = "vlan"
+
+	// MultusTypeMACVLAN is a constant for the "macvlan" cni type of the multus connectivity flavor.
+	MultusTypeMACVLAN = "macvlan"
+
+	// MultusTypeBridge is a constant for the "bridge" cni type of the multus connectivity flavor.
+	MultusTypeBridge = "bridge"
+
+	// MultusInterfacePrefix is the prefix of the (pod) interface names of the multus network
+	// attachments of a launcher -- the interface name is this prefix and the tunnel id of the link.
+	MultusInterfacePrefix = "mu"
+
+	// MultusVLANBaseDefault is the default vlan id of the first link of a topology using the multus
+	// connectivity flavor -- vlan 1 usually is the default (native) vlan so we skip it.
+	MultusVLANBaseDefault = 2
+
+	// MultusVLANIDMax is the highest usable vlan id.
+	MultusVLANIDMax = 4094
+
+	// TunnelStateCreated is the state a launcher reports for a tunnel in the Connectivity status
+	// once it has set the tunnel up.
+	TunnelStateCreated = "created"
+
+	// TunnelStateFailed is the state a launcher reports for a tunnel in the Connectivity status
+	// if it failed setting the tunnel up.
+	TunnelStateFailed = "failed"
+
+	// ProbeResultSuccess is the result a launcher reports for a status probe that succeeded the
+	// last time it ran.
+	ProbeResultSuccess = "success"
+
+	// ProbeResultFailure is the result a launcher reports for a status probe that failed the last
+	// time it ran.
+	ProbeResultFailure = "failure"
+
+	// ProbeResultsConfigMapSuffix is the suffix of the name of the ConfigMap (named after the
+	// topology) the launchers report the results of their status probes to.
+	ProbeResultsConfigMapSuffix = "probe-results"
+
+	// LinkAdminStateUp is the administrative state of a link that is up -- this is also what an
+	// unset link admin state means.
+	LinkAdminStateUp = "up"
+
+	// LinkAdminStateDown is the administrative state of a link that has been taken down.
+	LinkAdminStateDown = "down"
+
+	// SnapshotStateSaved is the state a launcher reports for a node in the TopologySnapshot status
+	// once it has stored the saved configuration of the node.
+	SnapshotStateSaved = "saved"
+
+	// SnapshotStateFailed is the state a launcher reports for a node in the TopologySnapshot
+	// status if it failed saving or storing the configuration of the node.
+	SnapshotStateFailed = "failed"
+
+	// SnapshotConfigKey is the key of the saved node configuration in the ConfigMaps created for
+	// a TopologySnapshot.
+	SnapshotConfigKey = "startup-config"
+
 	// NodeStatusFile is the file we write the node status to for launchers -- this is also used
 	// by the deployment for startup/liveness probes.
 	NodeStatusFile = "/clabernetes/.nodestatus"
 
+	// NativeModeReadyFile is the file native mode launchers write once the node interfaces have
+	// been plumbed and the tunnels set up, the startup probe of the launcher sidecar checks for it
+	// so that the node container is only started after that.
+	NativeModeReadyFile = "/clabernetes/.nativeready"
+
 	// NodeStatusHealthy is the content of hte NodeStatuesFile when/if the node in the launcher is
 	// healthy.
 	NodeStatusHealthy = "healthy"
@@ -51,4 +136,8 @@ const (
 	// NodeStatusDeploymentDisabled is reported in the topology.status.nodereadiness map when the
 	// parent topology has the "clabernetes/disableDeployments" label set.
 	NodeStatusDeploymentDisabled = "deploymentDisabled"
+
+	// NodeStatusPaused is reported in the topology.status.nodereadiness map for all nodes while the
+	// parent topology is paused.
+	NodeStatusPaused = "paused"
 )
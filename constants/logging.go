@@ -14,3 +14,21 @@ const (
 	// Disabled is the disabled (no logging) log level.
 	Disabled = "disabled"
 )
+
+const (
+	// LogFormatText is the (default) human oriented text log format.
+	LogFormatText = "text"
+	// LogFormatJSON is the structured json log format.
+	LogFormatJSON = "json"
+)
+
+const (
+	// LogFieldTopology is the contextual log field holding the name of the topology.
+	LogFieldTopology = "topology"
+	// LogFieldNamespace is the contextual log field holding the namespace.
+	LogFieldNamespace = "namespace"
+	// LogFieldNode is the contextual log field holding the name of the (topology) node.
+	LogFieldNode = "node"
+	// LogFieldReconcileID is the contextual log field holding the controller-runtime reconcile id.
+	LogFieldReconcileID = "reconcileID"
+)
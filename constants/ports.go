@@ -45,4 +45,12 @@ const (
 
 	// HealthProbePort is the port number for kubernetes health endpoints to run on.
 	HealthProbePort = 8080
+
+	// LauncherCapturePort is the port number the launcher serves (pcap) packet captures of its
+	// node(s) interfaces on.
+	LauncherCapturePort = 10445
+
+	// LauncherMetricsPort is the port number the launcher serves (prometheus) metrics of its node
+	// and tunnel interfaces on.
+	LauncherMetricsPort = 10446
 )
@@ -11,4 +11,9 @@ const (
 	// where we handle puller pod requests and in the launcher when we wait for the image to be
 	// available.
 	PullerPodTimeout = 5 * time.Minute
+
+	// LauncherWaitForTimeout is the max time a launcher waits for the nodes its node(s) depend on
+	// (via containerlab wait-for) to become ready before launching anyway. The controller extends
+	// the startup probe of launchers that wait on other nodes by this much too.
+	LauncherWaitForTimeout = 10 * time.Minute
 )
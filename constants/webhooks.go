@@ -0,0 +1,22 @@
+package constants
+
+const (
+	// WebhookMutateTopologyPath is the http path the Topology defaulting (mutating) webhook is
+	// served on.
+	WebhookMutateTopologyPath = "/mutate-topology"
+
+	// WebhookValidateTopologyPath is the http path the Topology validating webhook is served on.
+	WebhookValidateTopologyPath = "/validate-topology"
+
+	// WebhookMutateConfigPath is the http path the Config defaulting (mutating) webhook is served
+	// on.
+	WebhookMutateConfigPath = "/mutate-config"
+
+	// WebhookValidateConfigPath is the http path the Config validating webhook is served on.
+	WebhookValidateConfigPath = "/validate-config"
+)
+
+const (
+	// WebhookTimeoutSeconds is the timeout value set on the clabernetes webhook configurations.
+	WebhookTimeoutSeconds = 10
+)
@@ -9,8 +9,10 @@ import (
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
 	clientgorest "k8s.io/client-go/rest"
+	clientgorecord "k8s.io/client-go/tools/record"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimecontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 // NewController defines a function that creates and returns a clabernetes Controller object.
@@ -42,6 +44,13 @@ type BaseController struct {
 	Log                 claberneteslogging.Instance
 	Config              *clientgorest.Config
 	Client              ctrlruntimeclient.Client
+	// Recorder is the event recorder of the controller, controllers use it to emit events on the
+	// objects they reconcile so users can see what happened without access to the manager logs.
+	Recorder clientgorecord.EventRecorder
+
+	// kind is the kind of the resource the controller reconciles, it is used as the log field name
+	// for the name of the object under reconciliation.
+	kind string
 }
 
 // NewBaseController returns a new BaseController object to embed in clabernetes controllers.
@@ -51,6 +60,7 @@ func NewBaseController(
 	appName string,
 	config *clientgorest.Config,
 	client ctrlruntimeclient.Client,
+	recorder clientgorecord.EventRecorder,
 ) *BaseController {
 	logManager := claberneteslogging.GetManager()
 
@@ -71,37 +81,65 @@ func NewBaseController(
 		Log:                 logger,
 		Config:              config,
 		Client:              client,
+		Recorder:            recorder,
+		kind:                controllerName,
 	}
 }
 
+// ReconcileLog returns the logging instance of the controller carrying the namespace, name and
+// reconcile id of the given reconcile request as contextual fields.
+func (c *BaseController) ReconcileLog(
+	ctx context.Context,
+	req ctrlruntime.Request,
+) claberneteslogging.Instance {
+	return c.Log.WithFields(map[string]string{
+		clabernetesconstants.LogFieldNamespace: req.Namespace,
+		c.kind:                                 req.Name,
+		clabernetesconstants.LogFieldReconcileID: string(
+			ctrlruntimecontroller.ReconcileIDFromContext(ctx),
+		),
+	})
+}
+
 // LogReconcileStart is a convenience/consistency function to log the start of a reconcile event.
-func (c *BaseController) LogReconcileStart(req ctrlruntime.Request) {
-	c.Log.Info("reconcile started")
-	c.Log.Debugf("reconcile request namespace/name: %s/%s", req.Namespace, req.Name)
+func (c *BaseController) LogReconcileStart(ctx context.Context, req ctrlruntime.Request) {
+	log := c.ReconcileLog(ctx, req)
+
+	log.Info("reconcile started")
+	log.Debugf("reconcile request namespace/name: %s/%s", req.Namespace, req.Name)
 }
 
 // LogReconcileStartDelete is a convenience/consistency function to log the start of a *delete*
 // reconcile event.
-func (c *BaseController) LogReconcileStartDelete(_ ctrlruntime.Request) {
-	c.Log.Info("resource is deleting, handling deletion tasks")
+func (c *BaseController) LogReconcileStartDelete(ctx context.Context, req ctrlruntime.Request) {
+	c.ReconcileLog(ctx, req).Info("resource is deleting, handling deletion tasks")
 }
 
 // LogReconcileCompleteSuccess is a convenience/consistency function to log the successful
 // completion of a reconcile.
-func (c *BaseController) LogReconcileCompleteSuccess(_ ctrlruntime.Request) {
-	c.Log.Info("reconcile completed successfully")
+func (c *BaseController) LogReconcileCompleteSuccess(ctx context.Context, req ctrlruntime.Request) {
+	c.ReconcileLog(ctx, req).Info("reconcile completed successfully")
 }
 
 // LogReconcileCompleteObjectNotExist is a convenience/consistency function to log the successful
 // completion of a reconcile when an object doesn't exist anymore.
-func (c *BaseController) LogReconcileCompleteObjectNotExist(_ ctrlruntime.Request) {
-	c.Log.Info("object no longer exists, reconcile completed successfully")
+func (c *BaseController) LogReconcileCompleteObjectNotExist(
+	ctx context.Context,
+	req ctrlruntime.Request,
+) {
+	c.ReconcileLog(ctx, req).Info("object no longer exists, reconcile completed successfully")
 }
 
 // LogReconcileFailedGettingObject is a convenience/consistency function to log an error on failure
 // to get the object under reconciliation.
-func (c *BaseController) LogReconcileFailedGettingObject(req ctrlruntime.Request, err error) {
-	c.Log.Criticalf("failed fetching '%s/%s', error: %s", req.Namespace, req.Name, err)
+func (c *BaseController) LogReconcileFailedGettingObject(
+	ctx context.Context,
+	req ctrlruntime.Request,
+	err error,
+) {
+	c.ReconcileLog(ctx, req).Criticalf(
+		"failed fetching '%s/%s', error: %s", req.Namespace, req.Name, err,
+	)
 }
 
 // ShouldIgnoreReconcile checks if the given object has the LabelIgnoreReconcile label, if so, it
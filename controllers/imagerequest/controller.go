@@ -1,6 +1,8 @@
 package imagerequest
 
 import (
+	"fmt"
+
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetescontrollers "github.com/srl-labs/clabernetes/controllers"
@@ -26,6 +28,9 @@ func NewController(
 		clabernetes.GetAppName(),
 		clabernetes.GetKubeConfig(),
 		clabernetes.GetCtrlRuntimeClient(),
+		clabernetes.GetCtrlRuntimeMgr().GetEventRecorderFor(
+			fmt.Sprintf("%s-%s", clabernetes.GetAppName(), clabernetesapis.ImageRequest),
+		),
 	)
 
 	c := &Controller{
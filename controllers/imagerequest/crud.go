@@ -28,6 +28,25 @@ func (c *Controller) getImageRequestFromReq(
 	return imageRequest, err
 }
 
+// getOwningTopology fetches the Topology that the given ImageRequest was created for.
+func (c *Controller) getOwningTopology(
+	ctx context.Context,
+	imageRequest *clabernetesapisv1alpha1.ImageRequest,
+) (*clabernetesapisv1alpha1.Topology, error) {
+	topology := &clabernetesapisv1alpha1.Topology{}
+
+	err := c.BaseController.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: imageRequest.Namespace,
+			Name:      imageRequest.Spec.TopologyName,
+		},
+		topology,
+	)
+
+	return topology, err
+}
+
 func (c *Controller) update(
 	ctx context.Context,
 	imageRequest *clabernetesapisv1alpha1.ImageRequest,
@@ -3,10 +3,12 @@ package imagerequest
 import (
 	"context"
 	"fmt"
+	"time"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconfig "github.com/srl-labs/clabernetes/config"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesmetrics "github.com/srl-labs/clabernetes/metrics"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
 	k8scorev1 "k8s.io/api/core/v1"
@@ -26,17 +28,17 @@ func (c *Controller) Reconcile(
 	ctx context.Context,
 	req ctrlruntime.Request,
 ) (ctrlruntime.Result, error) {
-	c.BaseController.LogReconcileStart(req)
+	c.BaseController.LogReconcileStart(ctx, req)
 
 	imageRequest, err := c.getImageRequestFromReq(ctx, req)
 	if err != nil {
 		if apimachineryerrors.IsNotFound(err) {
-			c.BaseController.LogReconcileCompleteObjectNotExist(req)
+			c.BaseController.LogReconcileCompleteObjectNotExist(ctx, req)
 
 			return ctrlruntime.Result{}, nil
 		}
 
-		c.BaseController.LogReconcileFailedGettingObject(req, err)
+		c.BaseController.LogReconcileFailedGettingObject(ctx, req, err)
 
 		return ctrlruntime.Result{}, err
 	}
@@ -58,13 +60,37 @@ func (c *Controller) Reconcile(
 		return ctrlruntime.Result{Requeue: true}, nil
 	}
 
+	pullStart := time.Now()
+
 	pullerPodName, err := c.spawnImagePullerPod(ctx, imageRequest)
 	if err != nil {
+		clabernetesmetrics.RecordImagePull(time.Since(pullStart), err)
+
+		c.recordImagePullFailed(ctx, imageRequest, err)
+
 		return ctrlruntime.Result{}, err
 	}
 
+	c.recordTopologyEvent(
+		ctx,
+		imageRequest,
+		k8scorev1.EventTypeNormal,
+		clabernetesconstants.EventReasonImagePullRequested,
+		fmt.Sprintf(
+			"pulling image %q for node %q on kubernetes node %q",
+			imageRequest.Spec.RequestedImage,
+			imageRequest.Spec.TopologyNodeName,
+			imageRequest.Spec.KubernetesNode,
+		),
+	)
+
 	err = c.waitImagePullerPodOutOfPending(ctx, imageRequest.Namespace, pullerPodName)
+
+	clabernetesmetrics.RecordImagePull(time.Since(pullStart), err)
+
 	if err != nil {
+		c.recordImagePullFailed(ctx, imageRequest, err)
+
 		return ctrlruntime.Result{}, err
 	}
 
@@ -91,6 +117,51 @@ func (c *Controller) Reconcile(
 	return ctrlruntime.Result{}, nil
 }
 
+func (c *Controller) recordImagePullFailed(
+	ctx context.Context,
+	imageRequest *clabernetesapisv1alpha1.ImageRequest,
+	err error,
+) {
+	c.recordTopologyEvent(
+		ctx,
+		imageRequest,
+		k8scorev1.EventTypeWarning,
+		clabernetesconstants.EventReasonImagePullFailed,
+		fmt.Sprintf(
+			"failed pulling image %q for node %q on kubernetes node %q, error: %s",
+			imageRequest.Spec.RequestedImage,
+			imageRequest.Spec.TopologyNodeName,
+			imageRequest.Spec.KubernetesNode,
+			err,
+		),
+	)
+}
+
+// recordTopologyEvent emits an event on the Topology the given ImageRequest was created for --
+// image requests are deleted once handled, so events on them would not be of much use to users.
+func (c *Controller) recordTopologyEvent(
+	ctx context.Context,
+	imageRequest *clabernetesapisv1alpha1.ImageRequest,
+	eventType,
+	reason,
+	message string,
+) {
+	topology, err := c.getOwningTopology(ctx, imageRequest)
+	if err != nil {
+		c.Log.Warnf(
+			"failed fetching topology '%s/%s' to record %q event, error: %s",
+			imageRequest.Namespace,
+			imageRequest.Spec.TopologyName,
+			reason,
+			err,
+		)
+
+		return
+	}
+
+	c.Recorder.Event(topology, eventType, reason, message)
+}
+
 func (c *Controller) spawnImagePullerPod(
 	ctx context.Context,
 	imageRequest *clabernetesapisv1alpha1.ImageRequest,
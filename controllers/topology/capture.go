@@ -0,0 +1,193 @@
+package topology
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"os"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+// captureCertificateSecretName returns the name of the Secret holding the capture server
+// certificate of the launchers of the given topology.
+func captureCertificateSecretName(owningTopologyName string) string {
+	return clabernetesutilkubernetes.SafeConcatNameKubernetes(owningTopologyName, "capture")
+}
+
+// loadCaptureCA loads our certificate authority, returning the pem encoded certificate (for the
+// launchers to verify clients with) and the certificate with its key (to issue the capture server
+// certificates of the launchers with).
+func loadCaptureCA() (string, *tls.Certificate, error) {
+	caDirectory := fmt.Sprintf(
+		"%s/%s",
+		clabernetesconstants.CertificateDirectory,
+		clabernetesconstants.CertificateAuthoritySubDir,
+	)
+
+	caPEM, err := os.ReadFile(fmt.Sprintf("%s/ca.crt", caDirectory))
+	if err != nil {
+		return "", nil, err
+	}
+
+	ca, err := tls.LoadX509KeyPair(
+		fmt.Sprintf("%s/tls.crt", caDirectory),
+		fmt.Sprintf("%s/tls.key", caDirectory),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return string(caPEM), &ca, nil
+}
+
+// captureCertificateValid returns true if the given (pem encoded) certificate was issued by the
+// given certificate authority for the given server name and is not expired.
+func captureCertificateValid(rawCert []byte, ca *x509.Certificate, serverName string) bool {
+	block, _ := pem.Decode(rawCert)
+	if block == nil {
+		return false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:   serverName,
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+
+	return err == nil
+}
+
+// issueCaptureCertificate issues a capture server certificate for the launchers of the given
+// topology from our certificate authority.
+func (c *Controller) issueCaptureCertificate(
+	topology *clabernetesapisv1alpha1.Topology,
+) (*clabernetesutil.CertData, error) {
+	cert := clabernetesutil.CreateClientCertificate("launcher")
+	cert.DNSNames = []string{
+		clabernetesutil.LauncherCaptureServerName(topology.Namespace, topology.Name),
+	}
+
+	key := clabernetesutil.MustGeneratePrivateKey(clabernetesconstants.KeySize)
+
+	certBytes, err := x509.CreateCertificate(
+		cryptorand.Reader,
+		cert,
+		c.captureCA.Leaf,
+		&key.PublicKey,
+		c.captureCA.PrivateKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return clabernetesutil.GenerateCertificateData(certBytes, c.captureCA.Certificate[0], key)
+}
+
+// reconcileCaptureCertificate ensures the topology owns a Secret holding a capture server
+// certificate for its launchers, issued by our certificate authority, so we can verify the
+// launchers we stream captures from. The certificate is only (re)issued when it is missing or no
+// longer valid for our authority.
+func (c *Controller) reconcileCaptureCertificate(
+	ctx context.Context,
+	topology *clabernetesapisv1alpha1.Topology,
+) error {
+	if c.captureCA == nil {
+		// launchers don't serve captures without our authority, so there is nothing to secure
+		return nil
+	}
+
+	if c.captureCA.Leaf == nil {
+		return fmt.Errorf(
+			"%w: certificate authority has no parsed certificate", claberneteserrors.ErrCapture,
+		)
+	}
+
+	annotations, globalLabels := c.TopologyReconciler.configMapReconciler.configManagerGetter().
+		GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          topology.Name,
+		clabernetesconstants.LabelTopologyOwner: topology.Name,
+		clabernetesconstants.LabelTopologyKind:  GetTopologyKind(topology),
+	}
+
+	maps.Copy(labels, globalLabels)
+
+	serverName := clabernetesutil.LauncherCaptureServerName(topology.Namespace, topology.Name)
+
+	secret := &k8scorev1.Secret{}
+
+	err := c.BaseController.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: topology.Namespace,
+			Name:      captureCertificateSecretName(topology.Name),
+		},
+		secret,
+	)
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return err
+	}
+
+	exists := err == nil
+
+	if exists && captureCertificateValid(
+		secret.Data[k8scorev1.TLSCertKey],
+		c.captureCA.Leaf,
+		serverName,
+	) {
+		return nil
+	}
+
+	certData, err := c.issueCaptureCertificate(topology)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		k8scorev1.TLSCertKey:       certData.TLS,
+		k8scorev1.TLSPrivateKeyKey: certData.Key,
+	}
+
+	if !exists {
+		secret = &k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        captureCertificateSecretName(topology.Name),
+				Namespace:   topology.Namespace,
+				Annotations: annotations,
+				Labels:      labels,
+			},
+			Type: k8scorev1.SecretTypeTLS,
+			Data: data,
+		}
+
+		return c.TopologyReconciler.createObj(ctx, topology, secret, "Secret")
+	}
+
+	secret.Labels = labels
+	secret.Data = data
+
+	return c.TopologyReconciler.updateObj(ctx, secret, "Secret")
+}
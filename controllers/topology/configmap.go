@@ -80,10 +80,16 @@ func (r *ConfigMapReconciler) Render(
 		data[nodeName] = string(yamlNodeTopo)
 	}
 
-	for nodeName, nodeFilesFromURL := range filesFromURL {
-		// ignore bad node names
-		_, nodeOk := clabernetesConfigs[nodeName]
-		if !nodeOk {
+	for nodeName := range clabernetesConfigs {
+		// with node grouping a launcher may be responsible for more than one node, so we gather up
+		// the files of all of them; bad node names are simply ignored since we never look them up
+		nodeFilesFromURL := make([]clabernetesapisv1alpha1.FileFromURL, 0)
+
+		for _, launcherNodeName := range getLauncherNodeNames(clabernetesConfigs, nodeName) {
+			nodeFilesFromURL = append(nodeFilesFromURL, filesFromURL[launcherNodeName]...)
+		}
+
+		if len(nodeFilesFromURL) == 0 {
 			continue
 		}
 
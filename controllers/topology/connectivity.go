@@ -1,7 +1,10 @@
 package topology
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconfig "github.com/srl-labs/clabernetes/config"
@@ -100,3 +103,75 @@ func (r *ConnectivityReconciler) Conforms(
 
 	return true
 }
+
+// LinksReadyCondition returns the LinksReady condition for the Topology based on the desired
+// tunnels and the tunnel statuses the launchers reported in the Connectivity cr. A tunnel only
+// counts as created if its launcher reported it as such for the current tunnel id.
+func (r *ConnectivityReconciler) LinksReadyCondition(
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+	tunnelStatuses map[string][]*clabernetesapisv1alpha1.PointToPointTunnelStatus,
+) metav1.Condition {
+	var tunnelCount int
+
+	failedTunnels := make([]string, 0)
+	pendingTunnels := make([]string, 0)
+
+	for launcherName, launcherTunnels := range tunnels {
+		for _, tunnel := range launcherTunnels {
+			tunnelCount++
+
+			tunnelName := fmt.Sprintf("%s/%s", tunnel.LocalNode, tunnel.LocalInterface)
+
+			idx := slices.IndexFunc(
+				tunnelStatuses[launcherName],
+				func(tunnelStatus *clabernetesapisv1alpha1.PointToPointTunnelStatus) bool {
+					return tunnelStatus.LocalNode == tunnel.LocalNode &&
+						tunnelStatus.LocalInterface == tunnel.LocalInterface &&
+						tunnelStatus.TunnelID == tunnel.TunnelID
+				},
+			)
+
+			switch {
+			case idx == -1:
+				pendingTunnels = append(pendingTunnels, tunnelName)
+			case tunnelStatuses[launcherName][idx].State != clabernetesconstants.TunnelStateCreated:
+				failedTunnels = append(failedTunnels, tunnelName)
+			}
+		}
+	}
+
+	if len(failedTunnels) > 0 {
+		slices.Sort(failedTunnels)
+
+		return metav1.Condition{
+			Type:   clabernetesconstants.ConditionLinksReady,
+			Status: metav1.ConditionFalse,
+			Reason: clabernetesconstants.LinksReadyReasonTunnelsFailed,
+			Message: fmt.Sprintf(
+				"tunnel(s) for link(s) %s report failed, check connectivity status for more"+
+					" information",
+				strings.Join(failedTunnels, ", "),
+			),
+		}
+	}
+
+	if len(pendingTunnels) > 0 {
+		return metav1.Condition{
+			Type:   clabernetesconstants.ConditionLinksReady,
+			Status: metav1.ConditionFalse,
+			Reason: clabernetesconstants.LinksReadyReasonTunnelsPending,
+			Message: fmt.Sprintf(
+				"%d of %d tunnel(s) not yet reported created",
+				len(pendingTunnels),
+				tunnelCount,
+			),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    clabernetesconstants.ConditionLinksReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  clabernetesconstants.LinksReadyReasonTunnelsCreated,
+		Message: "all tunnels report created",
+	}
+}
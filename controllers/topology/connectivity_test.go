@@ -7,13 +7,17 @@ import (
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
 	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const renderConnectivityTestName = "connectivity/render-connectivity"
+const (
+	renderConnectivityTestName                 = "connectivity/render-connectivity"
+	renderNetworkAttachmentDefinitionsTestName = "connectivity/render-network-attachment-definitions"
+)
 
 func TestRenderConnectivity(t *testing.T) {
 	cases := []struct {
@@ -136,3 +140,276 @@ func TestRenderConnectivity(t *testing.T) {
 			})
 	}
 }
+
+func TestRenderNetworkAttachmentDefinitions(t *testing.T) {
+	tunnels := map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+		"srl1": {
+			{
+				TunnelID:        1,
+				LocalNode:       "srl1",
+				RemoteNode:      "srl2",
+				LocalInterface:  "e1-1",
+				RemoteInterface: "e1-1",
+			},
+			{
+				TunnelID:        2,
+				LocalNode:       "srl1",
+				RemoteNode:      "srl2",
+				LocalInterface:  "e1-2",
+				RemoteInterface: "e1-2",
+			},
+		},
+		"srl2": {
+			{
+				TunnelID:        1,
+				LocalNode:       "srl2",
+				RemoteNode:      "srl1",
+				LocalInterface:  "e1-1",
+				RemoteInterface: "e1-1",
+			},
+			{
+				TunnelID:        2,
+				LocalNode:       "srl2",
+				RemoteNode:      "srl1",
+				LocalInterface:  "e1-2",
+				RemoteInterface: "e1-2",
+			},
+		},
+	}
+
+	cases := []struct {
+		name   string
+		multus clabernetesapisv1alpha1.Multus
+	}{
+		{
+			name: "vlan",
+			multus: clabernetesapisv1alpha1.Multus{
+				Master: "eth1",
+			},
+		},
+		{
+			name: "macvlan",
+			multus: clabernetesapisv1alpha1.Multus{
+				Type:   clabernetesconstants.MultusTypeMACVLAN,
+				Master: "eth1",
+				MTU:    9000,
+			},
+		},
+		{
+			name: "bridge",
+			multus: clabernetesapisv1alpha1.Multus{
+				Type:   clabernetesconstants.MultusTypeBridge,
+				Master: "br-clab",
+			},
+		},
+		{
+			name: "vlan-base",
+			multus: clabernetesapisv1alpha1.Multus{
+				Master:   "eth1",
+				VLANBase: 100,
+			},
+		},
+		{
+			name: "vlan-range-exceeded",
+			multus: clabernetesapisv1alpha1.Multus{
+				Master:   "eth1",
+				VLANBase: 4094,
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				reconciler := clabernetescontrollerstopology.NewConnectivityReconciler(
+					&claberneteslogging.FakeInstance{},
+					clabernetesconfig.GetFakeManager,
+				)
+
+				got := reconciler.RenderNetworkAttachmentDefinitions(
+					&clabernetesapisv1alpha1.Topology{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "render-nad-test",
+							Namespace: "clabernetes",
+						},
+						Spec: clabernetesapisv1alpha1.TopologySpec{
+							Connectivity: clabernetesconstants.ConnectivityMultus,
+							Multus:       testCase.multus,
+						},
+					},
+					tunnels,
+				)
+
+				if *clabernetestesthelper.Update {
+					clabernetestesthelper.WriteTestFixtureJSON(
+						t,
+						fmt.Sprintf(
+							"golden/%s/%s.json",
+							renderNetworkAttachmentDefinitionsTestName,
+							testCase.name,
+						),
+						got,
+					)
+				}
+
+				var want []map[string]any
+
+				err := json.Unmarshal(
+					clabernetestesthelper.ReadTestFixtureFile(
+						t,
+						fmt.Sprintf(
+							"golden/%s/%s.json",
+							renderNetworkAttachmentDefinitionsTestName,
+							testCase.name,
+						),
+					),
+					&want,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				clabernetestesthelper.MarshaledEqual(t, got, want)
+			})
+	}
+}
+
+func TestLinksReadyCondition(t *testing.T) {
+	tunnels := map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+		"srl1": {
+			{
+				TunnelID:        1,
+				LocalNode:       "srl1",
+				Destination:     "topo-1-srl2.clabernetes.svc.cluster.local",
+				RemoteNode:      "srl2",
+				LocalInterface:  "e1-1",
+				RemoteInterface: "e1-1",
+			},
+		},
+		"srl2": {
+			{
+				TunnelID:        1,
+				LocalNode:       "srl2",
+				Destination:     "topo-1-srl1.clabernetes.svc.cluster.local",
+				RemoteNode:      "srl1",
+				LocalInterface:  "e1-1",
+				RemoteInterface: "e1-1",
+			},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		tunnels        map[string][]*clabernetesapisv1alpha1.PointToPointTunnel
+		tunnelStatuses map[string][]*clabernetesapisv1alpha1.PointToPointTunnelStatus
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "no-tunnels",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "TunnelsCreated",
+		},
+		{
+			name:           "nothing-reported",
+			tunnels:        tunnels,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "TunnelsPending",
+		},
+		{
+			name:    "all-created",
+			tunnels: tunnels,
+			tunnelStatuses: map[string][]*clabernetesapisv1alpha1.PointToPointTunnelStatus{
+				"srl1": {
+					{
+						TunnelID:       1,
+						LocalNode:      "srl1",
+						LocalInterface: "e1-1",
+						State:          "created",
+					},
+				},
+				"srl2": {
+					{
+						TunnelID:       1,
+						LocalNode:      "srl2",
+						LocalInterface: "e1-1",
+						State:          "created",
+					},
+				},
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "TunnelsCreated",
+		},
+		{
+			name:    "stale-tunnel-id",
+			tunnels: tunnels,
+			tunnelStatuses: map[string][]*clabernetesapisv1alpha1.PointToPointTunnelStatus{
+				"srl1": {
+					{
+						TunnelID:       1,
+						LocalNode:      "srl1",
+						LocalInterface: "e1-1",
+						State:          "created",
+					},
+				},
+				"srl2": {
+					{
+						TunnelID:       2,
+						LocalNode:      "srl2",
+						LocalInterface: "e1-1",
+						State:          "created",
+					},
+				},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "TunnelsPending",
+		},
+		{
+			name:    "one-failed",
+			tunnels: tunnels,
+			tunnelStatuses: map[string][]*clabernetesapisv1alpha1.PointToPointTunnelStatus{
+				"srl1": {
+					{
+						TunnelID:       1,
+						LocalNode:      "srl1",
+						LocalInterface: "e1-1",
+						State:          "failed",
+						LastError:      "did not get exactly one ip resolved",
+					},
+				},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "TunnelsFailed",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				reconciler := clabernetescontrollerstopology.NewConnectivityReconciler(
+					&claberneteslogging.FakeInstance{},
+					clabernetesconfig.GetFakeManager,
+				)
+
+				got := reconciler.LinksReadyCondition(testCase.tunnels, testCase.tunnelStatuses)
+
+				if got.Type != "LinksReady" {
+					clabernetestesthelper.FailOutput(t, got.Type, "LinksReady")
+				}
+
+				if got.Status != testCase.expectedStatus {
+					clabernetestesthelper.FailOutput(t, got.Status, testCase.expectedStatus)
+				}
+
+				if got.Reason != testCase.expectedReason {
+					clabernetestesthelper.FailOutput(t, got.Reason, testCase.expectedReason)
+				}
+			})
+	}
+}
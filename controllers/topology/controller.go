@@ -2,6 +2,8 @@ package topology
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
@@ -22,6 +24,10 @@ import (
 type Controller struct {
 	*clabernetescontrollers.BaseController
 	TopologyReconciler *Reconciler
+
+	// captureCA is our certificate authority (with its key), used to issue the capture server
+	// certificates of the launchers; nil if it could not be loaded.
+	captureCA *tls.Certificate
 }
 
 // NewController returns a new Controller.
@@ -36,16 +42,34 @@ func NewController(
 		clabernetes.GetAppName(),
 		clabernetes.GetKubeConfig(),
 		clabernetes.GetCtrlRuntimeClient(),
+		clabernetes.GetCtrlRuntimeMgr().GetEventRecorderFor(
+			fmt.Sprintf("%s-%s", clabernetes.GetAppName(), clabernetesapis.Topology),
+		),
 	)
 
+	// launchers only serve packet captures to clients presenting a certificate signed by our
+	// certificate authority (that is, to the manager), so they need to know the authority; the
+	// authority also issues the server certificates of the launchers so we can verify them
+	captureCAPEM, captureCA, err := loadCaptureCA()
+	if err != nil {
+		baseController.Log.Warnf(
+			"failed loading certificate authority, launchers will not serve packet captures,"+
+				" error: %s",
+			err,
+		)
+	}
+
 	c := &Controller{
 		BaseController: baseController,
+		captureCA:      captureCA,
 		TopologyReconciler: NewReconciler(
 			baseController.Log,
 			baseController.Client,
+			baseController.Recorder,
 			clabernetes.GetAppName(),
 			clabernetes.GetNamespace(),
 			clabernetes.GetClusterCRIKind(),
+			captureCAPEM,
 			clabernetesconfig.GetManager,
 		),
 	}
@@ -87,6 +111,34 @@ func (c *Controller) SetupWithManager(mgr ctrlruntime.Manager) error {
 				&clabernetesapisv1alpha1.Topology{},
 			),
 		).
+		// watch owned connectivity crs so we can roll the tunnel status reported by the launchers
+		// up into the topology conditions
+		Watches(
+			&clabernetesapisv1alpha1.Connectivity{},
+			ctrlruntimehandler.EnqueueRequestForOwner(
+				mgr.GetScheme(),
+				mgr.GetRESTMapper(),
+				&clabernetesapisv1alpha1.Topology{},
+			),
+		).
+		// watch owned configmaps so we can roll the status probe results reported by the launchers
+		// up into the topology status
+		Watches(
+			&k8scorev1.ConfigMap{},
+			ctrlruntimehandler.EnqueueRequestForOwner(
+				mgr.GetScheme(),
+				mgr.GetRESTMapper(),
+				&clabernetesapisv1alpha1.Topology{},
+			),
+		).
+		// watch snapshots so topologies waiting on a snapshot to restore from get reconciled once
+		// the snapshot completes
+		Watches(
+			&clabernetesapisv1alpha1.TopologySnapshot{},
+			ctrlruntimehandler.EnqueueRequestsFromMapFunc(
+				c.enqueueForSnapshot,
+			),
+		).
 		// watch our config cr too so we get any config updates handled
 		Watches(
 			&clabernetesapisv1alpha1.Config{},
@@ -124,3 +176,38 @@ func (c *Controller) enqueueForAll(
 
 	return requests
 }
+
+// enqueueForSnapshot enqueues the Topology CRs that are restored from the given TopologySnapshot.
+func (c *Controller) enqueueForSnapshot(
+	ctx context.Context,
+	snapshot ctrlruntimeclient.Object,
+) []ctrlruntimereconcile.Request {
+	topologies := &clabernetesapisv1alpha1.TopologyList{}
+
+	err := c.Client.List(ctx, topologies, ctrlruntimeclient.InNamespace(snapshot.GetNamespace()))
+	if err != nil {
+		c.Log.Criticalf("failed listing resource objects in enqueueForSnapshot, err: %s", err)
+
+		return nil
+	}
+
+	var requests []ctrlruntimereconcile.Request
+
+	for idx := range topologies.Items {
+		if topologies.Items[idx].Spec.Deployment.RestoreFromSnapshot != snapshot.GetName() {
+			continue
+		}
+
+		requests = append(
+			requests,
+			ctrlruntimereconcile.Request{
+				NamespacedName: apimachinerytypes.NamespacedName{
+					Namespace: topologies.Items[idx].GetNamespace(),
+					Name:      topologies.Items[idx].GetName(),
+				},
+			},
+		)
+	}
+
+	return requests
+}
@@ -125,6 +125,82 @@ func TestDefinitionProcess(t *testing.T) {
 			},
 			removeTopologyPrefix: false,
 		},
+		{
+			name: "containerlab-extended-links",
+			inTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "process-containerlab-definition-extended-links-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+      links:
+        - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+          mtu: 9000
+        - type: veth
+          mtu: 9100
+          endpoints:
+            - node: srl1
+              interface: e1-2
+              mac: 02:00:00:00:00:01
+            - node: srl2
+              interface: e1-2
+              mac: 02:00:00:00:00:02
+        - type: veth
+          endpoints:
+            - node: srl1
+              interface: e1-3
+            - node: srl1
+              interface: e1-4
+        - type: mgmt-net
+          endpoint:
+            node: srl1
+            interface: e1-5
+          host-interface: srl1-e1-5
+        - type: macvlan
+          endpoint:
+            node: srl2
+            interface: e1-5
+          host-interface: eth0
+          mode: bridge
+        - type: host
+          endpoint:
+            node: srl2
+            interface: e1-6
+          host-interface: srl2-e1-6
+        - type: dummy
+          endpoint:
+            node: srl1
+            interface: e1-6
+        - endpoints: ["mgmt-net:srl2-e1-7", "srl2:e1-7"]
+`,
+					},
+				},
+			},
+			reconcileData: &clabernetescontrollerstopology.ReconcileData{
+				Kind:           "containerlab",
+				ResolvedHashes: clabernetesapisv1alpha1.ReconcileHashes{},
+				ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{
+					"srl1": {},
+					"srl2": {},
+				},
+				ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+					"srl1": {},
+					"srl2": {},
+				},
+			},
+			removeTopologyPrefix: false,
+		},
 		{
 			name: "containerlab-simple",
 			inTopology: &clabernetesapisv1alpha1.Topology{
@@ -206,6 +282,201 @@ func TestDefinitionProcess(t *testing.T) {
 			},
 			removeTopologyPrefix: true,
 		},
+		{
+			name: "containerlab-node-groups",
+			inTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "process-containerlab-definition-node-groups-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      defaults:
+        ports:
+          - 8080:80/tcp
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        client1:
+          kind: linux
+          image: alpine
+          ports:
+            - 2222:22/tcp
+        client2:
+          kind: linux
+          image: alpine
+      links:
+        - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+        - endpoints: ["srl1:e1-2", "client1:eth1"]
+        - endpoints: ["srl2:e1-2", "client2:eth1"]
+        - endpoints: ["client1:eth2", "client2:eth2"]
+`,
+					},
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						NodeGrouping: clabernetesapisv1alpha1.NodeGrouping{
+							Groups: map[string][]string{
+								"pod1": {"srl1", "client1"},
+							},
+						},
+					},
+				},
+			},
+			reconcileData: &clabernetescontrollerstopology.ReconcileData{
+				Kind:            "containerlab",
+				ResolvedHashes:  clabernetesapisv1alpha1.ReconcileHashes{},
+				ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{},
+				ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+			},
+			removeTopologyPrefix: false,
+		},
+		{
+			name: "containerlab-containerlab-groups",
+			inTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "process-containerlab-definition-containerlab-groups-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      kinds:
+        linux:
+          group: clients
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+          group: spines
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+          group: spines
+        client1:
+          kind: linux
+          image: alpine
+        client2:
+          kind: linux
+          image: alpine
+      links:
+        - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+        - endpoints: ["srl1:e1-2", "client1:eth1"]
+        - endpoints: ["srl2:e1-2", "client2:eth1"]
+`,
+					},
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						NodeGrouping: clabernetesapisv1alpha1.NodeGrouping{
+							ContainerlabGroups: true,
+						},
+					},
+				},
+			},
+			reconcileData: &clabernetescontrollerstopology.ReconcileData{
+				Kind:            "containerlab",
+				ResolvedHashes:  clabernetesapisv1alpha1.ReconcileHashes{},
+				ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{},
+				ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+			},
+			removeTopologyPrefix: false,
+		},
+		{
+			name: "containerlab-link-impairments-and-admin-states",
+			inTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "process-containerlab-definition-link-impairments-and-admin-states-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+      links:
+        - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+        - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+`,
+					},
+					LinkImpairments: []clabernetesapisv1alpha1.LinkImpairment{
+						{
+							Endpoint: "srl2:e1-1",
+							Impairment: clabernetesapisv1alpha1.Impairment{
+								Delay:  "50ms",
+								Jitter: "5ms",
+								Loss:   "0.5",
+								Rate:   "100mbit",
+							},
+						},
+					},
+					LinkAdminStates: []clabernetesapisv1alpha1.LinkAdminState{
+						{
+							Endpoint:   "srl1:e1-2",
+							AdminState: "down",
+						},
+					},
+				},
+			},
+			reconcileData: &clabernetescontrollerstopology.ReconcileData{
+				Kind:            "containerlab",
+				ResolvedHashes:  clabernetesapisv1alpha1.ReconcileHashes{},
+				ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{},
+				ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+			},
+			removeTopologyPrefix: false,
+		},
+		{
+			name: "containerlab-restore-from-snapshot",
+			inTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "process-containerlab-definition-restore-from-snapshot-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+          startup-config: srl2.cfg
+      links:
+        - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+`,
+					},
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						RestoreFromSnapshot: "golden",
+					},
+				},
+			},
+			reconcileData: &clabernetescontrollerstopology.ReconcileData{
+				Kind:            "containerlab",
+				ResolvedHashes:  clabernetesapisv1alpha1.ReconcileHashes{},
+				ResolvedConfigs: map[string]*clabernetesutilcontainerlab.Config{},
+				ResolvedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+				// srl2 failed to be captured, so it keeps its own startup config
+				RestoredNodes: []string{"srl1"},
+			},
+			removeTopologyPrefix: false,
+		},
 	}
 
 	for _, testCase := range cases {
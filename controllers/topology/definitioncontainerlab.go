@@ -2,7 +2,8 @@ package topology
 
 import (
 	"fmt"
-	"strings"
+	"maps"
+	"slices"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
@@ -37,10 +38,27 @@ func (p *containerlabDefinitionProcessor) Process() error {
 	// check this here so we only have to check it once
 	removeTopologyPrefix := p.getRemoveTopologyPrefix()
 
-	for nodeName := range containerlabConfig.Topology.Nodes {
-		err = p.processConfigForNode(
+	launcherNodes, err := p.resolveLauncherNodes(containerlabConfig.Topology)
+	if err != nil {
+		p.logger.Criticalf("failed resolving node grouping, error: %s", err)
+
+		return err
+	}
+
+	nodeLaunchers := make(map[string]string)
+
+	for launcherName, nodeNames := range launcherNodes {
+		for _, nodeName := range nodeNames {
+			nodeLaunchers[nodeName] = launcherName
+		}
+	}
+
+	for launcherName, nodeNames := range launcherNodes {
+		err = p.processConfigForLauncher(
 			containerlabConfig,
-			nodeName,
+			launcherName,
+			nodeNames,
+			nodeLaunchers,
 			defaultsYAML,
 			removeTopologyPrefix,
 		)
@@ -52,6 +70,104 @@ func (p *containerlabDefinitionProcessor) Process() error {
 	return nil
 }
 
+// resolveLauncherNodes returns a mapping of launcher name to the containerlab nodes that launcher
+// is responsible for. Ungrouped nodes get a launcher of their own, grouped nodes share a launcher
+// that is named after the primary node of the group -- the primary node is the first node of the
+// group in alphabetical order and is always the first node in the returned slice. Going by the
+// sorted members means reordering the members of a group does not rename (and so recreate) its
+// launcher.
+func (p *containerlabDefinitionProcessor) resolveLauncherNodes(
+	clabTopo *clabernetesutilcontainerlab.Topology,
+) (map[string][]string, error) {
+	groups := p.topology.Spec.Deployment.NodeGrouping.Groups
+
+	if len(groups) == 0 && p.topology.Spec.Deployment.NodeGrouping.ContainerlabGroups {
+		groups = getContainerlabGroups(clabTopo)
+
+		// native mode nodes always get a launcher of their own, so just leave them out of their
+		// containerlab group rather than failing
+		for groupName, nodeNames := range groups {
+			groups[groupName] = slices.DeleteFunc(nodeNames, func(nodeName string) bool {
+				return isNativeModeNode(p.topology, nodeName)
+			})
+		}
+	}
+
+	launcherNodes := make(map[string][]string)
+	groupedNodes := clabernetesutil.NewStringSet()
+
+	for _, groupName := range slices.Sorted(maps.Keys(groups)) {
+		for _, nodeName := range groups[groupName] {
+			_, ok := clabTopo.Nodes[nodeName]
+			if !ok {
+				return nil, fmt.Errorf(
+					"%w: node group %q references unknown node %q",
+					claberneteserrors.ErrParse,
+					groupName,
+					nodeName,
+				)
+			}
+
+			if isNativeModeNode(p.topology, nodeName) {
+				return nil, fmt.Errorf(
+					"%w: node %q runs in native mode and cannot be a member of node group %q",
+					claberneteserrors.ErrParse,
+					nodeName,
+					groupName,
+				)
+			}
+
+			if groupedNodes.Contains(nodeName) {
+				return nil, fmt.Errorf(
+					"%w: node %q is a member of more than one node group",
+					claberneteserrors.ErrParse,
+					nodeName,
+				)
+			}
+
+			groupedNodes.Add(nodeName)
+		}
+
+		if len(groups[groupName]) == 0 {
+			continue
+		}
+
+		groupNodes := slices.Sorted(slices.Values(groups[groupName]))
+
+		launcherNodes[groupNodes[0]] = groupNodes
+	}
+
+	for nodeName := range clabTopo.Nodes {
+		if groupedNodes.Contains(nodeName) {
+			continue
+		}
+
+		launcherNodes[nodeName] = []string{nodeName}
+	}
+
+	return launcherNodes, nil
+}
+
+// getContainerlabGroups returns a mapping of containerlab group name to the (sorted) names of the
+// nodes in that group. A nodes group is resolved the same way containerlab does it: the node
+// itself, then the nodes kind, then the topology defaults.
+func getContainerlabGroups(
+	clabTopo *clabernetesutilcontainerlab.Topology,
+) map[string][]string {
+	groups := make(map[string][]string)
+
+	for _, nodeName := range slices.Sorted(maps.Keys(clabTopo.Nodes)) {
+		nodeGroup := clabTopo.GetNodeGroup(nodeName)
+		if nodeGroup == "" {
+			continue
+		}
+
+		groups[nodeGroup] = append(groups[nodeGroup], nodeName)
+	}
+
+	return groups
+}
+
 func getDefaultPorts() []*clabernetesutilcontainerlab.TypedPort {
 	return []*clabernetesutilcontainerlab.TypedPort{
 		{
@@ -246,63 +362,105 @@ func processPorts(
 
 	typedDefaultPorts = insertMissingDefaultPorts(typedDefaultPorts, typedNodePorts)
 
-	defaultPortsAsString = make([]string, len(typedDefaultPorts))
-	nodePortsAsString = make([]string, len(typedNodePorts))
-
 	// now we go through and allocated "expose" ports for any ports that don't have this already
 	// set -- unlike containerlab by default we need to know the expose ports ahead of time to
 	// properly set up the lb bits.
-	for idx, typedPort := range typedDefaultPorts {
-		if typedPort.ExposePort != 0 {
-			defaultPortsAsString[idx] = typedPort.AsContainerlabPortDefinition()
+	defaultPortsAsString = allocateExposePorts(
+		typedDefaultPorts,
+		&allocatedTCPExposePorts,
+		&allocatedUDPExposePorts,
+	)
+	nodePortsAsString = allocateExposePorts(
+		typedNodePorts,
+		&allocatedTCPExposePorts,
+		&allocatedUDPExposePorts,
+	)
 
-			continue
-		}
+	return defaultPortsAsString, nodePortsAsString
+}
 
-		switch typedPort.Protocol {
-		case clabernetesconstants.TCP:
-			allocatedPort := getNextPort(allocatedTCPExposePorts)
+// processGroupPorts is the node group flavor of processPorts -- since every node in a group shares
+// the launcher (and therefore the host ports), the topology default ports (plus the auto expose
+// ports) are only applied to the primary node of the group, and the expose ports are allocated
+// across all nodes of the group so they never collide. The returned slice holds the port
+// definitions for each node in the same order as the given node ports.
+func processGroupPorts(
+	topologyDefaultPorts []string,
+	topologyNodesPorts [][]string,
+) [][]string {
+	typedDefaultPorts := typedPortsFromPortDefinitions(topologyDefaultPorts)
+	typedNodesPorts := make([][]*clabernetesutilcontainerlab.TypedPort, len(topologyNodesPorts))
 
-			allocatedTCPExposePorts = append(allocatedTCPExposePorts, allocatedPort)
+	for idx, topologyNodePorts := range topologyNodesPorts {
+		typedNodesPorts[idx] = typedPortsFromPortDefinitions(topologyNodePorts)
+	}
 
-			typedPort.ExposePort = allocatedPort
-		case clabernetesconstants.UDP:
-			allocatedPort := getNextPort(allocatedUDPExposePorts)
+	allocatedTCPExposePorts, allocatedUDPExposePorts := recordAllocatedPorts(
+		slices.Concat(
+			[][]*clabernetesutilcontainerlab.TypedPort{typedDefaultPorts},
+			typedNodesPorts,
+		)...,
+	)
 
-			allocatedUDPExposePorts = append(allocatedUDPExposePorts, allocatedPort)
+	typedDefaultPorts = insertMissingDefaultPorts(typedDefaultPorts, typedNodesPorts[0])
 
-			typedPort.ExposePort = allocatedPort
+	defaultPortsAsString := allocateExposePorts(
+		typedDefaultPorts,
+		&allocatedTCPExposePorts,
+		&allocatedUDPExposePorts,
+	)
+
+	nodesPortsAsString := make([][]string, len(typedNodesPorts))
+
+	for idx, typedNodePorts := range typedNodesPorts {
+		nodePortsAsString := allocateExposePorts(
+			typedNodePorts,
+			&allocatedTCPExposePorts,
+			&allocatedUDPExposePorts,
+		)
+
+		if idx == 0 {
+			nodePortsAsString = slices.Concat(defaultPortsAsString, nodePortsAsString)
 		}
 
-		defaultPortsAsString[idx] = typedPort.AsContainerlabPortDefinition()
+		nodesPortsAsString[idx] = nodePortsAsString
 	}
 
-	for idx, typedPort := range typedNodePorts {
+	return nodesPortsAsString
+}
+
+func allocateExposePorts(
+	typedPorts []*clabernetesutilcontainerlab.TypedPort,
+	allocatedTCPExposePorts, allocatedUDPExposePorts *[]int64,
+) []string {
+	portsAsString := make([]string, len(typedPorts))
+
+	for idx, typedPort := range typedPorts {
 		if typedPort.ExposePort != 0 {
-			nodePortsAsString[idx] = typedPort.AsContainerlabPortDefinition()
+			portsAsString[idx] = typedPort.AsContainerlabPortDefinition()
 
 			continue
 		}
 
 		switch typedPort.Protocol {
 		case clabernetesconstants.TCP:
-			allocatedPort := getNextPort(allocatedTCPExposePorts)
+			allocatedPort := getNextPort(*allocatedTCPExposePorts)
 
-			allocatedTCPExposePorts = append(allocatedTCPExposePorts, allocatedPort)
+			*allocatedTCPExposePorts = append(*allocatedTCPExposePorts, allocatedPort)
 
 			typedPort.ExposePort = allocatedPort
 		case clabernetesconstants.UDP:
-			allocatedPort := getNextPort(allocatedUDPExposePorts)
+			allocatedPort := getNextPort(*allocatedUDPExposePorts)
 
-			allocatedUDPExposePorts = append(allocatedUDPExposePorts, allocatedPort)
+			*allocatedUDPExposePorts = append(*allocatedUDPExposePorts, allocatedPort)
 
 			typedPort.ExposePort = allocatedPort
 		}
 
-		nodePortsAsString[idx] = typedPort.AsContainerlabPortDefinition()
+		portsAsString[idx] = typedPort.AsContainerlabPortDefinition()
 	}
 
-	return defaultPortsAsString, nodePortsAsString
+	return portsAsString
 }
 
 func getKindsForNode(
@@ -335,31 +493,60 @@ func getKindsForNode(
 	return nil
 }
 
-func getDestinationLinkEndpoint(
-	targetNode string,
-	interestingEndpoint clabernetesapisv1alpha1.LinkEndpoint,
-	uninterestingEndpoint clabernetesapisv1alpha1.LinkEndpoint,
-) string {
-	if targetNode == clabernetesconstants.HostKeyword {
-		// It is a containerlab host entry, so the original provided interface is preserved
-		return fmt.Sprintf(
-			"%s:%s",
-			clabernetesconstants.HostKeyword,
-			uninterestingEndpoint.InterfaceName,
-		)
+// getTunnelHostLinkDefinition returns the link definition for the local side of a link that is
+// tunneled to a remote node -- the local node interface is connected to a host (launcher) interface
+// named "<node>-<interface>" that the connectivity manager then stitches to the tunnel. Brief links
+// stay brief, extended veth links are rendered as a "host" link so the endpoint mac is retained;
+// in both cases labels, vars and mtu are carried over.
+func getTunnelHostLinkDefinition(
+	link *clabernetesutilcontainerlab.LinkDefinition,
+	interestingEndpoint *clabernetesutilcontainerlab.LinkEndpoint,
+) *clabernetesutilcontainerlab.LinkDefinition {
+	hostInterface := fmt.Sprintf(
+		"%s-%s",
+		interestingEndpoint.Node,
+		interestingEndpoint.Interface,
+	)
+
+	if link.IsBrief() {
+		return &clabernetesutilcontainerlab.LinkDefinition{
+			LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+				Endpoints: []string{
+					fmt.Sprintf(
+						"%s:%s",
+						interestingEndpoint.Node,
+						interestingEndpoint.Interface,
+					),
+					fmt.Sprintf("%s:%s", clabernetesconstants.HostKeyword, hostInterface),
+				},
+				Labels: link.Labels,
+				Vars:   link.Vars,
+				MTU:    link.MTU,
+			},
+		}
 	}
 
-	return fmt.Sprintf(
-		"%s:%s-%s",
-		clabernetesconstants.HostKeyword,
-		interestingEndpoint.NodeName,
-		interestingEndpoint.InterfaceName,
-	)
+	return &clabernetesutilcontainerlab.LinkDefinition{
+		Type: clabernetesconstants.LinkTypeHost,
+		LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+			Endpoint: &clabernetesutilcontainerlab.LinkEndpoint{
+				Node:      interestingEndpoint.Node,
+				Interface: interestingEndpoint.Interface,
+				MAC:       interestingEndpoint.MAC,
+			},
+			HostInterface: hostInterface,
+			Labels:        link.Labels,
+			Vars:          link.Vars,
+			MTU:           link.MTU,
+		},
+	}
 }
 
-func (p *containerlabDefinitionProcessor) processConfigForNode(
+func (p *containerlabDefinitionProcessor) processConfigForLauncher(
 	containerlabConfig *clabernetesutilcontainerlab.Config,
-	nodeName string,
+	launcherName string,
+	nodeNames []string,
+	nodeLaunchers map[string]string,
 	defaultsYAML []byte,
 	removeTopologyPrefix bool,
 ) error {
@@ -370,35 +557,46 @@ func (p *containerlabDefinitionProcessor) processConfigForNode(
 		return err
 	}
 
-	nodeDefinition := containerlabConfig.Topology.Nodes[nodeName]
+	nodeDefinitions := make(map[string]*clabernetesutilcontainerlab.NodeDefinition)
 
-	if !p.topology.Spec.Expose.DisableExpose && !p.topology.Spec.Expose.DisableAutoExpose {
-		// disable expose is *not* set and disable auto expose is *not* set, so we want to
-		// automagically add our default expose ports to the topo. we'll simply tack this onto
-		// the clab defaults ports list since that will get merged w/ any user defined ports
-		defaultPorts, nodePorts := processPorts(
-			containerlabConfig.Topology.Defaults.Ports,
-			nodeDefinition.Ports,
-		)
+	var kindDefinitions map[string]*clabernetesutilcontainerlab.NodeDefinition
+
+	for _, nodeName := range nodeNames {
+		nodeDefinitions[nodeName] = containerlabConfig.Topology.Nodes[nodeName]
 
-		deepCopiedDefaults.Ports = defaultPorts
-		nodeDefinition.Ports = nodePorts
-	} else {
-		// zero value of slice is nil, that breaks deep equal checks later, so ensure we set to
-		// a non-zero (but empty) slice
-		nodeDefinition.Ports = []string{}
+		if slices.Contains(p.reconcileData.RestoredNodes, nodeName) {
+			// restored nodes boot from their saved configuration, which the deployment mounts at
+			// this path from the snapshot configmap
+			nodeDefinitions[nodeName].StartupConfig = restoredStartupConfigPath(nodeName)
+		}
+
+		for kindName, kindDefinition := range getKindsForNode(
+			containerlabConfig.Topology,
+			nodeName,
+		) {
+			if kindDefinitions == nil {
+				kindDefinitions = make(map[string]*clabernetesutilcontainerlab.NodeDefinition)
+			}
+
+			kindDefinitions[kindName] = kindDefinition
+		}
 	}
 
-	p.reconcileData.ResolvedConfigs[nodeName] = &clabernetesutilcontainerlab.Config{
-		Name: fmt.Sprintf("clabernetes-%s", nodeName),
+	p.processPortsForLauncher(
+		containerlabConfig.Topology.Defaults.Ports,
+		deepCopiedDefaults,
+		nodeNames,
+		nodeDefinitions,
+	)
+
+	p.reconcileData.ResolvedConfigs[launcherName] = &clabernetesutilcontainerlab.Config{
+		Name: fmt.Sprintf("clabernetes-%s", launcherName),
 		Mgmt: containerlabConfig.Mgmt,
 		Topology: &clabernetesutilcontainerlab.Topology{
 			Defaults: deepCopiedDefaults,
-			Kinds:    getKindsForNode(containerlabConfig.Topology, nodeName),
-			Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
-				nodeName: nodeDefinition,
-			},
-			Links: nil,
+			Kinds:    kindDefinitions,
+			Nodes:    nodeDefinitions,
+			Links:    nil,
 		},
 		// we override existing topo prefix and set it to empty prefix - "" (rather than accept
 		// what the user has provided *or* the default of "clab").
@@ -408,107 +606,216 @@ func (p *containerlabDefinitionProcessor) processConfigForNode(
 	}
 
 	for _, link := range containerlabConfig.Topology.Links {
-		if len(link.Endpoints) != clabernetesapisv1alpha1.LinkEndpointElementCount {
-			msg := fmt.Sprintf(
-				"endpoint '%q' has wrong syntax, unexpected number of items", link.Endpoints,
-			)
+		err = p.processLinkForLauncher(launcherName, nodeLaunchers, link, removeTopologyPrefix)
+		if err != nil {
+			return err
+		}
+	}
 
-			p.logger.Critical(msg)
+	return nil
+}
 
-			return fmt.Errorf(
-				"%w: %s", claberneteserrors.ErrParse, msg,
+func (p *containerlabDefinitionProcessor) processPortsForLauncher(
+	topologyDefaultPorts []string,
+	deepCopiedDefaults *clabernetesutilcontainerlab.NodeDefinition,
+	nodeNames []string,
+	nodeDefinitions map[string]*clabernetesutilcontainerlab.NodeDefinition,
+) {
+	exposing := !p.topology.Spec.Expose.DisableExpose && !p.topology.Spec.Expose.DisableAutoExpose
+
+	if len(nodeNames) == 1 {
+		nodeDefinition := nodeDefinitions[nodeNames[0]]
+
+		if exposing {
+			// disable expose is *not* set and disable auto expose is *not* set, so we want to
+			// automagically add our default expose ports to the topo. we'll simply tack this onto
+			// the clab defaults ports list since that will get merged w/ any user defined ports
+			defaultPorts, nodePorts := processPorts(
+				topologyDefaultPorts,
+				nodeDefinition.Ports,
 			)
-		}
 
-		endpointAParts := strings.Split(link.Endpoints[0], ":")
-		endpointBParts := strings.Split(link.Endpoints[1], ":")
+			deepCopiedDefaults.Ports = defaultPorts
+			nodeDefinition.Ports = nodePorts
+		} else {
+			// zero value of slice is nil, that breaks deep equal checks later, so ensure we set
+			// to a non-zero (but empty) slice
+			nodeDefinition.Ports = []string{}
+		}
 
-		if len(endpointAParts) != clabernetesapisv1alpha1.LinkEndpointElementCount ||
-			len(endpointBParts) != clabernetesapisv1alpha1.LinkEndpointElementCount {
-			msg := fmt.Sprintf(
-				"endpoint '%q' has wrong syntax, bad endpoint:interface config", link.Endpoints,
-			)
+		return
+	}
 
-			p.logger.Critical(msg)
+	// default ports would be applied to every node in the group, all fighting over the same host
+	// ports, so for groups they are never left in the defaults
+	deepCopiedDefaults.Ports = []string{}
 
-			return fmt.Errorf(
-				"%w: %s", claberneteserrors.ErrParse, msg,
-			)
+	if !exposing {
+		for _, nodeName := range nodeNames {
+			nodeDefinitions[nodeName].Ports = []string{}
 		}
 
-		endpointA := clabernetesapisv1alpha1.LinkEndpoint{
-			NodeName:      endpointAParts[0],
-			InterfaceName: endpointAParts[1],
-		}
-		endpointB := clabernetesapisv1alpha1.LinkEndpoint{
-			NodeName:      endpointBParts[0],
-			InterfaceName: endpointBParts[1],
-		}
+		return
+	}
 
-		if endpointA.NodeName != nodeName && endpointB.NodeName != nodeName {
-			// link doesn't apply to this node, carry on
-			continue
-		}
+	nodesPorts := make([][]string, len(nodeNames))
 
-		if endpointA.NodeName == nodeName && endpointB.NodeName == nodeName {
-			// link loops back to ourselves, no need to do overlay things just append the link
-			p.reconcileData.ResolvedConfigs[nodeName].Topology.Links = append(
-				p.reconcileData.ResolvedConfigs[nodeName].Topology.Links,
+	for idx, nodeName := range nodeNames {
+		nodesPorts[idx] = nodeDefinitions[nodeName].Ports
+	}
+
+	for idx, nodePorts := range processGroupPorts(topologyDefaultPorts, nodesPorts) {
+		nodeDefinitions[nodeNames[idx]].Ports = nodePorts
+	}
+}
+
+func (p *containerlabDefinitionProcessor) processLinkForLauncher(
+	launcherName string,
+	nodeLaunchers map[string]string,
+	link *clabernetesutilcontainerlab.LinkDefinition,
+	removeTopologyPrefix bool,
+) error {
+	endpoints, err := link.ResolveEndpoints()
+	if err != nil {
+		p.logger.Critical(err.Error())
+
+		return err
+	}
+
+	if link.Type != "" && link.Type != clabernetesconstants.LinkTypeVeth {
+		// single endpoint links (mgmt-net, macvlan, host, dummy, vxlan, vxlan-stitch) only ever
+		// touch the one node, so they never get tunneled, they just stay with their node
+		if nodeLaunchers[endpoints[0].Node] == launcherName {
+			p.reconcileData.ResolvedConfigs[launcherName].Topology.Links = append(
+				p.reconcileData.ResolvedConfigs[launcherName].Topology.Links,
 				link,
 			)
-
-			continue
 		}
 
-		interestingEndpoint := endpointA
-		uninterestingEndpoint := endpointB
+		return nil
+	}
+
+	if len(endpoints) != clabernetesapisv1alpha1.LinkEndpointElementCount {
+		msg := fmt.Sprintf(
+			"endpoint '%q' has wrong syntax, unexpected number of items", link.Endpoints,
+		)
 
-		if endpointB.NodeName == nodeName {
-			interestingEndpoint = endpointB
-			uninterestingEndpoint = endpointA
+		if !link.IsBrief() {
+			msg = fmt.Sprintf(
+				"link of type %q has wrong syntax, unexpected number of endpoints", link.Type,
+			)
 		}
 
-		p.reconcileData.ResolvedConfigs[nodeName].Topology.Links = append(
-			p.reconcileData.ResolvedConfigs[nodeName].Topology.Links,
-			&clabernetesutilcontainerlab.LinkDefinition{
-				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
-					Endpoints: []string{
-						fmt.Sprintf("%s:%s",
-							interestingEndpoint.NodeName,
-							interestingEndpoint.InterfaceName,
-						),
-						getDestinationLinkEndpoint(
-							endpointB.NodeName,
-							interestingEndpoint,
-							uninterestingEndpoint,
-						),
-					},
-				},
-			},
+		p.logger.Critical(msg)
+
+		return fmt.Errorf(
+			"%w: %s", claberneteserrors.ErrParse, msg,
 		)
+	}
+
+	endpointA := endpoints[0]
+	endpointB := endpoints[1]
+
+	endpointALocal := nodeLaunchers[endpointA.Node] == launcherName
+	endpointBLocal := nodeLaunchers[endpointB.Node] == launcherName
+
+	if !endpointALocal && !endpointBLocal {
+		// link doesn't apply to this launcher, carry on
+		return nil
+	}
+
+	if (endpointALocal && endpointBLocal) ||
+		clabernetesutilcontainerlab.IsReservedEndpointNode(endpointA.Node) ||
+		clabernetesutilcontainerlab.IsReservedEndpointNode(endpointB.Node) {
+		// link loops back to ourselves or stays within our node group, or is a brief format
+		// host/mgmt-net/macvlan link, no need to do overlay things just append the link
+		p.reconcileData.ResolvedConfigs[launcherName].Topology.Links = append(
+			p.reconcileData.ResolvedConfigs[launcherName].Topology.Links,
+			link,
+		)
+
+		return nil
+	}
+
+	interestingEndpoint := endpointA
+	uninterestingEndpoint := endpointB
+
+	if endpointBLocal {
+		interestingEndpoint = endpointB
+		uninterestingEndpoint = endpointA
+	}
+
+	remoteLauncherName, ok := nodeLaunchers[uninterestingEndpoint.Node]
+	if !ok {
+		remoteLauncherName = uninterestingEndpoint.Node
+	}
+
+	p.reconcileData.ResolvedConfigs[launcherName].Topology.Links = append(
+		p.reconcileData.ResolvedConfigs[launcherName].Topology.Links,
+		getTunnelHostLinkDefinition(link, interestingEndpoint),
+	)
+
+	p.reconcileData.ResolvedTunnels[launcherName] = append(
+		p.reconcileData.ResolvedTunnels[launcherName],
+		&clabernetesapisv1alpha1.PointToPointTunnel{
+			LocalNode:  interestingEndpoint.Node,
+			RemoteNode: uninterestingEndpoint.Node,
+			Destination: resolveConnectivityDestination(
+				p.topology.Name,
+				remoteLauncherName,
+				p.topology.Namespace,
+				removeTopologyPrefix,
+				p.configManagerGetter,
+			),
+			LocalInterface:  interestingEndpoint.Interface,
+			RemoteInterface: uninterestingEndpoint.Interface,
+			Impairment:      p.resolveLinkImpairment(endpointA, endpointB),
+			AdminState:      p.resolveLinkAdminState(endpointA, endpointB),
+		},
+	)
+
+	return nil
+}
+
+// linkSelectedBy returns true if the given "node:interface" endpoint selector refers to either of
+// the endpoints of a link.
+func linkSelectedBy(
+	selector string,
+	endpointA, endpointB *clabernetesutilcontainerlab.LinkEndpoint,
+) bool {
+	return selector == fmt.Sprintf("%s:%s", endpointA.Node, endpointA.Interface) ||
+		selector == fmt.Sprintf("%s:%s", endpointB.Node, endpointB.Interface)
+}
 
-		if endpointB.NodeName == clabernetesconstants.HostKeyword {
-			// This is containerlab host entry, so no VxLAN is required
+// resolveLinkImpairment returns the impairment configured for the link between the given
+// endpoints, if any. Impairments select a link by either of its endpoints, so both launchers of a
+// link end up with the same impairment regardless of which endpoint the user picked.
+func (p *containerlabDefinitionProcessor) resolveLinkImpairment(
+	endpointA, endpointB *clabernetesutilcontainerlab.LinkEndpoint,
+) *clabernetesapisv1alpha1.Impairment {
+	for _, linkImpairment := range p.topology.Spec.LinkImpairments {
+		if !linkSelectedBy(linkImpairment.Endpoint, endpointA, endpointB) {
 			continue
 		}
 
-		p.reconcileData.ResolvedTunnels[nodeName] = append(
-			p.reconcileData.ResolvedTunnels[nodeName],
-			&clabernetesapisv1alpha1.PointToPointTunnel{
-				LocalNode:  nodeName,
-				RemoteNode: uninterestingEndpoint.NodeName,
-				Destination: resolveConnectivityDestination(
-					p.topology.Name,
-					uninterestingEndpoint.NodeName,
-					p.topology.Namespace,
-					removeTopologyPrefix,
-					p.configManagerGetter,
-				),
-				LocalInterface:  interestingEndpoint.InterfaceName,
-				RemoteInterface: uninterestingEndpoint.InterfaceName,
-			},
-		)
+		return linkImpairment.Impairment.DeepCopy()
 	}
 
 	return nil
 }
+
+// resolveLinkAdminState returns the admin state configured for the link between the given
+// endpoints, or an empty string (meaning "up") if there is none.
+func (p *containerlabDefinitionProcessor) resolveLinkAdminState(
+	endpointA, endpointB *clabernetesutilcontainerlab.LinkEndpoint,
+) string {
+	for _, linkAdminState := range p.topology.Spec.LinkAdminStates {
+		if !linkSelectedBy(linkAdminState.Endpoint, endpointA, endpointB) {
+			continue
+		}
+
+		return linkAdminState.AdminState
+	}
+
+	return ""
+}
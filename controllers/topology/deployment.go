@@ -22,6 +22,7 @@ import (
 	k8scorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	clientgorecord "k8s.io/client-go/tools/record"
 )
 
 const (
@@ -29,6 +30,7 @@ const (
 	probePeriodSeconds                  = 20
 	probeReadinessFailureThreshold      = 3
 	probeDefaultStartupFailureThreshold = 40
+	persistenceVolumeName               = "containerlab-directory-persistence"
 )
 
 // DeploymentReconciler is a subcomponent of the "TopologyReconciler" but is exposed for testing
@@ -36,25 +38,31 @@ const (
 // clabernetes topology resource.
 type DeploymentReconciler struct {
 	log                 claberneteslogging.Instance
+	recorder            clientgorecord.EventRecorder
 	managerAppName      string
 	managerNamespace    string
 	criKind             string
+	captureCA           string
 	configManagerGetter clabernetesconfig.ManagerGetterFunc
 }
 
 // NewDeploymentReconciler returns an instance of DeploymentReconciler.
 func NewDeploymentReconciler(
 	log claberneteslogging.Instance,
+	recorder clientgorecord.EventRecorder,
 	managerAppName,
 	managerNamespace,
-	criKind string,
+	criKind,
+	captureCA string,
 	configManagerGetter clabernetesconfig.ManagerGetterFunc,
 ) *DeploymentReconciler {
 	return &DeploymentReconciler{
 		log:                 log,
+		recorder:            recorder,
 		managerAppName:      managerAppName,
 		managerNamespace:    managerNamespace,
 		criKind:             criKind,
+		captureCA:           captureCA,
 		configManagerGetter: configManagerGetter,
 	}
 }
@@ -108,11 +116,12 @@ func (r *DeploymentReconciler) Resolve(
 	return deployments, nil
 }
 
-// Render accepts the owning topology a mapping of clabernetes sub-topology configs and a node name
-// and renders the final deployment for this node.
+// Render accepts the owning topology a mapping of clabernetes sub-topology configs, the tunnels of
+// the topology and a node name and renders the final deployment for this node.
 func (r *DeploymentReconciler) Render(
 	owningTopology *clabernetesapisv1alpha1.Topology,
 	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
 	nodeName string,
 ) *k8sappsv1.Deployment {
 	owningTopologyName := owningTopology.GetName()
@@ -132,6 +141,11 @@ func (r *DeploymentReconciler) Render(
 		nodeName,
 	)
 
+	r.renderDeploymentReplicas(
+		deployment,
+		owningTopology,
+	)
+
 	r.renderDeploymentScheduling(
 		deployment,
 		owningTopology,
@@ -143,6 +157,7 @@ func (r *DeploymentReconciler) Render(
 		configVolumeName,
 		owningTopologyName,
 		owningTopology,
+		clabernetesConfigs,
 	)
 
 	r.renderDeploymentContainer(
@@ -185,6 +200,7 @@ func (r *DeploymentReconciler) Render(
 		deployment,
 		nodeName,
 		owningTopology,
+		clabernetesConfigs,
 	)
 
 	r.renderDeploymentDevices(
@@ -199,14 +215,29 @@ func (r *DeploymentReconciler) Render(
 		owningTopology,
 	)
 
+	r.renderDeploymentMultus(
+		deployment,
+		nodeName,
+		owningTopology,
+		tunnels,
+	)
+
+	r.renderDeploymentNativeMode(
+		deployment,
+		nodeName,
+		owningTopology,
+		clabernetesConfigs,
+	)
+
 	return deployment
 }
 
-// RenderAll accepts the owning topology a mapping of clabernetes sub-topology configs and a
-// list of node names and renders the final deployments for the given nodes.
+// RenderAll accepts the owning topology a mapping of clabernetes sub-topology configs, the tunnels
+// of the topology and a list of node names and renders the final deployments for the given nodes.
 func (r *DeploymentReconciler) RenderAll(
 	owningTopology *clabernetesapisv1alpha1.Topology,
 	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
 	nodeNames []string,
 ) []*k8sappsv1.Deployment {
 	deployments := make([]*k8sappsv1.Deployment, len(nodeNames))
@@ -215,6 +246,7 @@ func (r *DeploymentReconciler) RenderAll(
 		deployments[idx] = r.Render(
 			owningTopology,
 			clabernetesConfigs,
+			tunnels,
 			nodeName,
 		)
 	}
@@ -269,6 +301,20 @@ func (r *DeploymentReconciler) Conforms( //nolint: gocyclo
 		return false
 	}
 
+	if !clabernetesutilkubernetes.ContainersEqual(
+		existingDeployment.Spec.Template.Spec.InitContainers,
+		renderedDeployment.Spec.Template.Spec.InitContainers,
+	) {
+		return false
+	}
+
+	if !reflect.DeepEqual(
+		existingDeployment.Spec.Template.Spec.ImagePullSecrets,
+		renderedDeployment.Spec.Template.Spec.ImagePullSecrets,
+	) {
+		return false
+	}
+
 	if !reflect.DeepEqual(
 		existingDeployment.Spec.Template.Spec.ServiceAccountName,
 		renderedDeployment.Spec.Template.Spec.ServiceAccountName,
@@ -402,6 +448,19 @@ func (r *DeploymentReconciler) renderDeploymentBase(
 	}
 }
 
+func (r *DeploymentReconciler) renderDeploymentReplicas(
+	deployment *k8sappsv1.Deployment,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) {
+	if !owningTopology.Spec.Paused {
+		return
+	}
+
+	// paused topologies keep their deployments (and everything else) around, just with nothing
+	// running, so unpausing is simply scaling back up
+	deployment.Spec.Replicas = clabernetesutil.ToPointer(int32(0))
+}
+
 func (r *DeploymentReconciler) renderDeploymentScheduling(
 	deployment *k8sappsv1.Deployment,
 	owningTopology *clabernetesapisv1alpha1.Topology,
@@ -417,6 +476,7 @@ func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
 	configVolumeName,
 	owningTopologyName string,
 	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) []k8scorev1.VolumeMount {
 	volumes := []k8scorev1.Volume{
 		{
@@ -535,12 +595,48 @@ func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
 		)
 	}
 
+	if r.captureCA != "" {
+		volumes = append(
+			volumes,
+			k8scorev1.Volume{
+				Name: "capture-certificate",
+				VolumeSource: k8scorev1.VolumeSource{
+					Secret: &k8scorev1.SecretVolumeSource{
+						SecretName: captureCertificateSecretName(owningTopologyName),
+					},
+				},
+			},
+		)
+
+		volumeMountsFromCommonSpec = append(
+			volumeMountsFromCommonSpec,
+			k8scorev1.VolumeMount{
+				Name:      "capture-certificate",
+				ReadOnly:  true,
+				MountPath: clabernetesconstants.LauncherCaptureCertificateDirectory,
+			},
+		)
+	}
+
 	volumesFromConfigMaps := make([]clabernetesapisv1alpha1.FileFromConfigMap, 0)
 
-	volumesFromConfigMaps = append(
-		volumesFromConfigMaps,
-		owningTopology.Spec.Deployment.FilesFromConfigMap[nodeName]...,
-	)
+	for _, launcherNodeName := range getLauncherNodeNames(clabernetesConfigs, nodeName) {
+		volumesFromConfigMaps = append(
+			volumesFromConfigMaps,
+			owningTopology.Spec.Deployment.FilesFromConfigMap[launcherNodeName]...,
+		)
+
+		restoredFile, restored := restoredStartupConfigFile(
+			owningTopology,
+			clabernetesConfigs[nodeName],
+			launcherNodeName,
+		)
+		if restored {
+			volumesFromConfigMaps = append(volumesFromConfigMaps, restoredFile)
+		}
+	}
+
+	configMapVolumeNames := clabernetesutil.NewStringSet()
 
 	for _, podVolume := range volumesFromConfigMaps {
 		volumeName := clabernetesutilkubernetes.EnforceDNSLabelConvention(
@@ -550,6 +646,32 @@ func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
 			),
 		)
 
+		var mountPath string
+		// mount relative paths under /clabernetes, and absolute paths as is
+		if strings.HasPrefix(podVolume.FilePath, "/") {
+			mountPath = podVolume.FilePath
+		} else {
+			mountPath = fmt.Sprintf("/clabernetes/%s", podVolume.FilePath)
+		}
+
+		volumeMountsFromCommonSpec = append(
+			volumeMountsFromCommonSpec,
+			k8scorev1.VolumeMount{
+				Name:      volumeName,
+				ReadOnly:  false,
+				MountPath: mountPath,
+				SubPath:   podVolume.ConfigMapPath,
+			},
+		)
+
+		if configMapVolumeNames.Contains(volumeName) {
+			// multiple nodes (in a node group) may mount the same configmap (path), the volume
+			// only needs to exist once in the pod though
+			continue
+		}
+
+		configMapVolumeNames.Add(volumeName)
+
 		var mode *int32
 
 		switch podVolume.Mode {
@@ -579,26 +701,6 @@ func (r *DeploymentReconciler) renderDeploymentVolumes( //nolint:funlen
 				},
 			},
 		)
-
-		var mountPath string
-		// mount relative paths under /clabernetes, and absolute paths as is
-		if strings.HasPrefix(podVolume.FilePath, "/") {
-			mountPath = podVolume.FilePath
-		} else {
-			mountPath = fmt.Sprintf("/clabernetes/%s", podVolume.FilePath)
-		}
-
-		volumeMount := k8scorev1.VolumeMount{
-			Name:      volumeName,
-			ReadOnly:  false,
-			MountPath: mountPath,
-			SubPath:   podVolume.ConfigMapPath,
-		}
-
-		volumeMountsFromCommonSpec = append(
-			volumeMountsFromCommonSpec,
-			volumeMount,
-		)
 	}
 
 	deployment.Spec.Template.Spec.Volumes = volumes
@@ -631,6 +733,14 @@ func (r *DeploymentReconciler) renderDeploymentVolumesGetCRISockPath(
 					" will skip mounting cri sock",
 			)
 
+			r.recorder.Event(
+				owningTopology,
+				k8scorev1.EventTypeWarning,
+				clabernetesconstants.EventReasonImagePullThroughUnavailable,
+				"image pull cri sock override could not be parsed, launchers will pull images"+
+					" themselves",
+			)
+
 			return path, subPath
 		}
 	} else {
@@ -645,6 +755,15 @@ func (r *DeploymentReconciler) renderDeploymentVolumesGetCRISockPath(
 					" got cri kind %q",
 				r.criKind,
 			)
+
+			r.recorder.Eventf(
+				owningTopology,
+				k8scorev1.EventTypeWarning,
+				clabernetesconstants.EventReasonImagePullThroughUnavailable,
+				"image pull through is not supported with cri kind %q, launchers will pull"+
+					" images themselves",
+				r.criKind,
+			)
 		}
 	}
 
@@ -668,18 +787,33 @@ func (r *DeploymentReconciler) renderDeploymentContainer(
 		imagePullPolicy = r.configManagerGetter().GetLauncherImagePullPolicy()
 	}
 
+	ports := []k8scorev1.ContainerPort{
+		{
+			Name:          "metrics",
+			ContainerPort: clabernetesconstants.LauncherMetricsPort,
+			Protocol:      clabernetesconstants.TCP,
+		},
+	}
+
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		// with multus there are no tunnels between the launchers, so nothing to listen on
+		connectivityPortName, connectivityPort, connectivityProtocol := resolveConnectivityPort(
+			owningTopology,
+		)
+
+		ports = slices.Insert(ports, 0, k8scorev1.ContainerPort{
+			Name:          connectivityPortName,
+			ContainerPort: connectivityPort,
+			Protocol:      connectivityProtocol,
+		})
+	}
+
 	container := k8scorev1.Container{
 		Name:       nodeName,
 		WorkingDir: "/clabernetes",
 		Image:      image,
 		Command:    []string{"/clabernetes/manager", "launch"},
-		Ports: []k8scorev1.ContainerPort{
-			{
-				Name:          clabernetesconstants.ConnectivityVXLAN,
-				ContainerPort: clabernetesconstants.VXLANServicePort,
-				Protocol:      clabernetesconstants.UDP,
-			},
-		},
+		Ports:      ports,
 		VolumeMounts: []k8scorev1.VolumeMount{
 			{
 				Name:      configVolumeName,
@@ -727,6 +861,11 @@ func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 		launcherLogLevel = r.configManagerGetter().GetLauncherLogLevel()
 	}
 
+	launcherLogFormat := owningTopology.Spec.Deployment.LauncherLogFormat
+	if launcherLogFormat == "" {
+		launcherLogFormat = r.configManagerGetter().GetLauncherLogFormat()
+	}
+
 	imagePullThroughMode := owningTopology.Spec.ImagePull.PullThroughOverride
 	if owningTopology.Spec.ImagePull.PullThroughOverride == "" {
 		imagePullThroughMode = r.configManagerGetter().GetImagePullThroughMode()
@@ -810,6 +949,10 @@ func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 			Name:  clabernetesconstants.LauncherLoggerLevelEnv,
 			Value: launcherLogLevel,
 		},
+		{
+			Name:  clabernetesconstants.LauncherLoggerFormatEnv,
+			Value: launcherLogFormat,
+		},
 		{
 			Name:  clabernetesconstants.LauncherTopologyNameEnv,
 			Value: owningTopologyName,
@@ -859,6 +1002,27 @@ func (r *DeploymentReconciler) renderDeploymentContainerEnv( //nolint: funlen
 		)
 	}
 
+	waitFor := getLauncherWaitFor(clabernetesConfigs, nodeName)
+	if len(waitFor) > 0 {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherWaitFor,
+				Value: strings.Join(waitFor, ","),
+			},
+		)
+	}
+
+	if r.captureCA != "" {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherCaptureCA,
+				Value: r.captureCA,
+			},
+		)
+	}
+
 	if len(owningTopology.Spec.ImagePull.InsecureRegistries) > 0 {
 		envs = append(
 			envs,
@@ -1033,6 +1197,7 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 	deployment *k8sappsv1.Deployment,
 	nodeName string,
 	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
 ) {
 	if !owningTopology.Spec.StatusProbes.Enabled {
 		return
@@ -1049,7 +1214,11 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 	}
 
 	if nodeProbeConfiguration.SSHProbeConfiguration == nil &&
-		nodeProbeConfiguration.TCPProbeConfiguration == nil {
+		nodeProbeConfiguration.TCPProbeConfiguration == nil &&
+		nodeProbeConfiguration.GNMIProbeConfiguration == nil &&
+		nodeProbeConfiguration.NETCONFProbe == nil &&
+		nodeProbeConfiguration.HTTPProbeConfiguration == nil &&
+		nodeProbeConfiguration.ExecProbeConfiguration == nil {
 		r.log.Warnf("node %q has no status probe configurations, skipping...", nodeName)
 
 		return
@@ -1063,6 +1232,13 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 		failureThresholds = nodeProbeConfiguration.StartupSeconds / probePeriodSeconds
 	}
 
+	// launchers that wait on nodes in other launchers may sit there for a while before even
+	// starting their node(s), so give them that extra time on top
+	if len(getLauncherWaitFor(clabernetesConfigs, nodeName)) > 0 {
+		failureThresholds += int(clabernetesconstants.LauncherWaitForTimeout.Seconds()) /
+			probePeriodSeconds
+	}
+
 	// startup probe delays the start of the readiness probe -- this gives us time for the nos to
 	// boot before we start doing the readiness check on the (slightly) faster frequency
 	deployment.Spec.Template.Spec.Containers[0].StartupProbe = &k8scorev1.Probe{
@@ -1100,48 +1276,260 @@ func (r *DeploymentReconciler) renderDeploymentContainerStatus(
 		FailureThreshold: probeReadinessFailureThreshold,
 	}
 
+	deployment.Spec.Template.Spec.Containers[0].Env = append(
+		deployment.Spec.Template.Spec.Containers[0].Env,
+		renderDeploymentProbeEnvs(nodeProbeConfiguration)...,
+	)
+}
+
+// renderDeploymentProbeEnvs renders the env vars that tell the launcher which status probes to run
+// and how to run them.
+func renderDeploymentProbeEnvs(
+	probeConfiguration clabernetesapisv1alpha1.ProbeConfiguration,
+) []k8scorev1.EnvVar {
 	probeEnvVars := make([]k8scorev1.EnvVar, 0)
 
-	if nodeProbeConfiguration.TCPProbeConfiguration != nil {
+	if probeConfiguration.TCPProbeConfiguration != nil {
 		probeEnvVars = append(
 			probeEnvVars,
 			k8scorev1.EnvVar{
 				Name:  clabernetesconstants.LauncherTCPProbePort,
-				Value: strconv.Itoa(nodeProbeConfiguration.TCPProbeConfiguration.Port),
+				Value: strconv.Itoa(probeConfiguration.TCPProbeConfiguration.Port),
 			},
 		)
 	}
 
-	if nodeProbeConfiguration.SSHProbeConfiguration != nil {
+	sshProbeConfiguration := probeConfiguration.SSHProbeConfiguration
+	if sshProbeConfiguration != nil {
 		probeEnvVars = append(
 			probeEnvVars,
-			k8scorev1.EnvVar{
-				Name:  clabernetesconstants.LauncherSSHProbeUsername,
-				Value: nodeProbeConfiguration.SSHProbeConfiguration.Username,
-			},
-			k8scorev1.EnvVar{
-				Name:  clabernetesconstants.LauncherSSHProbePassword,
-				Value: nodeProbeConfiguration.SSHProbeConfiguration.Password,
-			},
+			renderDeploymentProbeCredentialEnvs(
+				clabernetesconstants.LauncherSSHProbeUsername,
+				clabernetesconstants.LauncherSSHProbePassword,
+				clabernetesconstants.LauncherSSHProbePrivateKey,
+				sshProbeConfiguration.Username,
+				sshProbeConfiguration.CredentialsSecret,
+			)...,
+		)
+
+		probeEnvVars = appendPortEnv(
+			probeEnvVars,
+			clabernetesconstants.LauncherSSHProbePort,
+			sshProbeConfiguration.Port,
+		)
+	}
+
+	gnmiProbeConfiguration := probeConfiguration.GNMIProbeConfiguration
+	if gnmiProbeConfiguration != nil {
+		probeEnvVars = append(
+			probeEnvVars,
+			renderDeploymentProbeCredentialEnvs(
+				clabernetesconstants.LauncherGNMIProbeUsername,
+				clabernetesconstants.LauncherGNMIProbePassword,
+				"",
+				gnmiProbeConfiguration.Username,
+				gnmiProbeConfiguration.CredentialsSecret,
+			)...,
+		)
+
+		// the gnmi probe has no mandatory settings, so the port is always set, the launcher
+		// treats it as the signal to run the probe
+		gnmiProbePort := gnmiProbeConfiguration.Port
+		if gnmiProbePort == 0 {
+			gnmiProbePort = clabernetesconstants.PortGNMINokia
+		}
+
+		probeEnvVars = appendPortEnv(
+			probeEnvVars,
+			clabernetesconstants.LauncherGNMIProbePort,
+			gnmiProbePort,
 		)
 
-		if nodeProbeConfiguration.SSHProbeConfiguration.Port != 0 {
+		if gnmiProbeConfiguration.Insecure {
 			probeEnvVars = append(
 				probeEnvVars,
 				k8scorev1.EnvVar{
-					Name:  clabernetesconstants.LauncherSSHProbePort,
-					Value: strconv.Itoa(nodeProbeConfiguration.SSHProbeConfiguration.Port),
+					Name:  clabernetesconstants.LauncherGNMIProbeInsecure,
+					Value: clabernetesconstants.True,
 				},
 			)
 		}
 	}
 
-	deployment.Spec.Template.Spec.Containers[0].Env = append(
-		deployment.Spec.Template.Spec.Containers[0].Env,
-		probeEnvVars...,
+	netconfProbeConfiguration := probeConfiguration.NETCONFProbe
+	if netconfProbeConfiguration != nil {
+		probeEnvVars = append(
+			probeEnvVars,
+			renderDeploymentProbeCredentialEnvs(
+				clabernetesconstants.LauncherNETCONFProbeUsername,
+				clabernetesconstants.LauncherNETCONFProbePassword,
+				clabernetesconstants.LauncherNETCONFProbePrivateKey,
+				netconfProbeConfiguration.Username,
+				netconfProbeConfiguration.CredentialsSecret,
+			)...,
+		)
+
+		probeEnvVars = appendPortEnv(
+			probeEnvVars,
+			clabernetesconstants.LauncherNETCONFProbePort,
+			netconfProbeConfiguration.Port,
+		)
+	}
+
+	if probeConfiguration.HTTPProbeConfiguration != nil {
+		probeEnvVars = append(
+			probeEnvVars,
+			renderDeploymentHTTPProbeEnvs(probeConfiguration.HTTPProbeConfiguration)...,
+		)
+	}
+
+	if probeConfiguration.ExecProbeConfiguration != nil {
+		probeEnvVars = append(
+			probeEnvVars,
+			renderDeploymentExecProbeEnvs(probeConfiguration.ExecProbeConfiguration)...,
+		)
+	}
+
+	return probeEnvVars
+}
+
+func appendPortEnv(envs []k8scorev1.EnvVar, name string, port int) []k8scorev1.EnvVar {
+	if port == 0 {
+		return envs
+	}
+
+	return append(
+		envs,
+		k8scorev1.EnvVar{
+			Name:  name,
+			Value: strconv.Itoa(port),
+		},
 	)
 }
 
+// renderDeploymentProbeCredentialEnvs renders the env vars holding the credentials of a probe.
+// The password and private key are only ever referenced from the credentials secret rather than
+// copied into the deployment -- the keys are optional so a basic-auth or ssh-auth secret (or a
+// secret with keys of both) works. An explicitly configured username (which is no secret) wins
+// over the one in the secret. Empty env var names are skipped.
+func renderDeploymentProbeCredentialEnvs(
+	usernameEnv,
+	passwordEnv,
+	privateKeyEnv,
+	username,
+	credentialsSecret string,
+) []k8scorev1.EnvVar {
+	var envs []k8scorev1.EnvVar
+
+	appendEnv := func(name, value, secretKey string) {
+		if name == "" {
+			return
+		}
+
+		if value != "" {
+			envs = append(envs, k8scorev1.EnvVar{Name: name, Value: value})
+
+			return
+		}
+
+		if credentialsSecret == "" {
+			return
+		}
+
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name: name,
+				ValueFrom: &k8scorev1.EnvVarSource{
+					SecretKeyRef: &k8scorev1.SecretKeySelector{
+						LocalObjectReference: k8scorev1.LocalObjectReference{
+							Name: credentialsSecret,
+						},
+						Key:      secretKey,
+						Optional: clabernetesutil.ToPointer(true),
+					},
+				},
+			},
+		)
+	}
+
+	appendEnv(usernameEnv, username, k8scorev1.BasicAuthUsernameKey)
+	appendEnv(passwordEnv, "", k8scorev1.BasicAuthPasswordKey)
+	appendEnv(privateKeyEnv, "", k8scorev1.SSHAuthPrivateKey)
+
+	return envs
+}
+
+func renderDeploymentHTTPProbeEnvs(
+	httpProbeConfiguration *clabernetesapisv1alpha1.HTTPProbeConfiguration,
+) []k8scorev1.EnvVar {
+	envs := []k8scorev1.EnvVar{
+		{
+			Name:  clabernetesconstants.LauncherHTTPProbePort,
+			Value: strconv.Itoa(httpProbeConfiguration.Port),
+		},
+	}
+
+	optionalEnvs := []k8scorev1.EnvVar{
+		{
+			Name:  clabernetesconstants.LauncherHTTPProbePath,
+			Value: httpProbeConfiguration.Path,
+		},
+		{
+			Name:  clabernetesconstants.LauncherHTTPProbeScheme,
+			Value: httpProbeConfiguration.Scheme,
+		},
+		{
+			Name:  clabernetesconstants.LauncherHTTPProbeBodyRegex,
+			Value: httpProbeConfiguration.BodyRegex,
+		},
+	}
+
+	if httpProbeConfiguration.StatusCode != 0 {
+		optionalEnvs = append(
+			optionalEnvs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherHTTPProbeStatusCode,
+				Value: strconv.Itoa(httpProbeConfiguration.StatusCode),
+			},
+		)
+	}
+
+	for _, env := range optionalEnvs {
+		if env.Value != "" {
+			envs = append(envs, env)
+		}
+	}
+
+	return envs
+}
+
+func renderDeploymentExecProbeEnvs(
+	execProbeConfiguration *clabernetesapisv1alpha1.ExecProbeConfiguration,
+) []k8scorev1.EnvVar {
+	// a slice of strings always marshals just fine
+	commandBytes, _ := json.Marshal(execProbeConfiguration.Command)
+
+	envs := []k8scorev1.EnvVar{
+		{
+			Name:  clabernetesconstants.LauncherExecProbeCommand,
+			Value: string(commandBytes),
+		},
+	}
+
+	if execProbeConfiguration.OutputRegex != "" {
+		envs = append(
+			envs,
+			k8scorev1.EnvVar{
+				Name:  clabernetesconstants.LauncherExecProbeOutputRegex,
+				Value: execProbeConfiguration.OutputRegex,
+			},
+		)
+	}
+
+	return envs
+}
+
 func (r *DeploymentReconciler) renderDeploymentDevices(
 	deployment *k8sappsv1.Deployment,
 	owningTopology *clabernetesapisv1alpha1.Topology,
@@ -1221,12 +1609,10 @@ func (r *DeploymentReconciler) renderDeploymentPersistence(
 		return
 	}
 
-	volumeName := "containerlab-directory-persistence"
-
 	deployment.Spec.Template.Spec.Volumes = append(
 		deployment.Spec.Template.Spec.Volumes,
 		k8scorev1.Volume{
-			Name: volumeName,
+			Name: persistenceVolumeName,
 			VolumeSource: k8scorev1.VolumeSource{
 				PersistentVolumeClaim: &k8scorev1.PersistentVolumeClaimVolumeSource{
 					ClaimName: fmt.Sprintf("%s-%s", owningTopologyName, nodeName),
@@ -1239,7 +1625,7 @@ func (r *DeploymentReconciler) renderDeploymentPersistence(
 	deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(
 		deployment.Spec.Template.Spec.Containers[0].VolumeMounts,
 		k8scorev1.VolumeMount{
-			Name:      volumeName,
+			Name:      persistenceVolumeName,
 			ReadOnly:  false,
 			MountPath: fmt.Sprintf("/clabernetes/clab-clabernetes-%s", nodeName),
 		},
@@ -1254,43 +1640,43 @@ func determineNodeNeedsRestart(
 	currentConfig := reconcileData.ResolvedConfigs[nodeName]
 
 	if previousConfig.Debug != currentConfig.Debug {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "containerlab debug setting changed")
 
 		return
 	}
 
 	if previousConfig.Name != currentConfig.Name {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "topology name changed")
 
 		return
 	}
 
 	if !reflect.DeepEqual(previousConfig.Mgmt, currentConfig.Mgmt) {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "management network changed")
 
 		return
 	}
 
 	if !reflect.DeepEqual(previousConfig.Prefix, currentConfig.Prefix) {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "prefix changed")
 
 		return
 	}
 
 	if !reflect.DeepEqual(previousConfig.Topology.Nodes, currentConfig.Topology.Nodes) {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "node definition changed")
 
 		return
 	}
 
 	if !reflect.DeepEqual(previousConfig.Topology.Kinds, currentConfig.Topology.Kinds) {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "kinds changed")
 
 		return
 	}
 
 	if !reflect.DeepEqual(previousConfig.Topology.Defaults, currentConfig.Topology.Defaults) {
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "defaults changed")
 
 		return
 	}
@@ -1298,25 +1684,46 @@ func determineNodeNeedsRestart(
 	if len(previousConfig.Topology.Links) != len(currentConfig.Topology.Links) {
 		// dont bother checking links since they cant be same/same, node needs rebooted to restart
 		// clab bits
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "links changed")
 
 		return
 	}
 
-	// we know (because we set this) that topology will never be nil and links will always be slices
-	// that are len 2... so we are a little risky here but its probably ok :)
+	// we know (because we set this) that topology will never be nil and that tunneled links are
+	// always "local" node first, so we only need to compare the local side of the links
 	for idx := range previousConfig.Topology.Links {
-		previousASide := previousConfig.Topology.Links[idx].Endpoints[0]
-		currentASide := currentConfig.Topology.Links[idx].Endpoints[0]
-
-		if previousASide == currentASide {
-			// as long as "a" side is the same, things will auto update itself since launcher is
-			// watching the connectivity cr
+		if linkLocalSideEqual(
+			previousConfig.Topology.Links[idx],
+			currentConfig.Topology.Links[idx],
+		) {
+			// as long as the local side is the same, things will auto update itself since
+			// launcher is watching the connectivity cr
 			continue
 		}
 
-		reconcileData.NodesNeedingReboot.Add(nodeName)
+		reconcileData.addNodeNeedingReboot(nodeName, "links changed")
 
 		return
 	}
 }
+
+func linkLocalSideEqual(
+	previousLink, currentLink *clabernetesutilcontainerlab.LinkDefinition,
+) bool {
+	if previousLink.IsBrief() && currentLink.IsBrief() {
+		if len(previousLink.Endpoints) == 0 || len(currentLink.Endpoints) == 0 {
+			return reflect.DeepEqual(previousLink, currentLink)
+		}
+
+		// the "b" side of a tunneled link is the host interface which is derived from the "a"
+		// side, so comparing both sides is the same as comparing the local side -- but links that
+		// stay within a node group have a "real" b side that we must not ignore
+		return slices.Equal(previousLink.Endpoints, currentLink.Endpoints) &&
+			previousLink.MTU == currentLink.MTU
+	}
+
+	// extended links are either the local side of a tunnel (a host link we render, which only
+	// contains the local side of things anyway) or links that live entirely on this node, so
+	// any change at all means we need a restart
+	return reflect.DeepEqual(previousLink, currentLink)
+}
@@ -17,6 +17,7 @@ import (
 	k8scorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	clientgorecord "k8s.io/client-go/tools/record"
 )
 
 const renderDeploymentTestName = "deployment/render-deployment"
@@ -93,9 +94,11 @@ func TestResolveDeployment(t *testing.T) {
 
 				reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
 					&claberneteslogging.FakeInstance{},
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					testCase.criKind,
+					"",
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -132,8 +135,10 @@ func TestRenderDeployment(t *testing.T) {
 		name                 string
 		owningTopology       *clabernetesapisv1alpha1.Topology
 		criKind              string
+		captureCA            string
 		imagePullThroughMode string
 		clabernetesConfigs   map[string]*clabernetesutilcontainerlab.Config
+		tunnels              map[string][]*clabernetesapisv1alpha1.PointToPointTunnel
 		nodeName             string
 		configManagerGetter  clabernetesconfig.ManagerGetterFunc
 	}{
@@ -196,6 +201,66 @@ func TestRenderDeployment(t *testing.T) {
 			nodeName:            "srl1",
 			configManagerGetter: clabernetesconfig.GetFakeManager,
 		},
+		{
+			name: "capture-ca",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+			},
+			captureCA: "-----BEGIN CERTIFICATE-----\nMIIB...\n-----END CERTIFICATE-----\n",
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+								"33333:57400/tcp",
+								"60000:21/tcp",
+								"60001:80/tcp",
+								"60002:443/tcp",
+								"60003:830/tcp",
+								"60004:5000/tcp",
+								"60005:5900/tcp",
+								"60006:6030/tcp",
+								"60007:9339/tcp",
+								"60008:9340/tcp",
+								"60009:9559/tcp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
 		{
 			name: "not-privileged-launcher",
 			owningTopology: &clabernetesapisv1alpha1.Topology{
@@ -706,6 +771,277 @@ func TestRenderDeployment(t *testing.T) {
 			nodeName:            "srl1",
 			configManagerGetter: clabernetesconfig.GetFakeManager,
 		},
+		{
+			name: "restore-from-snapshot",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						RestoreFromSnapshot: "golden",
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(true),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:          "srl",
+								Image:         "ghcr.io/nokia/srlinux",
+								StartupConfig: "snapshot/srl1/startup-config",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "paused",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Paused: true,
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(false),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "wait-for",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+          wait-for:
+            - srl1
+`,
+					},
+					StatusProbes: clabernetesapisv1alpha1.StatusProbes{
+						Enabled: true,
+						ProbeConfiguration: clabernetesapisv1alpha1.ProbeConfiguration{
+							TCPProbeConfiguration: &clabernetesapisv1alpha1.TCPProbeConfiguration{
+								Port: 22,
+							},
+						},
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(false),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl2": {
+					Name:   "srl2",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl2": {
+								Kind:    "srl",
+								Image:   "ghcr.io/nokia/srlinux",
+								WaitFor: []string{"srl1"},
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl2",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "ssh-probe-credentials-secret",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+					StatusProbes: clabernetesapisv1alpha1.StatusProbes{
+						Enabled: true,
+						ProbeConfiguration: clabernetesapisv1alpha1.ProbeConfiguration{
+							SSHProbeConfiguration: &clabernetesapisv1alpha1.SSHProbeConfiguration{
+								Username:          "admin",
+								CredentialsSecret: "srl-credentials",
+							},
+						},
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(false),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "additional-probes",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+					StatusProbes: clabernetesapisv1alpha1.StatusProbes{
+						Enabled: true,
+						ProbeConfiguration: clabernetesapisv1alpha1.ProbeConfiguration{
+							GNMIProbeConfiguration: &clabernetesapisv1alpha1.GNMIProbeConfiguration{
+								Insecure:          true,
+								CredentialsSecret: "srl-credentials",
+							},
+							NETCONFProbe: &clabernetesapisv1alpha1.NETCONFProbeConfiguration{
+								Username:          "admin",
+								CredentialsSecret: "srl-credentials",
+							},
+							HTTPProbeConfiguration: &clabernetesapisv1alpha1.HTTPProbeConfiguration{
+								Port:       443,
+								Path:       "/healthz",
+								Scheme:     "HTTPS",
+								StatusCode: 204,
+							},
+							ExecProbeConfiguration: &clabernetesapisv1alpha1.ExecProbeConfiguration{
+								Command:     []string{"sr_cli", "info from state system app-management"},
+								OutputRegex: "state running",
+							},
+						},
+					},
+				},
+				Status: clabernetesapisv1alpha1.TopologyStatus{
+					RemoveTopologyPrefix: clabernetesutil.ToPointer(false),
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{},
+						Kinds:    nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
 		{
 			name: "simple-node-selectors",
 			owningTopology: &clabernetesapisv1alpha1.Topology{
@@ -775,6 +1111,155 @@ func TestRenderDeployment(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "native-mode",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityVXLAN,
+					Deployment: clabernetesapisv1alpha1.Deployment{
+						NativeMode: clabernetesapisv1alpha1.NativeMode{
+							Nodes: []string{"srl1"},
+						},
+						FilesFromConfigMap: map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+							"srl1": {
+								{
+									FilePath:      "srl1.cfg",
+									ConfigMapName: "srl1-config",
+									ConfigMapPath: "config.json",
+								},
+							},
+						},
+						Persistence: clabernetesapisv1alpha1.Persistence{
+							Enabled: true,
+						},
+					},
+					ImagePull: clabernetesapisv1alpha1.ImagePull{
+						PullSecrets: []string{"regcred"},
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+          startup-config: srl1.cfg
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:          "srl",
+								Image:         "ghcr.io/nokia/srlinux",
+								StartupConfig: "srl1.cfg",
+								Env: map[string]string{
+									"FOO": "bar",
+								},
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
+		{
+			name: "multus",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-deployment-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Connectivity: clabernetesconstants.ConnectivityMultus,
+					Multus: clabernetesapisv1alpha1.Multus{
+						Type:   clabernetesconstants.MultusTypeVLAN,
+						Master: "eth1",
+					},
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+        srl2:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+      links:
+        - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+        - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+`,
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1": {
+					Name:   "srl1",
+					Prefix: clabernetesutil.ToPointer(""),
+					Topology: &clabernetesutilcontainerlab.Topology{
+						Defaults: &clabernetesutilcontainerlab.NodeDefinition{
+							Ports: []string{
+								"21022:22/tcp",
+								"21023:23/tcp",
+								"21161:161/udp",
+							},
+						},
+						Kinds: nil,
+						Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+							"srl1": {
+								Kind:  "srl",
+								Image: "ghcr.io/nokia/srlinux",
+							},
+						},
+						Links: nil,
+					},
+					Debug: false,
+				},
+			},
+			tunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+				"srl1": {
+					{
+						TunnelID:        2,
+						LocalNode:       "srl1",
+						LocalInterface:  "e1-2",
+						RemoteNode:      "srl2",
+						RemoteInterface: "e1-2",
+					},
+					{
+						TunnelID:        1,
+						LocalNode:       "srl1",
+						LocalInterface:  "e1-1",
+						RemoteNode:      "srl2",
+						RemoteInterface: "e1-1",
+					},
+				},
+			},
+			nodeName:            "srl1",
+			configManagerGetter: clabernetesconfig.GetFakeManager,
+		},
 	}
 
 	for _, testCase := range cases {
@@ -785,15 +1270,18 @@ func TestRenderDeployment(t *testing.T) {
 
 				reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
 					&claberneteslogging.FakeInstance{},
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					testCase.criKind,
+					testCase.captureCA,
 					testCase.configManagerGetter,
 				)
 
 				got := reconciler.Render(
 					testCase.owningTopology,
 					testCase.clabernetesConfigs,
+					testCase.tunnels,
 					testCase.nodeName,
 				)
 
@@ -1506,9 +1994,11 @@ func TestDeploymentConforms(t *testing.T) {
 
 				reconciler := clabernetescontrollerstopology.NewDeploymentReconciler(
 					&claberneteslogging.FakeInstance{},
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					"",
+					"",
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -0,0 +1,277 @@
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	apimachineryunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const multusCNIVersion = "0.3.1"
+
+// multusNetwork is an entry of the multus networks annotation of a launcher pod.
+type multusNetwork struct {
+	Name      string `json:"name"`
+	Interface string `json:"interface"`
+}
+
+// multusNetworkAttachmentDefinitionName returns the name of the NetworkAttachmentDefinition of the
+// link the given tunnel carries -- both sides of a link share the tunnel id, and so the name too.
+func multusNetworkAttachmentDefinitionName(
+	owningTopologyName string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) string {
+	return clabernetesutilkubernetes.SafeConcatNameKubernetes(
+		owningTopologyName,
+		"link",
+		strconv.Itoa(tunnel.TunnelID),
+	)
+}
+
+// multusInterfaceName returns the name of the (launcher pod) interface of the network attachment
+// of the link the given tunnel carries, the launcher stitches this interface to the node link.
+func multusInterfaceName(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return fmt.Sprintf("%s-%d", clabernetesconstants.MultusInterfacePrefix, tunnel.TunnelID)
+}
+
+// multusVLANID returns the vlan id of the link with the given tunnel id -- tunnel ids are unique
+// per topology and start at 1, so the links of the topology get consecutive vlan ids starting at
+// the vlan base of the topology.
+func multusVLANID(owningTopology *clabernetesapisv1alpha1.Topology, tunnelID int) int {
+	vlanBase := owningTopology.Spec.Multus.VLANBase
+	if vlanBase == 0 {
+		vlanBase = clabernetesconstants.MultusVLANBaseDefault
+	}
+
+	return vlanBase + tunnelID - 1
+}
+
+// renderMultusCNIConfig returns the cni config for the NetworkAttachmentDefinition of the link with
+// the given vlan id, the vlan id is what keeps the links apart from each other.
+func renderMultusCNIConfig(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	name string,
+	vlanID int,
+) string {
+	multus := owningTopology.Spec.Multus
+
+	config := map[string]any{
+		"cniVersion": multusCNIVersion,
+		"name":       name,
+	}
+
+	switch multus.Type {
+	case clabernetesconstants.MultusTypeMACVLAN:
+		config["type"] = clabernetesconstants.MultusTypeMACVLAN
+		config["master"] = fmt.Sprintf("%s.%d", multus.Master, vlanID)
+		config["mode"] = "bridge"
+	case clabernetesconstants.MultusTypeBridge:
+		config["type"] = clabernetesconstants.MultusTypeBridge
+		config["vlan"] = vlanID
+
+		if multus.Master != "" {
+			config["bridge"] = multus.Master
+		}
+	default:
+		config["type"] = clabernetesconstants.MultusTypeVLAN
+		config["master"] = multus.Master
+		config["vlanId"] = vlanID
+	}
+
+	if multus.MTU != 0 {
+		config["mtu"] = multus.MTU
+	}
+
+	// marshaling a map sorts the keys, so the rendered config is stable between reconciles
+	rendered, _ := json.Marshal(config) //nolint:errchkjson
+
+	return string(rendered)
+}
+
+// RenderNetworkAttachmentDefinitions returns the rendered (multus) NetworkAttachmentDefinitions
+// for the given topology/tunnels -- that is one per link that spans launchers. Tunnel ids must
+// already be allocated as the ids end up in the names and (as vlan ids) configs of the definitions.
+func (r *ConnectivityReconciler) RenderNetworkAttachmentDefinitions(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) []*apimachineryunstructured.Unstructured {
+	owningTopologyName := owningTopology.GetName()
+
+	annotations, globalLabels := r.configManagerGetter().GetAllMetadata()
+
+	renderedDefinitions := map[string]*apimachineryunstructured.Unstructured{}
+
+	for _, launcherTunnels := range tunnels {
+		for _, tunnel := range launcherTunnels {
+			name := multusNetworkAttachmentDefinitionName(owningTopologyName, tunnel)
+
+			_, ok := renderedDefinitions[name]
+			if ok {
+				// already rendered from the other side of the link
+				continue
+			}
+
+			vlanID := multusVLANID(owningTopology, tunnel.TunnelID)
+			if vlanID > clabernetesconstants.MultusVLANIDMax {
+				// the webhook rejects topologies with more links than fit the vlan range, but
+				// tunnel ids are not necessarily contiguous, so better safe than sorry -- the
+				// launchers report the links without attachment as failed
+				r.log.Warnf(
+					"vlan id %d of network attachment definition %q exceeds the maximum vlan id,"+
+						" skipping",
+					vlanID,
+					name,
+				)
+
+				continue
+			}
+
+			labels := map[string]string{
+				clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+				clabernetesconstants.LabelName:          name,
+				clabernetesconstants.LabelTopologyOwner: owningTopologyName,
+				clabernetesconstants.LabelTopologyKind:  GetTopologyKind(owningTopology),
+			}
+
+			maps.Copy(labels, globalLabels)
+
+			definition := &apimachineryunstructured.Unstructured{
+				Object: map[string]any{
+					"spec": map[string]any{
+						"config": renderMultusCNIConfig(owningTopology, name, vlanID),
+					},
+				},
+			}
+
+			definition.SetAPIVersion(clabernetesconstants.MultusGroupVersion)
+			definition.SetKind(clabernetesconstants.MultusNetworkAttachmentDefinitionKind)
+			definition.SetName(name)
+			definition.SetNamespace(owningTopology.GetNamespace())
+			definition.SetAnnotations(maps.Clone(annotations))
+			definition.SetLabels(labels)
+
+			renderedDefinitions[name] = definition
+		}
+	}
+
+	return slices.SortedFunc(
+		maps.Values(renderedDefinitions),
+		func(a, b *apimachineryunstructured.Unstructured) int {
+			return strings.Compare(a.GetName(), b.GetName())
+		},
+	)
+}
+
+// NetworkAttachmentDefinitionConforms checks if the existing NetworkAttachmentDefinition conforms
+// to the rendered expectation.
+func (r *ConnectivityReconciler) NetworkAttachmentDefinitionConforms(
+	existingDefinition,
+	renderedDefinition *apimachineryunstructured.Unstructured,
+	expectedOwnerUID apimachinerytypes.UID,
+) bool {
+	existingConfig, _, _ := apimachineryunstructured.NestedString(
+		existingDefinition.Object,
+		"spec",
+		"config",
+	)
+
+	renderedConfig, _, _ := apimachineryunstructured.NestedString(
+		renderedDefinition.Object,
+		"spec",
+		"config",
+	)
+
+	if existingConfig != renderedConfig {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingDefinition.GetAnnotations(),
+		renderedDefinition.GetAnnotations(),
+	) {
+		return false
+	}
+
+	if !clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+		existingDefinition.GetLabels(),
+		renderedDefinition.GetLabels(),
+	) {
+		return false
+	}
+
+	ownerReferences := existingDefinition.GetOwnerReferences()
+
+	if len(ownerReferences) != 1 {
+		// we should have only one owner reference, the topology
+		return false
+	}
+
+	if ownerReferences[0].UID != expectedOwnerUID {
+		// owner ref uid is not us
+		return false
+	}
+
+	return true
+}
+
+// renderDeploymentMultus adds the multus networks annotation to the launcher pod template when
+// using the multus connectivity flavor, so the pod gets a network attachment for every one of its
+// links that spans launchers.
+func (r *DeploymentReconciler) renderDeploymentMultus(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	tunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		return
+	}
+
+	launcherTunnels := tunnels[nodeName]
+	if len(launcherTunnels) == 0 {
+		return
+	}
+
+	networks := make([]multusNetwork, 0, len(launcherTunnels))
+
+	for _, tunnel := range launcherTunnels {
+		if multusVLANID(owningTopology, tunnel.TunnelID) > clabernetesconstants.MultusVLANIDMax {
+			// there is no definition for this link, see RenderNetworkAttachmentDefinitions
+			continue
+		}
+
+		networks = append(
+			networks,
+			multusNetwork{
+				Name:      multusNetworkAttachmentDefinitionName(owningTopology.GetName(), tunnel),
+				Interface: multusInterfaceName(tunnel),
+			},
+		)
+	}
+
+	slices.SortFunc(networks, func(a, b multusNetwork) int {
+		return strings.Compare(a.Interface, b.Interface)
+	})
+
+	renderedNetworks, _ := json.Marshal(networks)
+
+	// the pod template shares its annotations map with the deployment itself, so copy it rather
+	// than putting the networks annotation on the deployment too
+	annotations := maps.Clone(deployment.Spec.Template.ObjectMeta.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[clabernetesconstants.MultusNetworksAnnotation] = string(renderedNetworks)
+
+	deployment.Spec.Template.ObjectMeta.Annotations = annotations
+}
@@ -0,0 +1,345 @@
+package topology
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+)
+
+const nativeModeLauncherContainerName = "launcher"
+
+// nativeModeKind holds the bits containerlab would otherwise set up for a node of a given kind when
+// running it via docker -- in native mode we have to do this ourselves in the pod spec.
+type nativeModeKind struct {
+	command           []string
+	env               map[string]string
+	startupConfigPath string
+	persistencePath   string
+}
+
+//nolint:gochecknoglobals
+var nativeModeKinds = map[string]nativeModeKind{
+	clabernetesconstants.KindSRL: {
+		command: []string{
+			"sudo",
+			"bash",
+			"-c",
+			"touch /.dockerenv && /opt/srlinux/bin/sr_linux",
+		},
+		env: map[string]string{
+			"SRLINUX": "1",
+		},
+		startupConfigPath: "/etc/opt/srlinux/config.json",
+		persistencePath:   "/etc/opt/srlinux",
+	},
+	clabernetesconstants.KindCEOS: {
+		command: []string{
+			"/sbin/init",
+			"systemd.setenv=INTFTYPE=eth",
+			"systemd.setenv=ETBA=1",
+			"systemd.setenv=SKIP_ZEROTOUCH_BARRIER_IN_SYSDBINIT=1",
+			"systemd.setenv=CEOS=1",
+			"systemd.setenv=EOS_PLATFORM=ceoslab",
+			"systemd.setenv=container=docker",
+			"systemd.setenv=MAPETH0=1",
+			"systemd.setenv=MGMT_INTF=eth0",
+		},
+		env: map[string]string{
+			"CEOS":                                "1",
+			"EOS_PLATFORM":                        "ceoslab",
+			"container":                           "docker",
+			"ETBA":                                "1",
+			"SKIP_ZEROTOUCH_BARRIER_IN_SYSDBINIT": "1",
+			"INTFTYPE":                            "eth",
+			"MAPETH0":                             "1",
+			"MGMT_INTF":                           "eth0",
+		},
+		startupConfigPath: "/mnt/flash/startup-config",
+		persistencePath:   "/mnt/flash",
+	},
+	clabernetesconstants.KindLinux: {},
+}
+
+// getNativeModeKind returns the native mode settings for the given containerlab kind, handling the
+// long/short kind name aliases.
+func getNativeModeKind(containerlabKind string) nativeModeKind {
+	switch containerlabKind {
+	case clabernetesconstants.KindNokiaSRLinux:
+		containerlabKind = clabernetesconstants.KindSRL
+	case clabernetesconstants.KindAristaCEOS:
+		containerlabKind = clabernetesconstants.KindCEOS
+	}
+
+	return nativeModeKinds[containerlabKind]
+}
+
+// isNativeModeNode returns true if the given node is configured to run in native mode.
+func isNativeModeNode(owningTopology *clabernetesapisv1alpha1.Topology, nodeName string) bool {
+	return slices.Contains(owningTopology.Spec.Deployment.NativeMode.Nodes, nodeName)
+}
+
+// renderDeploymentNativeMode turns the "normal" launcher deployment of a native mode node into a
+// native mode one -- the launcher container is moved to a (native) sidecar and the node image is
+// rendered as the "main" container of the pod. This runs after all the other render steps so that
+// all the things that were rendered for the launcher container can be split up between the two.
+func (r *DeploymentReconciler) renderDeploymentNativeMode(
+	deployment *k8sappsv1.Deployment,
+	nodeName string,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) {
+	if !isNativeModeNode(owningTopology, nodeName) {
+		return
+	}
+
+	clabTopo := clabernetesConfigs[nodeName].Topology
+
+	containerlabKind, _ := clabTopo.GetNodeKindType(nodeName)
+
+	kind := getNativeModeKind(containerlabKind)
+
+	launcher := deployment.Spec.Template.Spec.Containers[0]
+
+	node := k8scorev1.Container{
+		Name:                     nodeName,
+		Image:                    clabTopo.GetNodeImage(nodeName),
+		Env:                      renderNativeModeNodeEnv(kind, clabTopo.GetNodeEnv(nodeName)),
+		Resources:                launcher.Resources,
+		SecurityContext:          launcher.SecurityContext,
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: "File",
+		ImagePullPolicy: renderNativeModeNodeImagePullPolicy(
+			clabTopo.GetNodeImagePullPolicy(nodeName),
+		),
+	}
+
+	node.Command, node.Args = renderNativeModeNodeCommand(kind, clabTopo.Nodes[nodeName])
+
+	node.VolumeMounts, launcher.VolumeMounts = renderNativeModeVolumeMounts(
+		kind,
+		clabTopo,
+		nodeName,
+		launcher.VolumeMounts,
+	)
+
+	deployment.Spec.Template.Spec.Volumes = slices.DeleteFunc(
+		deployment.Spec.Template.Spec.Volumes,
+		func(volume k8scorev1.Volume) bool {
+			return isNativeModeDockerVolume(volume.Name)
+		},
+	)
+
+	launcher.Name = nativeModeLauncherContainerName
+	// restart policy always makes this a "native" sidecar -- it is started before the node
+	// container and kept running next to it
+	launcher.RestartPolicy = clabernetesutil.ToPointer(k8scorev1.ContainerRestartPolicyAlways)
+	launcher.Resources = k8scorev1.ResourceRequirements{}
+	launcher.SecurityContext = &k8scorev1.SecurityContext{
+		Privileged: clabernetesutil.ToPointer(false),
+		RunAsUser:  clabernetesutil.ToPointer(int64(0)),
+		Capabilities: &k8scorev1.Capabilities{
+			Add: []k8scorev1.Capability{
+				"NET_ADMIN",
+				"NET_RAW",
+			},
+		},
+	}
+	launcher.Env = append(
+		launcher.Env,
+		k8scorev1.EnvVar{
+			Name:  clabernetesconstants.LauncherNativeModeEnv,
+			Value: clabernetesconstants.True,
+		},
+	)
+
+	failureThresholds := probeDefaultStartupFailureThreshold
+
+	if len(getLauncherWaitFor(clabernetesConfigs, nodeName)) > 0 {
+		failureThresholds += int(clabernetesconstants.LauncherWaitForTimeout.Seconds()) /
+			probePeriodSeconds
+	}
+
+	// the node container is only started once the sidecar startup probe succeeds, so rather than
+	// waiting on the node to be healthy (which would never happen...) we only wait on the launcher
+	// having plumbed the node interfaces
+	launcher.StartupProbe = &k8scorev1.Probe{
+		ProbeHandler: k8scorev1.ProbeHandler{
+			Exec: &k8scorev1.ExecAction{
+				Command: []string{
+					"test",
+					"-f",
+					clabernetesconstants.NativeModeReadyFile,
+				},
+			},
+		},
+		TimeoutSeconds:   1,
+		SuccessThreshold: 1,
+		PeriodSeconds:    probePeriodSeconds,
+		FailureThreshold: int32(failureThresholds), //nolint:gosec
+	}
+
+	deployment.Spec.Template.Spec.InitContainers = []k8scorev1.Container{launcher}
+	deployment.Spec.Template.Spec.Containers = []k8scorev1.Container{node}
+
+	if len(owningTopology.Spec.ImagePull.PullSecrets) > 0 {
+		imagePullSecrets := make(
+			[]k8scorev1.LocalObjectReference,
+			len(owningTopology.Spec.ImagePull.PullSecrets),
+		)
+
+		for idx, pullSecret := range owningTopology.Spec.ImagePull.PullSecrets {
+			imagePullSecrets[idx] = k8scorev1.LocalObjectReference{Name: pullSecret}
+		}
+
+		deployment.Spec.Template.Spec.ImagePullSecrets = imagePullSecrets
+	}
+}
+
+// isNativeModeDockerVolume returns true if the given volume only exists to serve the docker daemon
+// in the launcher, which native mode launchers do not run.
+func isNativeModeDockerVolume(volumeName string) bool {
+	switch volumeName {
+	case "docker", "cri-sock", "docker-daemon-config", "docker-config":
+		return true
+	default:
+		return false
+	}
+}
+
+func renderNativeModeNodeEnv(
+	kind nativeModeKind,
+	nodeEnv map[string]string,
+) []k8scorev1.EnvVar {
+	env := make(map[string]string, len(kind.env)+len(nodeEnv))
+
+	maps.Copy(env, kind.env)
+	maps.Copy(env, nodeEnv)
+
+	envs := make([]k8scorev1.EnvVar, 0, len(env))
+
+	// sorted so we always render the same container and dont trip conforms checks
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		envs = append(envs, k8scorev1.EnvVar{Name: k, Value: env[k]})
+	}
+
+	return envs
+}
+
+func renderNativeModeNodeImagePullPolicy(imagePullPolicy string) k8scorev1.PullPolicy {
+	switch strings.ToLower(imagePullPolicy) {
+	case strings.ToLower(string(k8scorev1.PullAlways)):
+		return k8scorev1.PullAlways
+	case strings.ToLower(string(k8scorev1.PullNever)):
+		return k8scorev1.PullNever
+	default:
+		return k8scorev1.PullIfNotPresent
+	}
+}
+
+// renderNativeModeNodeCommand returns the command and args for the node container -- an entrypoint
+// and/or cmd set on the node in the containerlab topology wins, otherwise we use what containerlab
+// would use for the kind.
+func renderNativeModeNodeCommand(
+	kind nativeModeKind,
+	nodeDefinition *clabernetesutilcontainerlab.NodeDefinition,
+) (command, args []string) {
+	if nodeDefinition == nil || (nodeDefinition.Entrypoint == "" && nodeDefinition.Cmd == "") {
+		return kind.command, nil
+	}
+
+	if nodeDefinition.Entrypoint != "" {
+		command = strings.Fields(nodeDefinition.Entrypoint)
+	}
+
+	if nodeDefinition.Cmd != "" {
+		args = strings.Fields(nodeDefinition.Cmd)
+	}
+
+	return command, args
+}
+
+// renderNativeModeVolumeMounts splits the volume mounts rendered for the launcher container up
+// between the node container and the launcher sidecar. Device and persistence mounts move to the
+// node container, the docker mounts are dropped, and the files the node references (its startup
+// config and binds) are mounted into the node container where the node expects them.
+func renderNativeModeVolumeMounts(
+	kind nativeModeKind,
+	clabTopo *clabernetesutilcontainerlab.Topology,
+	nodeName string,
+	launcherVolumeMounts []k8scorev1.VolumeMount,
+) (nodeVolumeMounts, remainingLauncherVolumeMounts []k8scorev1.VolumeMount) {
+	// file path (as the launcher sees it) -> path the node container wants it at
+	nodeFiles := make(map[string]string)
+
+	startupConfig := clabTopo.GetNodeStartupConfig(nodeName)
+	if startupConfig != "" && kind.startupConfigPath != "" {
+		nodeFiles[nativeModeLauncherFilePath(startupConfig)] = kind.startupConfigPath
+	}
+
+	for _, bind := range clabTopo.GetNodeBinds(nodeName) {
+		source, destination, ok := strings.Cut(bind, ":")
+		if !ok {
+			continue
+		}
+
+		// drop the (optional) mode suffix of the bind
+		destination, _, _ = strings.Cut(destination, ":")
+
+		nodeFiles[nativeModeLauncherFilePath(source)] = destination
+	}
+
+	nodeVolumeMounts = make([]k8scorev1.VolumeMount, 0)
+	nodeFileVolumeMounts := make([]k8scorev1.VolumeMount, 0)
+	remainingLauncherVolumeMounts = make([]k8scorev1.VolumeMount, 0)
+
+	for _, volumeMount := range launcherVolumeMounts {
+		switch {
+		case isNativeModeDockerVolume(volumeMount.Name):
+			continue
+		case strings.HasPrefix(volumeMount.Name, "dev-"):
+			nodeVolumeMounts = append(nodeVolumeMounts, volumeMount)
+
+			continue
+		case volumeMount.Name == persistenceVolumeName && kind.persistencePath != "":
+			volumeMount.MountPath = kind.persistencePath
+
+			nodeVolumeMounts = append(nodeVolumeMounts, volumeMount)
+
+			continue
+		}
+
+		remainingLauncherVolumeMounts = append(remainingLauncherVolumeMounts, volumeMount)
+
+		nodePath, ok := nodeFiles[volumeMount.MountPath]
+		if !ok {
+			continue
+		}
+
+		volumeMount.MountPath = nodePath
+
+		nodeFileVolumeMounts = append(nodeFileVolumeMounts, volumeMount)
+	}
+
+	// file mounts go last so they are mounted on top of a persistence mount of the same directory
+	nodeVolumeMounts = append(nodeVolumeMounts, nodeFileVolumeMounts...)
+
+	return nodeVolumeMounts, remainingLauncherVolumeMounts
+}
+
+// nativeModeLauncherFilePath returns the path a file referenced in the containerlab topology ends
+// up at in the launcher -- relative paths are relative to the topology file in /clabernetes.
+func nativeModeLauncherFilePath(path string) string {
+	if strings.HasPrefix(path, "/") {
+		return path
+	}
+
+	return fmt.Sprintf("/clabernetes/%s", strings.TrimPrefix(path, "./"))
+}
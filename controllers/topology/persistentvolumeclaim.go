@@ -243,7 +243,7 @@ func (r *PersistentVolumeClaimReconciler) renderPVCSpec(
 		storageClassName = clabernetesutil.ToPointer(persistence.StorageClassName)
 	}
 
-	pvcSize := resource.MustParse("5Gi")
+	pvcSize := resource.MustParse(clabernetesconstants.PersistenceClaimSizeDefault)
 
 	if persistence.ClaimSize != "" {
 		userClaimSize, err := resource.ParseQuantity(persistence.ClaimSize)
@@ -0,0 +1,201 @@
+package topology
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+// probeResultsConfigMapName returns the name of the ConfigMap the launchers of the given topology
+// report the results of their status probes to.
+func probeResultsConfigMapName(owningTopologyName string) string {
+	return clabernetesutilkubernetes.SafeConcatNameKubernetes(
+		owningTopologyName,
+		clabernetesconstants.ProbeResultsConfigMapSuffix,
+	)
+}
+
+// NodeProbeResults returns the status probe results the launchers reported that should be shown in
+// the status of the Topology -- that is the results of nodes that still exist (in any of the
+// launchers, as nodes may be grouped) and that still have their status probes enabled. While the
+// topology is paused there are no launchers to probe anything, so there are no results either.
+func NodeProbeResults(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	probeResults map[string][]*clabernetesapisv1alpha1.ProbeResult,
+) map[string][]*clabernetesapisv1alpha1.ProbeResult {
+	statusProbes := owningTopology.Spec.StatusProbes
+
+	if owningTopology.Spec.Paused || !statusProbes.Enabled {
+		return nil
+	}
+
+	var nodeProbeResults map[string][]*clabernetesapisv1alpha1.ProbeResult
+
+	for nodeName, nodeProbeResult := range probeResults {
+		if !topologyHasNode(clabernetesConfigs, nodeName) ||
+			slices.Contains(statusProbes.ExcludedNodes, nodeName) {
+			continue
+		}
+
+		if nodeProbeResults == nil {
+			nodeProbeResults = make(map[string][]*clabernetesapisv1alpha1.ProbeResult)
+		}
+
+		nodeProbeResults[nodeName] = nodeProbeResult
+	}
+
+	return nodeProbeResults
+}
+
+// topologyHasNode returns true if any of the given (launcher) configs holds the given node.
+func topologyHasNode(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	nodeName string,
+) bool {
+	for _, clabernetesConfig := range clabernetesConfigs {
+		if clabernetesConfig == nil || clabernetesConfig.Topology == nil {
+			continue
+		}
+
+		_, ok := clabernetesConfig.Topology.Nodes[nodeName]
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReconcileProbeResults ensures the probe results ConfigMap the launchers report their status
+// probe results to exists, drops the results of nodes that no longer exist from it, and copies the
+// results that matter to the status of the topology. The launchers report to this ConfigMap
+// rather than to the Connectivity cr since the launchers watch the latter and would otherwise
+// wake each other up with every report.
+func (r *Reconciler) ReconcileProbeResults(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	existingConfigMap := &k8scorev1.ConfigMap{}
+
+	err := r.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: owningTopology.GetNamespace(),
+			Name:      probeResultsConfigMapName(owningTopology.GetName()),
+		},
+		existingConfigMap,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		return r.createProbeResultsConfigMap(ctx, owningTopology, reconcileData)
+	}
+
+	probeResults := make(map[string][]*clabernetesapisv1alpha1.ProbeResult)
+
+	var staleNodes []string
+
+	for nodeName, rawNodeProbeResults := range existingConfigMap.Data {
+		if !topologyHasNode(reconcileData.ResolvedConfigs, nodeName) {
+			staleNodes = append(staleNodes, nodeName)
+
+			continue
+		}
+
+		var nodeProbeResults []*clabernetesapisv1alpha1.ProbeResult
+
+		err = json.Unmarshal([]byte(rawNodeProbeResults), &nodeProbeResults)
+		if err != nil {
+			r.Log.Warnf(
+				"failed unmarshaling node %q probe results, ignoring, error: %s",
+				nodeName,
+				err,
+			)
+
+			continue
+		}
+
+		probeResults[nodeName] = nodeProbeResults
+	}
+
+	r.setNodeProbeResults(owningTopology, reconcileData, probeResults)
+
+	if len(staleNodes) == 0 {
+		return nil
+	}
+
+	for _, nodeName := range staleNodes {
+		delete(existingConfigMap.Data, nodeName)
+	}
+
+	return r.updateObj(ctx, existingConfigMap, clabernetesconstants.KubernetesConfigMap)
+}
+
+func (r *Reconciler) createProbeResultsConfigMap(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	annotations, globalLabels := r.configMapReconciler.configManagerGetter().GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:           clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:          owningTopology.GetName(),
+		clabernetesconstants.LabelTopologyOwner: owningTopology.GetName(),
+		clabernetesconstants.LabelTopologyKind:  GetTopologyKind(owningTopology),
+	}
+
+	maps.Copy(labels, globalLabels)
+
+	// nothing reported yet, so nothing to show either
+	r.setNodeProbeResults(owningTopology, reconcileData, nil)
+
+	return r.createObj(
+		ctx,
+		owningTopology,
+		&k8scorev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        probeResultsConfigMapName(owningTopology.GetName()),
+				Namespace:   owningTopology.GetNamespace(),
+				Annotations: annotations,
+				Labels:      labels,
+			},
+		},
+		clabernetesconstants.KubernetesConfigMap,
+	)
+}
+
+func (r *Reconciler) setNodeProbeResults(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	probeResults map[string][]*clabernetesapisv1alpha1.ProbeResult,
+) {
+	nodeProbeResults := NodeProbeResults(
+		owningTopology,
+		reconcileData.ResolvedConfigs,
+		probeResults,
+	)
+
+	if reflect.DeepEqual(owningTopology.Status.NodeProbeResults, nodeProbeResults) {
+		return
+	}
+
+	owningTopology.Status.NodeProbeResults = nodeProbeResults
+
+	reconcileData.ShouldUpdateResource = true
+}
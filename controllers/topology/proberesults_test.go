@@ -0,0 +1,132 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func TestNodeProbeResults(t *testing.T) {
+	// srl2 is grouped into the srl1 launcher, so its results are still keyed by its own name
+	clabernetesConfigs := map[string]*clabernetesutilcontainerlab.Config{
+		"srl1": {
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"srl1": {},
+					"srl2": {},
+				},
+			},
+		},
+		"srl4": {
+			Topology: &clabernetesutilcontainerlab.Topology{
+				Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+					"srl4": {},
+				},
+			},
+		},
+	}
+
+	srl1ProbeResults := []*clabernetesapisv1alpha1.ProbeResult{
+		{
+			Probe:  "ssh",
+			Result: "success",
+		},
+	}
+
+	srl2ProbeResults := []*clabernetesapisv1alpha1.ProbeResult{
+		{
+			Probe:               "tcp",
+			Result:              "failure",
+			ConsecutiveFailures: 3,
+			LastError:           "dial tcp 10.0.0.2:22: connect: connection refused",
+		},
+	}
+
+	probeResults := map[string][]*clabernetesapisv1alpha1.ProbeResult{
+		"srl1": srl1ProbeResults,
+		"srl2": srl2ProbeResults,
+		"srl3": srl1ProbeResults,
+		"srl4": srl1ProbeResults,
+	}
+
+	cases := []struct {
+		name         string
+		statusProbes clabernetesapisv1alpha1.StatusProbes
+		paused       bool
+		probeResults map[string][]*clabernetesapisv1alpha1.ProbeResult
+		expected     map[string][]*clabernetesapisv1alpha1.ProbeResult
+	}{
+		{
+			name:         "no-results",
+			statusProbes: clabernetesapisv1alpha1.StatusProbes{Enabled: true},
+		},
+		{
+			name:         "probes-disabled",
+			probeResults: probeResults,
+		},
+		{
+			name:         "paused",
+			statusProbes: clabernetesapisv1alpha1.StatusProbes{Enabled: true},
+			paused:       true,
+			probeResults: probeResults,
+		},
+		{
+			name:         "stale-node",
+			statusProbes: clabernetesapisv1alpha1.StatusProbes{Enabled: true},
+			probeResults: probeResults,
+			expected: map[string][]*clabernetesapisv1alpha1.ProbeResult{
+				"srl1": srl1ProbeResults,
+				"srl2": srl2ProbeResults,
+				"srl4": srl1ProbeResults,
+			},
+		},
+		{
+			name: "excluded-node",
+			statusProbes: clabernetesapisv1alpha1.StatusProbes{
+				Enabled:       true,
+				ExcludedNodes: []string{"srl1", "srl4"},
+			},
+			probeResults: probeResults,
+			expected: map[string][]*clabernetesapisv1alpha1.ProbeResult{
+				"srl2": srl2ProbeResults,
+			},
+		},
+		{
+			name:         "grouped-node",
+			statusProbes: clabernetesapisv1alpha1.StatusProbes{Enabled: true},
+			probeResults: map[string][]*clabernetesapisv1alpha1.ProbeResult{
+				"srl2": srl2ProbeResults,
+			},
+			expected: map[string][]*clabernetesapisv1alpha1.ProbeResult{
+				"srl2": srl2ProbeResults,
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				got := clabernetescontrollerstopology.NodeProbeResults(
+					&clabernetesapisv1alpha1.Topology{
+						Spec: clabernetesapisv1alpha1.TopologySpec{
+							StatusProbes: testCase.statusProbes,
+							Paused:       testCase.paused,
+						},
+					},
+					clabernetesConfigs,
+					testCase.probeResults,
+				)
+
+				if !reflect.DeepEqual(got, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, got, testCase.expected)
+				}
+			})
+	}
+}
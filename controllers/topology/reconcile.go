@@ -2,48 +2,75 @@ package topology
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	k8scorev1 "k8s.io/api/core/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimeutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const teardownRequeueInterval = 5 * time.Second
+
 // Reconcile handles reconciliation for this controller.
 func (c *Controller) Reconcile(
 	ctx context.Context,
 	req ctrlruntime.Request,
 ) (ctrlruntime.Result, error) {
-	c.BaseController.LogReconcileStart(req)
+	c.BaseController.LogReconcileStart(ctx, req)
 
 	topology, err := c.getTopologyFromReq(ctx, req)
 	if err != nil {
 		if apimachineryerrors.IsNotFound(err) {
 			// was deleted, nothing to do
-			c.BaseController.LogReconcileCompleteObjectNotExist(req)
+			c.BaseController.LogReconcileCompleteObjectNotExist(ctx, req)
 
 			return ctrlruntime.Result{}, nil
 		}
 
-		c.BaseController.LogReconcileFailedGettingObject(req, err)
+		c.BaseController.LogReconcileFailedGettingObject(ctx, req, err)
 
 		return ctrlruntime.Result{}, err
 	}
 
 	if topology.DeletionTimestamp != nil {
-		// deleting nothing to do, we have no finalizers or anything at this point
-		return ctrlruntime.Result{}, nil
+		return c.reconcileTeardown(ctx, req, topology)
 	}
 
 	if c.BaseController.ShouldIgnoreReconcile(topology) {
 		return ctrlruntime.Result{}, nil
 	}
 
+	// make sure our finalizer is in place so we get the chance to gracefully tear things down when
+	// the topology gets deleted; we push this update right away so that a deletion happening
+	// before we finish reconciling still goes through our teardown
+	if ctrlruntimeutil.AddFinalizer(topology, clabernetesconstants.FinalizerTopologyTeardown) {
+		err = c.BaseController.Client.Update(ctx, topology)
+		if err != nil {
+			c.BaseController.Log.Criticalf(
+				"failed adding finalizer to object '%s/%s' error: %s",
+				topology.Namespace,
+				topology.Name,
+				err,
+			)
+
+			return ctrlruntime.Result{}, err
+		}
+	}
+
 	// we always reconcile the "namespace" resources first -- meaning the resources that exist in
 	// the namespace that are not 1:1 to a Topology -- for example: service account and role
 	// binding. These resources are created for the namespace on creation of the first Topology in
 	// the namespace, and are removed when the last Topology is removed from the namespace.
 	err = c.TopologyReconciler.ReconcileNamespaceResources(ctx, topology)
 	if err != nil {
+		c.recordReconcileFailed(topology, "failed reconciling namespace resources", err)
+
 		return ctrlruntime.Result{}, err
 	}
 
@@ -53,21 +80,44 @@ func (c *Controller) Reconcile(
 			"failed processing previously stored containerlab resource, error: %s", err,
 		)
 
+		c.recordConfigInvalid(topology, "failed processing previously stored definition", err)
+
 		return ctrlruntime.Result{}, err
 	}
 
 	// reconcile the naming -- we *must* do this to ensure that our status field is set!
 	c.TopologyReconciler.ReconcileNaming(topology, reconcileData)
 
+	restoreReady, err := c.resolveRestoreSnapshot(ctx, topology, reconcileData)
+	if err != nil {
+		c.BaseController.Log.Criticalf("failed resolving snapshot to restore, error: %s", err)
+
+		c.recordReconcileFailed(topology, "failed resolving snapshot to restore", err)
+
+		return ctrlruntime.Result{}, err
+	}
+
+	if !restoreReady {
+		// already logged and recorded, the snapshot watch brings us back once the snapshot
+		// completes
+		return ctrlruntime.Result{}, nil
+	}
+
 	err = c.processDefinition(topology, reconcileData)
 	if err != nil {
 		c.BaseController.Log.Criticalf("failed processing topology definition, error: %s", err)
 
+		c.recordConfigInvalid(topology, "failed processing topology definition", err)
+
 		return ctrlruntime.Result{}, err
 	}
 
+	c.recordIgnoredProbePasswords(topology)
+
 	err = c.reconcileResources(ctx, topology, reconcileData)
 	if err != nil {
+		c.recordReconcileFailed(topology, "failed reconciling topology resources", err)
+
 		return ctrlruntime.Result{}, err
 	}
 
@@ -99,7 +149,77 @@ func (c *Controller) Reconcile(
 		}
 	}
 
-	c.BaseController.LogReconcileCompleteSuccess(req)
+	c.BaseController.LogReconcileCompleteSuccess(ctx, req)
+
+	return ctrlruntime.Result{}, nil
+}
+
+func (c *Controller) reconcileTeardown(
+	ctx context.Context,
+	req ctrlruntime.Request,
+	topology *clabernetesapisv1alpha1.Topology,
+) (ctrlruntime.Result, error) {
+	if !ctrlruntimeutil.ContainsFinalizer(
+		topology,
+		clabernetesconstants.FinalizerTopologyTeardown,
+	) {
+		// not our finalizer (or we already finished), nothing to do
+		return ctrlruntime.Result{}, nil
+	}
+
+	var teardownComplete bool
+
+	if c.BaseController.ShouldIgnoreReconcile(topology) {
+		// ignoring reconciliation, so no graceful teardown, just get out of the way and let the
+		// owner references handle the clean up
+		teardownComplete = true
+	} else {
+		reconcileData, err := NewReconcileData(topology)
+		if err != nil {
+			c.BaseController.Log.Criticalf(
+				"failed processing previously stored containerlab resource, error: %s", err,
+			)
+
+			return ctrlruntime.Result{}, err
+		}
+
+		teardownComplete, err = c.TopologyReconciler.ReconcileTeardown(
+			ctx,
+			topology,
+			reconcileData,
+		)
+		if err != nil {
+			c.BaseController.Log.Criticalf("failed tearing down topology, error: %s", err)
+
+			c.recordReconcileFailed(topology, "failed tearing down topology", err)
+
+			return ctrlruntime.Result{}, err
+		}
+	}
+
+	if teardownComplete {
+		ctrlruntimeutil.RemoveFinalizer(topology, clabernetesconstants.FinalizerTopologyTeardown)
+	}
+
+	err := c.BaseController.Client.Update(ctx, topology)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed updating object '%s/%s' error: %s",
+			topology.Namespace,
+			topology.Name,
+			err,
+		)
+
+		return ctrlruntime.Result{}, err
+	}
+
+	if !teardownComplete {
+		// we watch the deployments but not the pods, so make sure we come back around to check
+		// on terminating launcher pods
+		return ctrlruntime.Result{RequeueAfter: teardownRequeueInterval}, nil
+	}
+
+	c.BaseController.LogReconcileCompleteSuccess(ctx, req)
 
 	return ctrlruntime.Result{}, nil
 }
@@ -109,7 +229,17 @@ func (c *Controller) reconcileResources(
 	topology *clabernetesapisv1alpha1.Topology,
 	reconcileData *ReconcileData,
 ) error {
-	err := c.TopologyReconciler.ReconcileConfigMap(
+	err := c.reconcileCaptureCertificate(ctx, topology)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling capture certificate secret, error: %s",
+			err,
+		)
+
+		return err
+	}
+
+	err = c.TopologyReconciler.ReconcileConfigMap(
 		ctx,
 		topology,
 		reconcileData,
@@ -137,6 +267,20 @@ func (c *Controller) reconcileResources(
 		return err
 	}
 
+	err = c.TopologyReconciler.ReconcileProbeResults(
+		ctx,
+		topology,
+		reconcileData,
+	)
+	if err != nil {
+		c.BaseController.Log.Criticalf(
+			"failed reconciling clabernetes probe results, error: %s",
+			err,
+		)
+
+		return err
+	}
+
 	err = c.TopologyReconciler.ReconcileServices(
 		ctx,
 		topology,
@@ -171,3 +315,79 @@ func (c *Controller) reconcileResources(
 
 	return nil
 }
+
+// recordConfigInvalid emits a warning event on the given topology reporting that its definition
+// could not be processed.
+func (c *Controller) recordConfigInvalid(
+	topology *clabernetesapisv1alpha1.Topology,
+	message string,
+	err error,
+) {
+	c.BaseController.Recorder.Eventf(
+		topology,
+		k8scorev1.EventTypeWarning,
+		clabernetesconstants.EventReasonConfigInvalid,
+		"%s: %s",
+		message,
+		err,
+	)
+}
+
+// recordReconcileFailed emits a warning event on the given topology reporting that (a part of) its
+// reconciliation failed.
+func (c *Controller) recordReconcileFailed(
+	topology *clabernetesapisv1alpha1.Topology,
+	message string,
+	err error,
+) {
+	c.BaseController.Recorder.Eventf(
+		topology,
+		k8scorev1.EventTypeWarning,
+		clabernetesconstants.EventReasonReconcileFailed,
+		"%s: %s",
+		message,
+		err,
+	)
+}
+
+// recordIgnoredProbePasswords warns (via the log and an event on the given topology) about ssh
+// probes that still set the deprecated plain text password. The validating webhook rejects those,
+// but topologies created before that still have them, and since we never render the plain text
+// password the launchers of those topologies quietly skip the ssh probe.
+func (c *Controller) recordIgnoredProbePasswords(topology *clabernetesapisv1alpha1.Topology) {
+	probeConfigurations := map[string]clabernetesapisv1alpha1.ProbeConfiguration{
+		"spec.statusProbes.probeConfiguration": topology.Spec.StatusProbes.ProbeConfiguration,
+	}
+
+	for nodeName, probeConfiguration := range topology.Spec.StatusProbes.NodeProbeConfigurations {
+		probeConfigurations[fmt.Sprintf(
+			"spec.statusProbes.nodeProbeConfigurations[%s]",
+			nodeName,
+		)] = probeConfiguration
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(probeConfigurations)) {
+		sshProbeConfiguration := probeConfigurations[path].SSHProbeConfiguration
+		if sshProbeConfiguration == nil || sshProbeConfiguration.Password == "" {
+			continue
+		}
+
+		c.BaseController.Log.Warnf(
+			"topology '%s/%s' sets a plain text ssh probe password in %s, plain text passwords"+
+				" are no longer supported and the ssh probe is skipped, use credentialsSecret"+
+				" instead",
+			topology.Namespace,
+			topology.Name,
+			path,
+		)
+
+		c.BaseController.Recorder.Eventf(
+			topology,
+			k8scorev1.EventTypeWarning,
+			clabernetesconstants.EventReasonProbePasswordIgnored,
+			"plain text ssh probe password in %s is ignored and the ssh probe is skipped, use"+
+				" credentialsSecret instead",
+			path,
+		)
+	}
+}
@@ -28,6 +28,12 @@ type ReconcileData struct {
 	TopologyReady        bool
 
 	NodesNeedingReboot clabernetesutil.StringSet
+	// NodeRebootReasons holds the (first) reason each of the NodesNeedingReboot needs a reboot.
+	NodeRebootReasons map[string]string
+
+	// RestoredNodes holds the names of the nodes that have a saved configuration in the
+	// TopologySnapshot the topology is restored from (if any).
+	RestoredNodes []string
 
 	ShouldUpdateResource bool
 }
@@ -54,6 +60,7 @@ func NewReconcileData(
 		PreviousNodeStatuses: owningTopology.Status.NodeReadiness,
 		NodeStatuses:         make(map[string]string),
 		NodesNeedingReboot:   clabernetesutil.NewStringSet(),
+		NodeRebootReasons:    make(map[string]string),
 	}
 
 	for nodeName, nodeConfig := range status.Configs {
@@ -96,6 +103,21 @@ func (r *ReconcileData) SetStatus(
 	return nil
 }
 
+// addNodeNeedingReboot adds the given node to the nodes needing a reboot, recording the reason it
+// needs one unless we already know of another reason.
+func (r *ReconcileData) addNodeNeedingReboot(nodeName, reason string) {
+	r.NodesNeedingReboot.Add(nodeName)
+
+	if r.NodeRebootReasons == nil {
+		r.NodeRebootReasons = make(map[string]string)
+	}
+
+	_, ok := r.NodeRebootReasons[nodeName]
+	if !ok {
+		r.NodeRebootReasons[nodeName] = reason
+	}
+}
+
 // ConfigMapHasChanges returns true if the data that gets stored in the topology configmap has
 // changed between the last reconcile and the current iteration. This is just a helper to be more
 // verbose/clear what we are checking rather than having a giant conditional in the Reconciler.
@@ -5,13 +5,11 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
-	"time"
 
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconfig "github.com/srl-labs/clabernetes/config"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
-	claberneteserrors "github.com/srl-labs/clabernetes/errors"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
 	k8sappsv1 "k8s.io/api/apps/v1"
@@ -19,7 +17,9 @@ import (
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
 	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	clientgorecord "k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimeutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -29,8 +29,9 @@ import (
 // common/standard resources that represent a clabernetes object (configmap, deployments,
 // services, etc.).
 type Reconciler struct {
-	Log    claberneteslogging.Instance
-	Client ctrlruntimeclient.Client
+	Log      claberneteslogging.Instance
+	Client   ctrlruntimeclient.Client
+	Recorder clientgorecord.EventRecorder
 
 	serviceAccountReconciler *ServiceAccountReconciler
 	roleBindingReconciler    *RoleBindingReconciler
@@ -50,14 +51,17 @@ type Reconciler struct {
 func NewReconciler(
 	log claberneteslogging.Instance,
 	client ctrlruntimeclient.Client,
+	recorder clientgorecord.EventRecorder,
 	managerAppName,
 	managerNamespace,
-	criKind string,
+	criKind,
+	captureCA string,
 	configManagerGetter clabernetesconfig.ManagerGetterFunc,
 ) *Reconciler {
 	return &Reconciler{
-		Log:    log,
-		Client: client,
+		Log:      log,
+		Client:   client,
+		Recorder: recorder,
 		serviceAccountReconciler: NewServiceAccountReconciler(
 			log,
 			client,
@@ -83,6 +87,7 @@ func NewReconciler(
 		),
 		ServiceExposeReconciler: NewServiceExposeReconciler(
 			log,
+			recorder,
 			configManagerGetter,
 		),
 		PersistentVolumeClaimReconciler: NewPersistentVolumeClaimReconciler(
@@ -91,9 +96,11 @@ func NewReconciler(
 		),
 		DeploymentReconciler: NewDeploymentReconciler(
 			log,
+			recorder,
 			managerAppName,
 			managerNamespace,
 			criKind,
+			captureCA,
 			configManagerGetter,
 		),
 	}
@@ -190,6 +197,8 @@ func (r *Reconciler) ReconcileConfigMap(
 	reconcileData.ResolvedConfigsBytes = configBytes
 	reconcileData.ResolvedHashes.Config = configHash
 
+	nodeLaunchers := getNodeLaunchers(reconcileData.ResolvedConfigs)
+
 	for nodeName, nodeFilesFromURL := range owningTopology.Spec.Deployment.FilesFromURL {
 		var nodeFilesFromURLHash string
 
@@ -201,9 +210,14 @@ func (r *Reconciler) ReconcileConfigMap(
 		reconcileData.ResolvedHashes.FilesFromURL[nodeName] = nodeFilesFromURLHash
 
 		if reconcileData.PreviousHashes.FilesFromURL[nodeName] != nodeFilesFromURLHash {
-			// files from url hash has changed, need to smack the node so the configmap update
-			// gets realized
-			reconcileData.NodesNeedingReboot.Add(nodeName)
+			// files from url hash has changed, need to smack the node (or rather its launcher)
+			// so the configmap update gets realized
+			launcherName, ok := nodeLaunchers[nodeName]
+			if !ok {
+				launcherName = nodeName
+			}
+
+			reconcileData.addNodeNeedingReboot(launcherName, "files from url changed")
 		}
 	}
 
@@ -304,6 +318,23 @@ func (r *Reconciler) ReconcileConnectivity(
 		reconcileData.ResolvedTunnels,
 	)
 
+	// the network attachment definitions are named after the tunnel ids, so they can only be
+	// reconciled once the ids are allocated
+	nadErr := r.reconcileNetworkAttachmentDefinitions(ctx, owningTopology, reconcileData)
+	if nadErr != nil {
+		return nadErr
+	}
+
+	if apimachinerymeta.SetStatusCondition(
+		&owningTopology.Status.Conditions,
+		r.connectivityReconciler.LinksReadyCondition(
+			reconcileData.ResolvedTunnels,
+			existingConnectivity.Status.PointToPointTunnels,
+		),
+	) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
 	if err != nil {
 		// get error was not found, we need to create
 		return r.createObj(
@@ -314,6 +345,23 @@ func (r *Reconciler) ReconcileConnectivity(
 		)
 	}
 
+	// the launchers own the status of the connectivity cr, so carry it over (minus any launchers
+	// that no longer exist) rather than wiping it out with our update
+	for launcherName, tunnelStatuses := range existingConnectivity.Status.PointToPointTunnels {
+		_, ok := reconcileData.ResolvedTunnels[launcherName]
+		if !ok {
+			continue
+		}
+
+		if renderedConnectivity.Status.PointToPointTunnels == nil {
+			renderedConnectivity.Status.PointToPointTunnels = make(
+				map[string][]*clabernetesapisv1alpha1.PointToPointTunnelStatus,
+			)
+		}
+
+		renderedConnectivity.Status.PointToPointTunnels[launcherName] = tunnelStatuses
+	}
+
 	// otherwise we continue to check if the connectivity info conforms and if not we update
 	if r.connectivityReconciler.Conforms(
 		existingConnectivity,
@@ -331,6 +379,137 @@ func (r *Reconciler) ReconcileConnectivity(
 	return r.updateObj(ctx, renderedConnectivity, clabernetesapis.Connectivity)
 }
 
+// reconcileNetworkAttachmentDefinitions reconciles the (multus) NetworkAttachmentDefinitions for
+// the links of the topology when using the multus connectivity flavor, with any other flavor it
+// removes the definitions left over from a previous multus setup.
+func (r *Reconciler) reconcileNetworkAttachmentDefinitions(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) error {
+	useMultus := owningTopology.Spec.Connectivity == clabernetesconstants.ConnectivityMultus
+
+	existingDefinitions := &apimachineryunstructured.UnstructuredList{}
+
+	existingDefinitions.SetAPIVersion(clabernetesconstants.MultusGroupVersion)
+	existingDefinitions.SetKind(
+		fmt.Sprintf("%sList", clabernetesconstants.MultusNetworkAttachmentDefinitionKind),
+	)
+
+	err := r.Client.List(
+		ctx,
+		existingDefinitions,
+		ctrlruntimeclient.InNamespace(owningTopology.GetNamespace()),
+		ctrlruntimeclient.MatchingLabels{
+			clabernetesconstants.LabelTopologyOwner: owningTopology.GetName(),
+		},
+	)
+	if err != nil {
+		if !useMultus && apimachinerymeta.IsNoMatchError(err) {
+			// multus (or at least its crd) is not installed, so there is nothing to clean up
+			return nil
+		}
+
+		r.Log.Criticalf(
+			"failed fetching owned %ss, error: '%s'",
+			clabernetesconstants.KubernetesNetworkAttachmentDefinition,
+			err,
+		)
+
+		return err
+	}
+
+	var renderedDefinitions []*apimachineryunstructured.Unstructured
+
+	if useMultus {
+		renderedDefinitions = r.connectivityReconciler.RenderNetworkAttachmentDefinitions(
+			owningTopology,
+			reconcileData.ResolvedTunnels,
+		)
+	}
+
+	renderedDefinitionNames := make([]string, len(renderedDefinitions))
+
+	for idx, renderedDefinition := range renderedDefinitions {
+		renderedDefinitionNames[idx] = renderedDefinition.GetName()
+	}
+
+	r.Log.Info("pruning extraneous network attachment definitions")
+
+	for idx := range existingDefinitions.Items {
+		existingDefinition := &existingDefinitions.Items[idx]
+
+		if slices.Contains(renderedDefinitionNames, existingDefinition.GetName()) {
+			continue
+		}
+
+		err = r.deleteObj(
+			ctx,
+			existingDefinition,
+			clabernetesconstants.KubernetesNetworkAttachmentDefinition,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("enforcing desired state on network attachment definitions")
+
+	for _, renderedDefinition := range renderedDefinitions {
+		idx := slices.IndexFunc(
+			existingDefinitions.Items,
+			func(existingDefinition apimachineryunstructured.Unstructured) bool {
+				return existingDefinition.GetName() == renderedDefinition.GetName()
+			},
+		)
+		if idx == -1 {
+			err = r.createObj(
+				ctx,
+				owningTopology,
+				renderedDefinition,
+				clabernetesconstants.KubernetesNetworkAttachmentDefinition,
+			)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		existingDefinition := &existingDefinitions.Items[idx]
+
+		err = ctrlruntimeutil.SetOwnerReference(
+			owningTopology,
+			renderedDefinition,
+			r.Client.Scheme(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if r.connectivityReconciler.NetworkAttachmentDefinitionConforms(
+			existingDefinition,
+			renderedDefinition,
+			owningTopology.GetUID(),
+		) {
+			continue
+		}
+
+		renderedDefinition.SetResourceVersion(existingDefinition.GetResourceVersion())
+
+		err = r.updateObj(
+			ctx,
+			renderedDefinition,
+			clabernetesconstants.KubernetesNetworkAttachmentDefinition,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ReconcileServices reconciles all the services for a clabernetes Topology.
 func (r *Reconciler) ReconcileServices(
 	ctx context.Context,
@@ -692,7 +871,7 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 		r.Log.Warn("skipping reconciling deployments due to disable deployments label set")
 
 		apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
-			Type:   "TopologyReady",
+			Type:   clabernetesconstants.ConditionTopologyReady,
 			Status: "False",
 			Reason: clabernetesconstants.NodeStatusDeploymentDisabled,
 			Message: "topology has 'clabernetes/disableDeployments' label set," +
@@ -716,6 +895,7 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 	renderedMissingDeployments := r.DeploymentReconciler.RenderAll(
 		owningTopology,
 		reconcileData.ResolvedConfigs,
+		reconcileData.ResolvedTunnels,
 		deployments.Missing,
 	)
 
@@ -737,6 +917,7 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 		renderedCurrentDeployment := r.DeploymentReconciler.Render(
 			owningTopology,
 			reconcileData.ResolvedConfigs,
+			reconcileData.ResolvedTunnels,
 			existingCurrentDeploymentNodeName,
 		)
 
@@ -770,32 +951,42 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 
 	r.Log.Info("processing deployment statuses")
 
-	for nodeName, deployment := range deployments.Current {
+	// node statuses are reported per (containerlab) node, with node grouping every node in a
+	// launcher simply shares the state of that launcher's deployment
+	for launcherName, deployment := range deployments.Current {
+		state := clabernetesconstants.NodeStatusNotReady
 		if deployment.Status.ReadyReplicas == 1 {
-			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusReady
-		} else {
-			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusNotReady //nolint:lll
+			state = clabernetesconstants.NodeStatusReady
+		}
+
+		for _, nodeName := range getLauncherNodeNames(reconcileData.ResolvedConfigs, launcherName) {
+			reconcileData.NodeStatuses[nodeName] = state
 		}
 	}
 
 	for _, missingDeploymentName := range deployments.Missing {
-		reconcileData.NodeStatuses[missingDeploymentName] = clabernetesconstants.NodeStatusUnknown //nolint:lll
+		for _, nodeName := range getLauncherNodeNames(
+			reconcileData.ResolvedConfigs,
+			missingDeploymentName,
+		) {
+			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusUnknown
+		}
 	}
 
-	topologyReady := true
-
-	for nodeName := range reconcileData.ResolvedConfigs {
-		state, ok := reconcileData.NodeStatuses[nodeName]
-		if !ok {
-			topologyReady = false
+	if owningTopology.Spec.Paused {
+		return r.reconcileDeploymentsHandlePaused(owningTopology, reconcileData)
+	}
 
-			break
-		}
+	topologyReady := true
 
-		if state != clabernetesconstants.NodeStatusReady {
-			topologyReady = false
+	for launcherName := range reconcileData.ResolvedConfigs {
+		for _, nodeName := range getLauncherNodeNames(reconcileData.ResolvedConfigs, launcherName) {
+			state, ok := reconcileData.NodeStatuses[nodeName]
+			if !ok || state != clabernetesconstants.NodeStatusReady {
+				topologyReady = false
 
-			break
+				break
+			}
 		}
 	}
 
@@ -803,14 +994,14 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 		reconcileData.TopologyReady = true
 
 		apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
-			Type:    "TopologyReady",
+			Type:    clabernetesconstants.ConditionTopologyReady,
 			Status:  "True",
 			Reason:  clabernetesconstants.NodeStatusReady,
 			Message: "all nodes report ready",
 		})
 	} else {
 		apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
-			Type:   "TopologyReady",
+			Type:   clabernetesconstants.ConditionTopologyReady,
 			Status: "False",
 			Reason: clabernetesconstants.NodeStatusNotReady,
 			Message: "one or more nodes report not ready, check node status field " +
@@ -830,106 +1021,42 @@ func (r *Reconciler) ReconcileDeployments( //nolint: gocyclo,funlen
 	)
 }
 
-func (r *Reconciler) reconcileDeploymentsHandleRestarts(
-	ctx context.Context,
+// reconcileDeploymentsHandlePaused reports all nodes of a paused topology as paused. Restarts for
+// config changes are skipped while paused -- the nodes start from the current config when the
+// topology is unpaused anyway.
+func (r *Reconciler) reconcileDeploymentsHandlePaused(
 	owningTopology *clabernetesapisv1alpha1.Topology,
-	deployments *clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment],
 	reconcileData *ReconcileData,
 ) error {
-	r.Log.Debug("determining nodes needing restart")
+	r.Log.Info("topology is paused, node deployments are scaled to zero")
 
-	r.DeploymentReconciler.DetermineNodesNeedingRestart(
-		reconcileData,
-	)
-
-	if reconcileData.NodesNeedingReboot.Len() == 0 {
-		r.Log.Debug("all nodes are up to date, no restarts required")
-
-		return nil
-	}
-
-	var restartNodeError error
-
-	for _, nodeName := range reconcileData.NodesNeedingReboot.Items() {
-		if slices.Contains(deployments.Missing, nodeName) {
-			// is a new node, don't restart, we'll deploy it soon
-			continue
-		}
-
-		r.Log.Infof(
-			"restarting the node '%s' as configurations have changed",
-			nodeName,
-		)
-
-		r.diffIfDebug(
-			reconcileData.PreviousConfigs[nodeName],
-			reconcileData.ResolvedConfigs[nodeName],
-		)
-
-		deploymentName := fmt.Sprintf("%s-%s", owningTopology.GetName(), nodeName)
-
-		if ResolveTopologyRemovePrefix(owningTopology) {
-			deploymentName = nodeName
-		}
-
-		nodeDeployment := &k8sappsv1.Deployment{}
-
-		err := r.getObj(
-			ctx,
-			nodeDeployment,
-			apimachinerytypes.NamespacedName{
-				Namespace: owningTopology.GetNamespace(),
-				Name:      deploymentName,
-			},
-			clabernetesconstants.KubernetesDeployment,
-		)
-		if err != nil {
-			if apimachineryerrors.IsNotFound(err) {
-				r.Log.Warnf(
-					"could not find deployment '%s', cannot restart after config change,"+
-						" this should not happen",
-					deploymentName,
-				)
-
-				continue
-			}
-
-			r.Log.Warnf("failed fetching deployment for node %q, err: %s", nodeName, err)
-
-			if restartNodeError == nil {
-				restartNodeError = fmt.Errorf(
-					"%w: encountered issue during node reboot process",
-					claberneteserrors.ErrReconcile,
-				)
-			}
-
-			continue
-		}
-
-		if nodeDeployment.Spec.Template.ObjectMeta.Annotations == nil {
-			nodeDeployment.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	for launcherName := range reconcileData.ResolvedConfigs {
+		for _, nodeName := range getLauncherNodeNames(reconcileData.ResolvedConfigs, launcherName) {
+			reconcileData.NodeStatuses[nodeName] = clabernetesconstants.NodeStatusPaused
 		}
+	}
 
-		now := time.Now().Format(time.RFC3339)
+	apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    clabernetesconstants.ConditionTopologyReady,
+		Status:  "False",
+		Reason:  clabernetesconstants.NodeStatusPaused,
+		Message: "topology is paused, all node deployments are scaled to zero",
+	})
 
-		nodeDeployment.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = now //nolint:lll
+	if owningTopology.Status.RestartProgress != nil ||
+		owningTopology.Status.PendingRestarts != nil {
+		// nothing left to restart, nodes start from the current config once unpaused
+		owningTopology.Status.RestartProgress = nil
+		owningTopology.Status.PendingRestarts = nil
 
-		err = r.updateObj(ctx, nodeDeployment, clabernetesconstants.KubernetesDeployment)
-		if err != nil {
-			r.Log.Warnf("failed restarting deployment for node %q, err: %s", nodeName, err)
-
-			if restartNodeError == nil {
-				restartNodeError = fmt.Errorf(
-					"%w: encountered issue during node reboot process",
-					claberneteserrors.ErrReconcile,
-				)
-			}
+		reconcileData.ShouldUpdateResource = true
+	}
 
-			continue
-		}
+	if !reflect.DeepEqual(reconcileData.NodeStatuses, reconcileData.PreviousNodeStatuses) {
+		reconcileData.ShouldUpdateResource = true
 	}
 
-	return restartNodeError
+	return nil
 }
 
 func (r *Reconciler) diffIfDebug(a, b any) {
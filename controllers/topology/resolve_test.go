@@ -25,6 +25,7 @@ import (
 	k8scorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	clientgorecord "k8s.io/client-go/tools/record"
 	ctrlruntimeclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -135,9 +136,11 @@ func TestReconcileResolveDeployment(t *testing.T) {
 				r := clabernetescontrollerstopology.NewReconciler(
 					&claberneteslogging.FakeInstance{},
 					fakeClient,
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					"",
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -294,9 +297,11 @@ func TestReconcileResolvePVC(t *testing.T) {
 				r := clabernetescontrollerstopology.NewReconciler(
 					&claberneteslogging.FakeInstance{},
 					fakeClient,
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					"",
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -458,9 +463,11 @@ func TestReconcileResolveServiceFabric(t *testing.T) {
 				r := clabernetescontrollerstopology.NewReconciler(
 					&claberneteslogging.FakeInstance{},
 					fakeClient,
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					"",
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -622,9 +629,11 @@ func TestReconcileResolveServiceExpose(t *testing.T) {
 				r := clabernetescontrollerstopology.NewReconciler(
 					&claberneteslogging.FakeInstance{},
 					fakeClient,
+					&clientgorecord.FakeRecorder{},
 					"clabernetes",
 					"clabernetes",
 					"containerd",
+					"",
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -0,0 +1,475 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesmetrics "github.com/srl-labs/clabernetes/metrics"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	"gopkg.in/yaml.v3"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// QueueNodeRestarts adds the given nodes to the pending restarts of the restart progress (unless
+// they are already pending) and sorts the pending restarts by the given order -- nodes in the order
+// come first in the order they are listed, all other nodes follow in alphabetical order.
+func QueueNodeRestarts(
+	restartProgress *clabernetesapisv1alpha1.RestartProgress,
+	nodeNames []string,
+	order []string,
+) {
+	for _, nodeName := range nodeNames {
+		if !slices.Contains(restartProgress.Pending, nodeName) {
+			restartProgress.Pending = append(restartProgress.Pending, nodeName)
+		}
+	}
+
+	orderIndex := func(nodeName string) int {
+		idx := slices.Index(order, nodeName)
+		if idx == -1 {
+			return len(order)
+		}
+
+		return idx
+	}
+
+	slices.SortFunc(restartProgress.Pending, func(a, b string) int {
+		aIdx, bIdx := orderIndex(a), orderIndex(b)
+		if aIdx != bIdx {
+			return aIdx - bIdx
+		}
+
+		return strings.Compare(a, b)
+	})
+}
+
+// DeploymentRestartComplete returns true if the given deployment has rolled out the pod restarted
+// at the given restartedAt time, and, if waitForReady is true, that pod reports ready.
+func DeploymentRestartComplete(
+	deployment *k8sappsv1.Deployment,
+	restartedAt string,
+	waitForReady bool,
+) bool {
+	// our cached copy of the deployment may not have caught up with the restart yet
+	if deployment.Spec.Template.Annotations[restartedAtAnnotation] != restartedAt {
+		return false
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+
+	// we use the recreate strategy, so the old pod is gone before the new one is created -- once
+	// the only replica is the updated one the node has been restarted
+	if deployment.Status.Replicas != 1 || deployment.Status.UpdatedReplicas != 1 {
+		return false
+	}
+
+	if !waitForReady {
+		return true
+	}
+
+	return deployment.Status.ReadyReplicas == 1
+}
+
+// MergePendingRestart returns the given pending restart with the given reason and diff merged in,
+// as a node may need a restart for more than one reason before its restart is requested.
+func MergePendingRestart(
+	pendingRestart clabernetesapisv1alpha1.PendingRestart,
+	reason, diff string,
+) clabernetesapisv1alpha1.PendingRestart {
+	switch {
+	case pendingRestart.Reason == "":
+		pendingRestart.Reason = reason
+	case !slices.Contains(strings.Split(pendingRestart.Reason, "; "), reason):
+		pendingRestart.Reason = fmt.Sprintf("%s; %s", pendingRestart.Reason, reason)
+	}
+
+	switch {
+	case diff == "":
+	case pendingRestart.Diff == "":
+		pendingRestart.Diff = diff
+	default:
+		pendingRestart.Diff = fmt.Sprintf("%s\n%s", pendingRestart.Diff, diff)
+	}
+
+	return pendingRestart
+}
+
+// reconcileDeploymentsHandleRestarts restarts the nodes whose configuration has changed as per the
+// restart strategy of the topology. Nodes are restarted in batches of (at most) the max concurrent
+// value of the strategy, the next batch is only started once all nodes of the current batch have
+// come back up. The progress is kept in the topology status so we pick it back up on the next
+// reconcile (which the deployments of the restarted nodes coming back up will trigger).
+func (r *Reconciler) reconcileDeploymentsHandleRestarts(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	deployments *clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment],
+	reconcileData *ReconcileData,
+) error {
+	r.Log.Debug("determining nodes needing restart")
+
+	r.DeploymentReconciler.DetermineNodesNeedingRestart(
+		reconcileData,
+	)
+
+	restartStrategy := owningTopology.Spec.Deployment.RestartStrategy
+
+	previousRestartProgress := owningTopology.Status.RestartProgress
+
+	restartProgress := previousRestartProgress.DeepCopy()
+	if restartProgress == nil {
+		restartProgress = &clabernetesapisv1alpha1.RestartProgress{}
+	}
+
+	nodesToRestart := make([]string, 0)
+
+	for _, nodeName := range reconcileData.NodesNeedingReboot.Items() {
+		if slices.Contains(deployments.Missing, nodeName) {
+			// is a new node, don't restart, we'll deploy it soon
+			continue
+		}
+
+		r.Log.Infof(
+			"node '%s' needs restarting as configurations have changed, reason: %s",
+			nodeName,
+			reconcileData.NodeRebootReasons[nodeName],
+		)
+
+		r.diffIfDebug(
+			reconcileData.PreviousConfigs[nodeName],
+			reconcileData.ResolvedConfigs[nodeName],
+		)
+
+		nodesToRestart = append(nodesToRestart, nodeName)
+	}
+
+	nodesToRestart = r.reconcilePendingRestarts(owningTopology, reconcileData, nodesToRestart)
+
+	QueueNodeRestarts(restartProgress, nodesToRestart, restartStrategy.Order)
+
+	// nodes may have been removed from the topology since they were queued/restarted
+	isRemovedNode := func(nodeName string) bool {
+		_, ok := reconcileData.ResolvedConfigs[nodeName]
+
+		return !ok
+	}
+
+	restartProgress.Pending = slices.DeleteFunc(restartProgress.Pending, isRemovedNode)
+	restartProgress.Restarting = slices.DeleteFunc(restartProgress.Restarting, isRemovedNode)
+
+	restartProgress.Restarting = slices.DeleteFunc(
+		restartProgress.Restarting,
+		func(nodeName string) bool {
+			deployment, ok := deployments.Current[nodeName]
+			if ok && !DeploymentRestartComplete(
+				deployment,
+				restartProgress.BatchStartedAt,
+				restartStrategy.WaitForReady,
+			) {
+				return false
+			}
+
+			restartProgress.Restarted++
+
+			return true
+		},
+	)
+
+	var restartNodeError error
+
+	if len(restartProgress.Restarting) == 0 && len(restartProgress.Pending) > 0 {
+		restartNodeError = r.restartNextBatch(
+			ctx,
+			owningTopology,
+			restartProgress,
+			restartStrategy.MaxConcurrent,
+		)
+	}
+
+	if len(restartProgress.Pending) == 0 && len(restartProgress.Restarting) == 0 {
+		if previousRestartProgress != nil {
+			r.Log.Info("all queued node restarts have completed")
+		}
+
+		restartProgress = nil
+	}
+
+	if !reflect.DeepEqual(previousRestartProgress, restartProgress) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	owningTopology.Status.RestartProgress = restartProgress
+
+	return restartNodeError
+}
+
+// restartNextBatch restarts the next (at most) maxConcurrent pending nodes, moving them from the
+// pending to the restarting nodes of the restart progress. Nodes that fail to restart are left
+// pending, so we'll try them again on the next reconcile.
+func (r *Reconciler) restartNextBatch(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	restartProgress *clabernetesapisv1alpha1.RestartProgress,
+	maxConcurrent int,
+) error {
+	batchSize := len(restartProgress.Pending)
+	if maxConcurrent > 0 && maxConcurrent < batchSize {
+		batchSize = maxConcurrent
+	}
+
+	batch := restartProgress.Pending[:batchSize]
+	remaining := restartProgress.Pending[batchSize:]
+
+	var restartNodeError error
+
+	var failedNodes []string
+
+	restartProgress.BatchStartedAt = time.Now().Format(time.RFC3339)
+
+	for _, nodeName := range batch {
+		r.Log.Infof("restarting the node '%s' as configurations have changed", nodeName)
+
+		err := r.restartNode(ctx, owningTopology, nodeName, restartProgress.BatchStartedAt)
+		if err != nil {
+			r.Log.Warnf("failed restarting deployment for node %q, err: %s", nodeName, err)
+
+			r.Recorder.Eventf(
+				owningTopology,
+				k8scorev1.EventTypeWarning,
+				clabernetesconstants.EventReasonNodeRestartFailed,
+				"failed restarting node %q, will retry",
+				nodeName,
+			)
+
+			if restartNodeError == nil {
+				restartNodeError = fmt.Errorf(
+					"%w: encountered issue during node reboot process",
+					claberneteserrors.ErrReconcile,
+				)
+			}
+
+			failedNodes = append(failedNodes, nodeName)
+
+			continue
+		}
+
+		restartProgress.Restarting = append(restartProgress.Restarting, nodeName)
+	}
+
+	restartProgress.Pending = slices.Concat(failedNodes, remaining)
+
+	return restartNodeError
+}
+
+// restartNode restarts the deployment of the given node by setting the restartedAt annotation of
+// its pod template -- just like `kubectl rollout restart` does.
+func (r *Reconciler) restartNode(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	nodeName,
+	restartedAt string,
+) error {
+	deploymentName := fmt.Sprintf("%s-%s", owningTopology.GetName(), nodeName)
+
+	if ResolveTopologyRemovePrefix(owningTopology) {
+		deploymentName = nodeName
+	}
+
+	nodeDeployment := &k8sappsv1.Deployment{}
+
+	err := r.getObj(
+		ctx,
+		nodeDeployment,
+		apimachinerytypes.NamespacedName{
+			Namespace: owningTopology.GetNamespace(),
+			Name:      deploymentName,
+		},
+		clabernetesconstants.KubernetesDeployment,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			r.Log.Warnf(
+				"could not find deployment '%s', cannot restart after config change,"+
+					" this should not happen",
+				deploymentName,
+			)
+
+			return nil
+		}
+
+		return err
+	}
+
+	if nodeDeployment.Spec.Template.ObjectMeta.Annotations == nil {
+		nodeDeployment.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	nodeDeployment.Spec.Template.ObjectMeta.Annotations[restartedAtAnnotation] = restartedAt
+
+	err = r.updateObj(ctx, nodeDeployment, clabernetesconstants.KubernetesDeployment)
+	if err != nil {
+		return err
+	}
+
+	clabernetesmetrics.RecordNodeRestart(owningTopology.GetNamespace(), owningTopology.GetName())
+
+	r.Recorder.Eventf(
+		owningTopology,
+		k8scorev1.EventTypeNormal,
+		clabernetesconstants.EventReasonNodeRestarted,
+		"restarted node %q as its configuration has changed",
+		nodeName,
+	)
+
+	return nil
+}
+
+// reconcilePendingRestarts handles the "Manual" restart policy -- the given nodes needing a restart
+// are recorded as pending restarts in the topology status rather than being restarted, and only
+// the pending restarts requested via the restart nodes annotation are returned to be restarted.
+// With any other policy the given nodes are returned along with any pending restarts left over
+// from when the policy was "Manual".
+func (r *Reconciler) reconcilePendingRestarts(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	nodeNames []string,
+) []string {
+	previousPendingRestarts := owningTopology.Status.PendingRestarts
+
+	pendingRestarts := maps.Clone(previousPendingRestarts)
+	if pendingRestarts == nil {
+		pendingRestarts = make(map[string]clabernetesapisv1alpha1.PendingRestart)
+	}
+
+	// nodes may have been removed from the topology since their restart became pending
+	maps.DeleteFunc(
+		pendingRestarts,
+		func(nodeName string, _ clabernetesapisv1alpha1.PendingRestart) bool {
+			_, ok := reconcileData.ResolvedConfigs[nodeName]
+
+			return !ok
+		},
+	)
+
+	var nodesToRestart []string
+
+	restartPolicy := owningTopology.Spec.Deployment.RestartStrategy.Policy
+
+	if restartPolicy != clabernetesconstants.RestartPolicyManual {
+		nodesToRestart = slices.Concat(nodeNames, slices.Sorted(maps.Keys(pendingRestarts)))
+
+		clear(pendingRestarts)
+	} else {
+		for _, nodeName := range nodeNames {
+			pendingRestarts[nodeName] = MergePendingRestart(
+				pendingRestarts[nodeName],
+				reconcileData.NodeRebootReasons[nodeName],
+				r.nodeConfigDiff(reconcileData, nodeName),
+			)
+		}
+
+		nodesToRestart = r.takeRequestedRestarts(owningTopology, reconcileData, pendingRestarts)
+	}
+
+	if len(pendingRestarts) == 0 {
+		pendingRestarts = nil
+	}
+
+	if !reflect.DeepEqual(previousPendingRestarts, pendingRestarts) {
+		reconcileData.ShouldUpdateResource = true
+	}
+
+	owningTopology.Status.PendingRestarts = pendingRestarts
+
+	return nodesToRestart
+}
+
+// takeRequestedRestarts returns the nodes requested to be restarted in the restart nodes annotation
+// of the topology that have a pending restart, removing them from the pending restarts. The
+// annotation is removed from the topology since we have now handled the request.
+func (r *Reconciler) takeRequestedRestarts(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+	pendingRestarts map[string]clabernetesapisv1alpha1.PendingRestart,
+) []string {
+	requestedRestarts, ok := owningTopology.Annotations[clabernetesconstants.AnnotationRestartNodes]
+	if !ok {
+		return nil
+	}
+
+	var nodesToRestart []string
+
+	for _, nodeName := range strings.Split(requestedRestarts, ",") {
+		nodeName = strings.TrimSpace(nodeName)
+
+		_, pending := pendingRestarts[nodeName]
+		if !pending {
+			r.Log.Warnf(
+				"restart of node %q requested but the node has no pending restart, ignoring",
+				nodeName,
+			)
+
+			continue
+		}
+
+		r.Log.Infof("restart of node %q requested, queueing restart", nodeName)
+
+		nodesToRestart = append(nodesToRestart, nodeName)
+
+		delete(pendingRestarts, nodeName)
+	}
+
+	delete(owningTopology.Annotations, clabernetesconstants.AnnotationRestartNodes)
+
+	reconcileData.ShouldUpdateResource = true
+
+	return nodesToRestart
+}
+
+// nodeConfigDiff returns the unified diff between the previous and the current containerlab config
+// of the given node, or an empty string if there is nothing to diff (or we failed diff'ing).
+func (r *Reconciler) nodeConfigDiff(reconcileData *ReconcileData, nodeName string) string {
+	previousConfig, previousOk := reconcileData.PreviousConfigs[nodeName]
+	currentConfig, currentOk := reconcileData.ResolvedConfigs[nodeName]
+
+	if !previousOk || !currentOk {
+		return ""
+	}
+
+	previousConfigBytes, err := yaml.Marshal(previousConfig)
+	if err != nil {
+		r.Log.Warnf("failed marshaling previous config of node %q, err: %s", nodeName, err)
+
+		return ""
+	}
+
+	currentConfigBytes, err := yaml.Marshal(currentConfig)
+	if err != nil {
+		r.Log.Warnf("failed marshaling current config of node %q, err: %s", nodeName, err)
+
+		return ""
+	}
+
+	diff, err := clabernetesutil.UnifiedDiff(previousConfigBytes, currentConfigBytes)
+	if err != nil {
+		r.Log.Warnf("failed diff'ing configs of node %q, err: %s", nodeName, err)
+
+		return ""
+	}
+
+	return diff
+}
@@ -0,0 +1,235 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const restartTestRestartedAt = "2024-01-01T00:00:00Z"
+
+func TestQueueNodeRestarts(t *testing.T) {
+	cases := []struct {
+		name      string
+		pending   []string
+		nodeNames []string
+		order     []string
+		expected  []string
+	}{
+		{
+			name:      "alphabetical",
+			nodeNames: []string{"srl3", "srl1", "srl2"},
+			expected:  []string{"srl1", "srl2", "srl3"},
+		},
+		{
+			name:      "ordered",
+			nodeNames: []string{"srl3", "srl1", "srl2", "client"},
+			order:     []string{"srl2", "srl3"},
+			expected:  []string{"srl2", "srl3", "client", "srl1"},
+		},
+		{
+			name:      "already-pending",
+			pending:   []string{"srl2", "srl1"},
+			nodeNames: []string{"srl1", "srl3"},
+			order:     []string{"srl3"},
+			expected:  []string{"srl3", "srl1", "srl2"},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				restartProgress := &clabernetesapisv1alpha1.RestartProgress{
+					Pending: testCase.pending,
+				}
+
+				clabernetescontrollerstopology.QueueNodeRestarts(
+					restartProgress,
+					testCase.nodeNames,
+					testCase.order,
+				)
+
+				if !reflect.DeepEqual(restartProgress.Pending, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, restartProgress.Pending, testCase.expected)
+				}
+			})
+	}
+}
+
+func restartTestDeployment(
+	restartedAt string,
+	observedGeneration int64,
+	status k8sappsv1.DeploymentStatus,
+) *k8sappsv1.Deployment {
+	status.ObservedGeneration = observedGeneration
+
+	return &k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 2,
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Template: k8scorev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/restartedAt": restartedAt,
+					},
+				},
+			},
+		},
+		Status: status,
+	}
+}
+
+func TestDeploymentRestartComplete(t *testing.T) {
+	cases := []struct {
+		name         string
+		deployment   *k8sappsv1.Deployment
+		waitForReady bool
+		expected     bool
+	}{
+		{
+			name: "stale-restarted-at",
+			deployment: restartTestDeployment(
+				"2023-01-01T00:00:00Z",
+				2,
+				k8sappsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			),
+			expected: false,
+		},
+		{
+			name: "not-observed",
+			deployment: restartTestDeployment(
+				restartTestRestartedAt,
+				1,
+				k8sappsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			),
+			expected: false,
+		},
+		{
+			name: "old-pod-terminating",
+			deployment: restartTestDeployment(
+				restartTestRestartedAt,
+				2,
+				k8sappsv1.DeploymentStatus{Replicas: 1},
+			),
+			expected: false,
+		},
+		{
+			name: "recreated",
+			deployment: restartTestDeployment(
+				restartTestRestartedAt,
+				2,
+				k8sappsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1},
+			),
+			expected: true,
+		},
+		{
+			name: "recreated-wait-for-ready",
+			deployment: restartTestDeployment(
+				restartTestRestartedAt,
+				2,
+				k8sappsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1},
+			),
+			waitForReady: true,
+			expected:     false,
+		},
+		{
+			name: "ready-wait-for-ready",
+			deployment: restartTestDeployment(
+				restartTestRestartedAt,
+				2,
+				k8sappsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			),
+			waitForReady: true,
+			expected:     true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.DeploymentRestartComplete(
+					testCase.deployment,
+					restartTestRestartedAt,
+					testCase.waitForReady,
+				)
+				if actual != testCase.expected {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestMergePendingRestart(t *testing.T) {
+	cases := []struct {
+		name           string
+		pendingRestart clabernetesapisv1alpha1.PendingRestart
+		reason         string
+		diff           string
+		expected       clabernetesapisv1alpha1.PendingRestart
+	}{
+		{
+			name:   "new",
+			reason: "node definition changed",
+			diff:   "-a\n+b\n",
+			expected: clabernetesapisv1alpha1.PendingRestart{
+				Reason: "node definition changed",
+				Diff:   "-a\n+b\n",
+			},
+		},
+		{
+			name: "same-reason",
+			pendingRestart: clabernetesapisv1alpha1.PendingRestart{
+				Reason: "node definition changed",
+				Diff:   "-a\n+b\n",
+			},
+			reason: "node definition changed",
+			diff:   "-b\n+c\n",
+			expected: clabernetesapisv1alpha1.PendingRestart{
+				Reason: "node definition changed",
+				Diff:   "-a\n+b\n\n-b\n+c\n",
+			},
+		},
+		{
+			name: "different-reason-no-diff",
+			pendingRestart: clabernetesapisv1alpha1.PendingRestart{
+				Reason: "node definition changed",
+				Diff:   "-a\n+b\n",
+			},
+			reason: "files from url changed",
+			expected: clabernetesapisv1alpha1.PendingRestart{
+				Reason: "node definition changed; files from url changed",
+				Diff:   "-a\n+b\n",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.MergePendingRestart(
+					testCase.pendingRestart,
+					testCase.reason,
+					testCase.diff,
+				)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
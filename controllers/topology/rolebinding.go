@@ -104,6 +104,79 @@ func (r *RoleBindingReconciler) Reconcile(
 	return nil
 }
 
+// Teardown removes the owning Topology from the owner references of the launcher role binding
+// in the Topology's namespace, or deletes the role binding entirely if the Topology is its last
+// owner -- meaning it is the last Topology in the namespace.
+func (r *RoleBindingReconciler) Teardown(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	namespace := owningTopology.Namespace
+
+	existingRoleBinding := &k8srbacv1.RoleBinding{}
+
+	err := r.client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: namespace,
+			Name:      launcherRoleBindingName(),
+		},
+		existingRoleBinding,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	remainingOwnerReferences := ownerReferencesWithout(
+		existingRoleBinding.OwnerReferences,
+		owningTopology.UID,
+	)
+
+	if len(remainingOwnerReferences) == 0 {
+		r.log.Infof(
+			"last topology in namespace %q being removed, deleting launcher role binding",
+			namespace,
+		)
+
+		err = r.client.Delete(ctx, existingRoleBinding)
+		if err != nil && !apimachineryerrors.IsNotFound(err) {
+			r.log.Criticalf(
+				"failed deleting launcher role binding in namespace %q, error: %s",
+				namespace,
+				err,
+			)
+
+			return err
+		}
+
+		return nil
+	}
+
+	if len(remainingOwnerReferences) == len(existingRoleBinding.OwnerReferences) {
+		// we are not an owner, nothing to do
+		return nil
+	}
+
+	existingRoleBinding.OwnerReferences = remainingOwnerReferences
+
+	err = r.client.Update(ctx, existingRoleBinding)
+	if err != nil {
+		r.log.Criticalf(
+			"failed updating launcher role binding in namespace %q, error: %s",
+			namespace,
+			err,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
 // Render renders the role binding for the given namespace. Exported for easy testing.
 func (r *RoleBindingReconciler) Render(
 	owningTopology *clabernetesapisv1alpha1.Topology,
@@ -104,6 +104,79 @@ func (r *ServiceAccountReconciler) Reconcile(
 	return nil
 }
 
+// Teardown removes the owning Topology from the owner references of the launcher service account
+// in the Topology's namespace, or deletes the service account entirely if the Topology is its last
+// owner -- meaning it is the last Topology in the namespace.
+func (r *ServiceAccountReconciler) Teardown(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) error {
+	namespace := owningTopology.Namespace
+
+	existingServiceAccount := &k8scorev1.ServiceAccount{}
+
+	err := r.client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: namespace,
+			Name:      launcherServiceAccountName(),
+		},
+		existingServiceAccount,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	remainingOwnerReferences := ownerReferencesWithout(
+		existingServiceAccount.OwnerReferences,
+		owningTopology.UID,
+	)
+
+	if len(remainingOwnerReferences) == 0 {
+		r.log.Infof(
+			"last topology in namespace %q being removed, deleting launcher service account",
+			namespace,
+		)
+
+		err = r.client.Delete(ctx, existingServiceAccount)
+		if err != nil && !apimachineryerrors.IsNotFound(err) {
+			r.log.Criticalf(
+				"failed deleting launcher service account in namespace %q, error: %s",
+				namespace,
+				err,
+			)
+
+			return err
+		}
+
+		return nil
+	}
+
+	if len(remainingOwnerReferences) == len(existingServiceAccount.OwnerReferences) {
+		// we are not an owner, nothing to do
+		return nil
+	}
+
+	existingServiceAccount.OwnerReferences = remainingOwnerReferences
+
+	err = r.client.Update(ctx, existingServiceAccount)
+	if err != nil {
+		r.log.Criticalf(
+			"failed updating launcher service account in namespace %q, error: %s",
+			namespace,
+			err,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
 // Render renders a service account for the given namespace. Exported for easy testing.
 func (r *ServiceAccountReconciler) Render(
 	owningTopology *clabernetesapisv1alpha1.Topology,
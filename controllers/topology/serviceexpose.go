@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgorecord "k8s.io/client-go/tools/record"
 )
 
 const exposeTypeNone = "None"
@@ -35,16 +36,19 @@ func exposeTypeToServiceType(exposeType string) k8scorev1.ServiceType {
 // clabernetes topology resource.
 type ServiceExposeReconciler struct {
 	log                 claberneteslogging.Instance
+	recorder            clientgorecord.EventRecorder
 	configManagerGetter clabernetesconfig.ManagerGetterFunc
 }
 
 // NewServiceExposeReconciler returns an instance of ServiceExposeReconciler.
 func NewServiceExposeReconciler(
 	log claberneteslogging.Instance,
+	recorder clientgorecord.EventRecorder,
 	configManagerGetter clabernetesconfig.ManagerGetterFunc,
 ) *ServiceExposeReconciler {
 	return &ServiceExposeReconciler{
 		log:                 log,
+		recorder:            recorder,
 		configManagerGetter: configManagerGetter,
 	}
 }
@@ -102,11 +106,11 @@ func (r *ServiceExposeReconciler) Resolve(
 			continue
 		}
 
-		// if disable auto expose is true *and* there are no ports defined for the node *and*
+		// if disable auto expose is true *and* there are no ports defined for the node(s) *and*
 		// there are no default ports defined for the topology we can skip the node from an expose
 		// perspective.
 		if disableAutoExpose &&
-			len(nodeData.Topology.Nodes[nodeName].Ports) == 0 &&
+			!nodesHavePorts(nodeData) &&
 			len(nodeData.Topology.Defaults.Ports) == 0 {
 			continue
 		}
@@ -290,9 +294,11 @@ func (r *ServiceExposeReconciler) renderServicePorts(
 	// definitions.
 	allContainerlabPorts := clabernetesutil.NewStringSet()
 
-	allContainerlabPorts.Extend(
-		reconcileData.ResolvedConfigs[nodeName].Topology.Nodes[nodeName].Ports,
-	)
+	// when node grouping is in use there may be more than one node in the sub-topology, the
+	// expose ports are allocated across all of them so we can just expose them all
+	for _, nodeDefinition := range reconcileData.ResolvedConfigs[nodeName].Topology.Nodes {
+		allContainerlabPorts.Extend(nodeDefinition.Ports)
+	}
 
 	allContainerlabPorts.Extend(reconcileData.ResolvedConfigs[nodeName].Topology.Defaults.Ports)
 
@@ -376,9 +382,29 @@ func (r *ServiceExposeReconciler) processMgmtLoadbalanacerExpose(
 					raw,
 					nodeName,
 				)
+
+				r.recorder.Eventf(
+					owningTopology,
+					k8scorev1.EventTypeWarning,
+					clabernetesconstants.EventReasonExposeIPRejected,
+					"mgmt-%s %q of node %q is not a valid IP, using auto-assigned LoadBalancerIP",
+					mgmtProtocol,
+					raw,
+					nodeName,
+				)
 			} else {
 				service.Spec.LoadBalancerIP = ip.String()
 			}
 		}
 	}
 }
+
+func nodesHavePorts(clabernetesConfig *clabernetesutilcontainerlab.Config) bool {
+	for _, nodeDefinition := range clabernetesConfig.Topology.Nodes {
+		if len(nodeDefinition.Ports) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -15,6 +15,7 @@ import (
 	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
 	k8scorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgorecord "k8s.io/client-go/tools/record"
 )
 
 const renderServiceExposeTestName = "serviceexpose/render-service"
@@ -146,6 +147,7 @@ func TestResolveServiceExpose(t *testing.T) {
 
 				reconciler := clabernetescontrollerstopology.NewServiceExposeReconciler(
 					&claberneteslogging.FakeInstance{},
+					&clientgorecord.FakeRecorder{},
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -485,6 +487,7 @@ func TestRenderServiceExpose(t *testing.T) {
 
 				reconciler := clabernetescontrollerstopology.NewServiceExposeReconciler(
 					&claberneteslogging.FakeInstance{},
+					&clientgorecord.FakeRecorder{},
 					clabernetesconfig.GetFakeManager,
 				)
 
@@ -41,7 +41,7 @@ func NewServiceFabricReconciler(
 func (r *ServiceFabricReconciler) Resolve(
 	ownedServices *k8scorev1.ServiceList,
 	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
-	_ *clabernetesapisv1alpha1.Topology,
+	owningTopology *clabernetesapisv1alpha1.Topology,
 ) (*clabernetesutil.ObjectDiffer[*k8scorev1.Service], error) {
 	services := &clabernetesutil.ObjectDiffer[*k8scorev1.Service]{
 		Current: map[string]*k8scorev1.Service{},
@@ -76,14 +76,14 @@ func (r *ServiceFabricReconciler) Resolve(
 		services.Current[nodeName] = &ownedServices.Items[i]
 	}
 
-	allNodes := make([]string, len(clabernetesConfigs))
-
-	var nodeIdx int
+	allNodes := make([]string, 0, len(clabernetesConfigs))
 
-	for nodeName := range clabernetesConfigs {
-		allNodes[nodeIdx] = nodeName
-
-		nodeIdx++
+	if owningTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		// with multus links are carried over the network attachments of the launchers rather
+		// than tunnels to the fabric services, so no node needs a fabric service then
+		for nodeName := range clabernetesConfigs {
+			allNodes = append(allNodes, nodeName)
+		}
 	}
 
 	services.SetMissing(allNodes)
@@ -178,6 +178,10 @@ func (r *ServiceFabricReconciler) renderServiceBase(
 		labels[k] = v
 	}
 
+	connectivityPortName, connectivityPort, connectivityProtocol := resolveConnectivityPort(
+		owningTopology,
+	)
+
 	return &k8scorev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
@@ -188,11 +192,11 @@ func (r *ServiceFabricReconciler) renderServiceBase(
 		Spec: k8scorev1.ServiceSpec{
 			Ports: []k8scorev1.ServicePort{
 				{
-					Name:     "vxlan",
-					Protocol: clabernetesconstants.UDP,
-					Port:     clabernetesconstants.VXLANServicePort,
+					Name:     connectivityPortName,
+					Protocol: connectivityProtocol,
+					Port:     connectivityPort,
 					TargetPort: intstr.IntOrString{
-						IntVal: clabernetesconstants.VXLANServicePort,
+						IntVal: connectivityPort,
 					},
 				},
 			},
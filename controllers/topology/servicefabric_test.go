@@ -24,6 +24,7 @@ func TestResolveServiceFabric(t *testing.T) {
 		name               string
 		ownedServices      *k8scorev1.ServiceList
 		clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config
+		connectivity       string
 		expectedCurrent    []string
 		expectedMissing    []string
 		expectedExtra      []*k8scorev1.Service
@@ -81,6 +82,42 @@ func TestResolveServiceFabric(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multus",
+			ownedServices: &k8scorev1.ServiceList{
+				Items: []k8scorev1.Service{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "resolve-servicefabric-test",
+							Namespace: "clabernetes",
+							Labels: map[string]string{
+								clabernetesconstants.LabelTopologyServiceType: clabernetesconstants.TopologyServiceTypeFabric,
+								clabernetesconstants.LabelTopologyNode:        "node1",
+							},
+						},
+					},
+				},
+			},
+			clabernetesConfigs: map[string]*clabernetesutilcontainerlab.Config{
+				"node1": nil,
+				"node2": nil,
+			},
+			connectivity:    clabernetesconstants.ConnectivityMultus,
+			expectedCurrent: []string{"node1"},
+			expectedMissing: nil,
+			expectedExtra: []*k8scorev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "resolve-servicefabric-test",
+						Namespace: "clabernetes",
+						Labels: map[string]string{
+							clabernetesconstants.LabelTopologyServiceType: clabernetesconstants.TopologyServiceTypeFabric,
+							clabernetesconstants.LabelTopologyNode:        "node1",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -97,7 +134,11 @@ func TestResolveServiceFabric(t *testing.T) {
 				got, err := reconciler.Resolve(
 					testCase.ownedServices,
 					testCase.clabernetesConfigs,
-					nil,
+					&clabernetesapisv1alpha1.Topology{
+						Spec: clabernetesapisv1alpha1.TopologySpec{
+							Connectivity: testCase.connectivity,
+						},
+					},
 				)
 				if err != nil {
 					t.Fatal(err)
@@ -177,6 +218,52 @@ func TestRenderServiceFabric(t *testing.T) {
 			},
 			nodeName: "srl1",
 		},
+		{
+			name: "simple-geneve",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-service-fabric-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+					Connectivity: "geneve",
+				},
+			},
+			nodeName: "srl1",
+		},
+		{
+			name: "simple-slurpeeth",
+			owningTopology: &clabernetesapisv1alpha1.Topology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "render-service-fabric-test",
+					Namespace: "clabernetes",
+				},
+				Spec: clabernetesapisv1alpha1.TopologySpec{
+					Definition: clabernetesapisv1alpha1.Definition{
+						Containerlab: `---
+    name: test
+    topology:
+      nodes:
+        srl1:
+          kind: srl
+          image: ghcr.io/nokia/srlinux
+`,
+					},
+					Connectivity: "slurpeeth",
+				},
+			},
+			nodeName: "srl1",
+		},
 	}
 
 	for _, testCase := range cases {
@@ -0,0 +1,298 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const restoredStartupConfigDir = "snapshot"
+
+// restoredStartupConfigPath returns the path (relative to the launcher's /clabernetes directory,
+// which is also where the containerlab topology file lives) that the saved configuration of the
+// given node is mounted at when restoring a Topology from a TopologySnapshot.
+func restoredStartupConfigPath(nodeName string) string {
+	return fmt.Sprintf(
+		"%s/%s/%s",
+		restoredStartupConfigDir,
+		nodeName,
+		clabernetesconstants.SnapshotConfigKey,
+	)
+}
+
+// restoredStartupConfigMapName returns the name of the ConfigMap holding the (topology owned)
+// copy of the saved configuration of the given node that the topology is restored from.
+func restoredStartupConfigMapName(owningTopologyName, nodeName string) string {
+	return clabernetesutilkubernetes.SafeConcatNameKubernetes(
+		owningTopologyName,
+		nodeName,
+		restoredStartupConfigDir,
+	)
+}
+
+// resolveRestoreSnapshot resolves the nodes of the topology that are restored from the
+// TopologySnapshot the topology should be restored from, if any, and records them in the reconcile
+// data. The saved configurations are copied into ConfigMaps owned by the topology, so once
+// restored the topology does not depend on the snapshot anymore -- deleting the snapshot does not
+// break the launchers of the topology. Returns false if the topology cannot be reconciled just yet
+// because the snapshot is missing or not complete and the topology was not restored from it
+// already; we watch snapshots, so we come back around once the snapshot completes.
+func (c *Controller) resolveRestoreSnapshot(
+	ctx context.Context,
+	topology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) (bool, error) {
+	snapshotName := topology.Spec.Deployment.RestoreFromSnapshot
+
+	existingConfigMaps := &k8scorev1.ConfigMapList{}
+
+	err := c.BaseController.Client.List(
+		ctx,
+		existingConfigMaps,
+		ctrlruntimeclient.InNamespace(topology.Namespace),
+		ctrlruntimeclient.MatchingLabels{
+			clabernetesconstants.LabelTopologyOwner: topology.Name,
+		},
+		ctrlruntimeclient.HasLabels{clabernetesconstants.LabelTopologySnapshot},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if snapshotName == "" {
+		return true, c.pruneRestoredStartupConfigMaps(ctx, existingConfigMaps, "", nil)
+	}
+
+	snapshot := &clabernetesapisv1alpha1.TopologySnapshot{}
+
+	err = c.BaseController.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: topology.Namespace,
+			Name:      snapshotName,
+		},
+		snapshot,
+	)
+	if err != nil && !apimachineryerrors.IsNotFound(err) {
+		return false, err
+	}
+
+	if err != nil || !snapshot.Status.Complete {
+		for idx := range existingConfigMaps.Items {
+			existingConfigMap := &existingConfigMaps.Items[idx]
+
+			if existingConfigMap.Labels[clabernetesconstants.LabelTopologySnapshot] !=
+				snapshotName {
+				continue
+			}
+
+			reconcileData.RestoredNodes = append(
+				reconcileData.RestoredNodes,
+				existingConfigMap.Labels[clabernetesconstants.LabelTopologyNode],
+			)
+		}
+
+		if len(reconcileData.RestoredNodes) == 0 {
+			c.BaseController.Log.Warnf(
+				"snapshot %q to restore from does not exist or is not complete yet, waiting",
+				snapshotName,
+			)
+
+			c.BaseController.Recorder.Eventf(
+				topology,
+				k8scorev1.EventTypeWarning,
+				clabernetesconstants.EventReasonSnapshotNotReady,
+				"snapshot %q to restore from does not exist or is not complete yet",
+				snapshotName,
+			)
+
+			return false, nil
+		}
+
+		// we already restored from this snapshot, so we carry on with our copies
+		slices.Sort(reconcileData.RestoredNodes)
+
+		return true, nil
+	}
+
+	for nodeName, nodeStatus := range snapshot.Status.Nodes {
+		if nodeStatus.State != clabernetesconstants.SnapshotStateSaved {
+			c.BaseController.Log.Warnf(
+				"snapshot %q has no saved configuration for node %q, node will not be restored",
+				snapshotName,
+				nodeName,
+			)
+
+			continue
+		}
+
+		err = c.copyRestoredStartupConfig(ctx, topology, snapshot, nodeName, nodeStatus)
+		if err != nil {
+			return false, err
+		}
+
+		reconcileData.RestoredNodes = append(reconcileData.RestoredNodes, nodeName)
+	}
+
+	slices.Sort(reconcileData.RestoredNodes)
+
+	return true, c.pruneRestoredStartupConfigMaps(
+		ctx,
+		existingConfigMaps,
+		snapshotName,
+		reconcileData.RestoredNodes,
+	)
+}
+
+// copyRestoredStartupConfig copies the saved configuration of the given node from the snapshot
+// ConfigMap into a ConfigMap owned by the topology, creating or updating the copy as needed.
+func (c *Controller) copyRestoredStartupConfig(
+	ctx context.Context,
+	topology *clabernetesapisv1alpha1.Topology,
+	snapshot *clabernetesapisv1alpha1.TopologySnapshot,
+	nodeName string,
+	nodeStatus clabernetesapisv1alpha1.NodeSnapshotStatus,
+) error {
+	snapshotConfigMap := &k8scorev1.ConfigMap{}
+
+	err := c.BaseController.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: snapshot.Namespace,
+			Name:      nodeStatus.ConfigMapName,
+		},
+		snapshotConfigMap,
+	)
+	if err != nil {
+		return err
+	}
+
+	annotations, globalLabels := c.TopologyReconciler.configMapReconciler.configManagerGetter().
+		GetAllMetadata()
+
+	labels := map[string]string{
+		clabernetesconstants.LabelApp:              clabernetesconstants.Clabernetes,
+		clabernetesconstants.LabelName:             topology.Name,
+		clabernetesconstants.LabelTopologyOwner:    topology.Name,
+		clabernetesconstants.LabelTopologyKind:     GetTopologyKind(topology),
+		clabernetesconstants.LabelTopologyNode:     nodeName,
+		clabernetesconstants.LabelTopologySnapshot: snapshot.Name,
+	}
+
+	maps.Copy(labels, globalLabels)
+
+	configKey := clabernetesconstants.SnapshotConfigKey
+
+	data := map[string]string{
+		configKey: snapshotConfigMap.Data[configKey],
+	}
+
+	restoredConfigMap := &k8scorev1.ConfigMap{}
+
+	err = c.BaseController.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: topology.Namespace,
+			Name:      restoredStartupConfigMapName(topology.Name, nodeName),
+		},
+		restoredConfigMap,
+	)
+	if err != nil {
+		if !apimachineryerrors.IsNotFound(err) {
+			return err
+		}
+
+		restoredConfigMap = &k8scorev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        restoredStartupConfigMapName(topology.Name, nodeName),
+				Namespace:   topology.Namespace,
+				Annotations: annotations,
+				Labels:      labels,
+			},
+			Data: data,
+		}
+
+		return c.TopologyReconciler.createObj(ctx, topology, restoredConfigMap, "ConfigMap")
+	}
+
+	if reflect.DeepEqual(restoredConfigMap.Data, data) &&
+		clabernetesutilkubernetes.ExistingMapStringStringContainsAllExpectedKeyValues(
+			restoredConfigMap.Labels,
+			labels,
+		) {
+		return nil
+	}
+
+	restoredConfigMap.Labels = labels
+	restoredConfigMap.Data = data
+
+	return c.TopologyReconciler.updateObj(ctx, restoredConfigMap, "ConfigMap")
+}
+
+// pruneRestoredStartupConfigMaps deletes the restored startup-config ConfigMaps of the topology
+// that do not belong to the given snapshot or to one of the given (restored) nodes.
+func (c *Controller) pruneRestoredStartupConfigMaps(
+	ctx context.Context,
+	existingConfigMaps *k8scorev1.ConfigMapList,
+	snapshotName string,
+	restoredNodes []string,
+) error {
+	for idx := range existingConfigMaps.Items {
+		existingConfigMap := &existingConfigMaps.Items[idx]
+
+		if existingConfigMap.Labels[clabernetesconstants.LabelTopologySnapshot] == snapshotName &&
+			slices.Contains(
+				restoredNodes,
+				existingConfigMap.Labels[clabernetesconstants.LabelTopologyNode],
+			) {
+			continue
+		}
+
+		err := c.TopologyReconciler.deleteObj(ctx, existingConfigMap, "ConfigMap")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoredStartupConfigFile returns the FileFromConfigMap that mounts the (copied) saved snapshot
+// configuration of the given node, if the node is restored from a snapshot at all. Whether or
+// not it is restored is decided by the definition processing, so we just check the rendered
+// (sub-topology) config for the restored startup-config path.
+func restoredStartupConfigFile(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	clabernetesConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+) (clabernetesapisv1alpha1.FileFromConfigMap, bool) {
+	snapshotName := owningTopology.Spec.Deployment.RestoreFromSnapshot
+
+	if snapshotName == "" || clabernetesConfig == nil || clabernetesConfig.Topology == nil {
+		return clabernetesapisv1alpha1.FileFromConfigMap{}, false
+	}
+
+	nodeDefinition, ok := clabernetesConfig.Topology.Nodes[nodeName]
+	if !ok || nodeDefinition.StartupConfig != restoredStartupConfigPath(nodeName) {
+		return clabernetesapisv1alpha1.FileFromConfigMap{}, false
+	}
+
+	return clabernetesapisv1alpha1.FileFromConfigMap{
+		FilePath:      restoredStartupConfigPath(nodeName),
+		ConfigMapName: restoredStartupConfigMapName(owningTopology.GetName(), nodeName),
+		ConfigMapPath: clabernetesconstants.SnapshotConfigKey,
+		Mode:          clabernetesconstants.FileModeRead,
+	}, true
+}
@@ -0,0 +1,255 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachinerymeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResolveTeardownStages accepts a mapping of clabernetes sub-topology configs and returns the
+// order in which the nodes should be torn down as a slice of "stages". Nodes that other nodes wait
+// for (via the containerlab "wait-for" setting) are only torn down once all the nodes waiting on
+// them are gone -- basically the startup order in reverse. Nodes in the same stage are torn down
+// at the same time, and any nodes that are part of a dependency cycle end up in the final stage.
+func ResolveTeardownStages(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) [][]string {
+	waitFor := map[string][]string{}
+
+	nodeLaunchers := getNodeLaunchers(clabernetesConfigs)
+
+	for launcherName := range clabernetesConfigs {
+		// with node grouping a launcher waits for whatever launchers its nodes wait for, nodes
+		// waiting on other nodes in the same launcher are containerlabs problem not ours
+		for _, waitForNodeName := range getLauncherWaitFor(clabernetesConfigs, launcherName) {
+			waitForLauncherName, ok := nodeLaunchers[waitForNodeName]
+			if !ok {
+				waitForLauncherName = waitForNodeName
+			}
+
+			waitFor[launcherName] = append(waitFor[launcherName], waitForLauncherName)
+		}
+	}
+
+	remainingNodes := clabernetesutil.NewStringSet()
+
+	for nodeName := range clabernetesConfigs {
+		remainingNodes.Add(nodeName)
+	}
+
+	var stages [][]string
+
+	for remainingNodes.Len() > 0 {
+		remainingNodeNames := remainingNodes.Items()
+
+		sort.Strings(remainingNodeNames)
+
+		var stage []string
+
+		for _, nodeName := range remainingNodeNames {
+			var isWaitedFor bool
+
+			for _, otherNodeName := range remainingNodeNames {
+				if otherNodeName == nodeName {
+					continue
+				}
+
+				if slices.Contains(waitFor[otherNodeName], nodeName) {
+					isWaitedFor = true
+
+					break
+				}
+			}
+
+			if !isWaitedFor {
+				stage = append(stage, nodeName)
+			}
+		}
+
+		if len(stage) == 0 {
+			// every remaining node is waited on by some other remaining node, so we've got a
+			// cycle, nothing smart to do here, just take them all down together
+			stage = remainingNodeNames
+		}
+
+		for _, nodeName := range stage {
+			remainingNodes.Remove(nodeName)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages
+}
+
+// ReconcileTeardown handles the graceful teardown of a Topology that is being deleted. Node
+// deployments are scaled down stage by stage (see ResolveTeardownStages), giving the launchers the
+// chance to save the node configs on the way down. Once all nodes are gone the launcher service
+// account and role binding are cleaned up. Progress is reported in the "TopologyTeardown" status
+// condition. Returns true once the teardown is complete and the finalizer can be removed.
+func (r *Reconciler) ReconcileTeardown(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	reconcileData *ReconcileData,
+) (bool, error) {
+	deployments, err := ReconcileResolve(
+		ctx,
+		r,
+		&k8sappsv1.Deployment{},
+		&k8sappsv1.DeploymentList{},
+		clabernetesconstants.KubernetesDeployment,
+		owningTopology,
+		reconcileData.PreviousConfigs,
+		r.DeploymentReconciler.Resolve,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    clabernetesconstants.ConditionTopologyReady,
+		Status:  "False",
+		Reason:  clabernetesconstants.TeardownReasonTearingDown,
+		Message: "topology is being deleted",
+	})
+
+	stages := ResolveTeardownStages(reconcileData.PreviousConfigs)
+
+	if len(deployments.Extra) > 0 {
+		// deployments we have no stored config for have no ordering info, take them down first
+		extraNodes := make([]string, len(deployments.Extra))
+
+		for idx, deployment := range deployments.Extra {
+			extraNodes[idx] = deployment.Labels[clabernetesconstants.LabelTopologyNode]
+		}
+
+		sort.Strings(extraNodes)
+
+		stages = append([][]string{extraNodes}, stages...)
+	}
+
+	for stageIdx, stage := range stages {
+		var pendingNodes []string
+
+		pendingNodes, err = r.reconcileTeardownStage(ctx, owningTopology, deployments, stage)
+		if err != nil {
+			return false, err
+		}
+
+		if len(pendingNodes) == 0 {
+			continue
+		}
+
+		r.Log.Infof(
+			"teardown stage %d/%d in progress, waiting on node(s) %q",
+			stageIdx+1,
+			len(stages),
+			pendingNodes,
+		)
+
+		apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+			Type:   clabernetesconstants.ConditionTopologyTeardown,
+			Status: "False",
+			Reason: clabernetesconstants.TeardownReasonScalingDown,
+			Message: fmt.Sprintf(
+				"teardown stage %d/%d, waiting on node(s) %s to scale down",
+				stageIdx+1,
+				len(stages),
+				strings.Join(pendingNodes, ", "),
+			),
+		})
+
+		return false, nil
+	}
+
+	r.Log.Info("all nodes scaled down, cleaning up namespace resources")
+
+	err = r.serviceAccountReconciler.Teardown(ctx, owningTopology)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.roleBindingReconciler.Teardown(ctx, owningTopology)
+	if err != nil {
+		return false, err
+	}
+
+	apimachinerymeta.SetStatusCondition(&owningTopology.Status.Conditions, metav1.Condition{
+		Type:    clabernetesconstants.ConditionTopologyTeardown,
+		Status:  "True",
+		Reason:  clabernetesconstants.TeardownReasonComplete,
+		Message: "all nodes scaled down and namespace resources cleaned up",
+	})
+
+	return true, nil
+}
+
+// reconcileTeardownStage scales down the deployments of the nodes in the given stage and returns
+// the nodes that still have (possibly terminating) pods running.
+func (r *Reconciler) reconcileTeardownStage(
+	ctx context.Context,
+	owningTopology *clabernetesapisv1alpha1.Topology,
+	deployments *clabernetesutil.ObjectDiffer[*k8sappsv1.Deployment],
+	stage []string,
+) ([]string, error) {
+	pendingNodes := make([]string, 0)
+
+	for _, nodeName := range stage {
+		deployment, ok := deployments.Current[nodeName]
+		if !ok {
+			continue
+		}
+
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			r.Log.Infof("scaling down deployment for node %q", nodeName)
+
+			deployment.Spec.Replicas = clabernetesutil.ToPointer(int32(0))
+
+			err := r.updateObj(ctx, deployment, clabernetesconstants.KubernetesDeployment)
+			if err != nil {
+				return nil, err
+			}
+
+			pendingNodes = append(pendingNodes, nodeName)
+
+			continue
+		}
+
+		// deployment replica counts dont include terminating pods, but we want to give the
+		// launchers the time to save configs and exit, so check for the actual pods
+		pods := &k8scorev1.PodList{}
+
+		err := r.Client.List(
+			ctx,
+			pods,
+			ctrlruntimeclient.InNamespace(owningTopology.GetNamespace()),
+			ctrlruntimeclient.MatchingLabels{
+				clabernetesconstants.LabelTopologyOwner: owningTopology.GetName(),
+				clabernetesconstants.LabelTopologyNode:  nodeName,
+			},
+		)
+		if err != nil {
+			r.Log.Criticalf("failed listing pods for node %q, error: %s", nodeName, err)
+
+			return nil, err
+		}
+
+		if len(pods.Items) > 0 {
+			pendingNodes = append(pendingNodes, nodeName)
+		}
+	}
+
+	return pendingNodes, nil
+}
@@ -0,0 +1,74 @@
+package topology_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetescontrollerstopology "github.com/srl-labs/clabernetes/controllers/topology"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+func teardownTestConfig(
+	nodeName string,
+	waitFor ...string,
+) *clabernetesutilcontainerlab.Config {
+	return &clabernetesutilcontainerlab.Config{
+		Topology: &clabernetesutilcontainerlab.Topology{
+			Nodes: map[string]*clabernetesutilcontainerlab.NodeDefinition{
+				nodeName: {
+					WaitFor: waitFor,
+				},
+			},
+		},
+	}
+}
+
+func TestResolveTeardownStages(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       map[string]*clabernetesutilcontainerlab.Config
+		expected [][]string
+	}{
+		{
+			name: "no-dependencies",
+			in: map[string]*clabernetesutilcontainerlab.Config{
+				"srl2": teardownTestConfig("srl2"),
+				"srl1": teardownTestConfig("srl1"),
+			},
+			expected: [][]string{{"srl1", "srl2"}},
+		},
+		{
+			name: "chained-dependencies",
+			in: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1":   teardownTestConfig("srl1"),
+				"srl2":   teardownTestConfig("srl2", "srl1"),
+				"client": teardownTestConfig("client", "srl2"),
+				"other":  teardownTestConfig("other"),
+			},
+			expected: [][]string{{"client", "other"}, {"srl2"}, {"srl1"}},
+		},
+		{
+			name: "cycle",
+			in: map[string]*clabernetesutilcontainerlab.Config{
+				"srl1":   teardownTestConfig("srl1", "srl2"),
+				"srl2":   teardownTestConfig("srl2", "srl1"),
+				"client": teardownTestConfig("client", "srl1"),
+			},
+			expected: [][]string{{"client"}, {"srl1", "srl2"}},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetescontrollerstopology.ResolveTeardownStages(testCase.in)
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
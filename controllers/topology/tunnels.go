@@ -40,7 +40,8 @@ func AllocateTunnelIDs(
 
 		for _, newTunnel := range nodeTunnels {
 			for _, existingTunnel := range existingNodeTunnels {
-				if newTunnel.LocalInterface == existingTunnel.LocalInterface &&
+				if newTunnel.LocalNode == existingTunnel.LocalNode &&
+					newTunnel.LocalInterface == existingTunnel.LocalInterface &&
 					newTunnel.RemoteInterface == existingTunnel.RemoteInterface &&
 					newTunnel.RemoteNode == existingTunnel.RemoteNode {
 					newTunnel.TunnelID = existingTunnel.TunnelID
@@ -65,8 +66,7 @@ func AllocateTunnelIDs(
 			// if *yes* we need to re-use that vnid obviously!
 			idToAssign := findAllocatedIDIfExists(
 				nodeName,
-				tunnel.RemoteNode,
-				tunnel.LocalInterface,
+				tunnel,
 				processedTunnelsSortedKeys,
 				processedTunnels,
 			)
@@ -95,30 +95,30 @@ func AllocateTunnelIDs(
 	}
 }
 
+// findAllocatedIDIfExists looks for the remote side of the given tunnel and returns its id if it
+// has one already. Tunnels are paired up by their node/interface names rather than by the launcher
+// they belong to since launchers may host more than one node when node grouping is in use.
 func findAllocatedIDIfExists(
-	nodeName, tunnelRemoteNodeName, tunnelLocalLinkName string,
+	launcherName string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
 	sortedKeys []string,
 	processedTunnels map[string][]*clabernetesapisv1alpha1.PointToPointTunnel,
 ) int {
-	for _, remoteNodeName := range sortedKeys {
-		if nodeName == remoteNodeName {
+	for _, remoteLauncherName := range sortedKeys {
+		if launcherName == remoteLauncherName {
 			// this is us, next...
 			continue
 		}
 
-		if tunnelRemoteNodeName != remoteNodeName {
-			// this remote node name doesnt match our current tunnels remote node name
-			// so not our remote end, next...
-			continue
-		}
-
-		for _, remoteTunnel := range processedTunnels[remoteNodeName] {
-			if remoteTunnel.RemoteNode != nodeName {
+		for _, remoteTunnel := range processedTunnels[remoteLauncherName] {
+			if remoteTunnel.LocalNode != tunnel.RemoteNode ||
+				remoteTunnel.RemoteNode != tunnel.LocalNode {
 				// tunnel not between this node pair
 				continue
 			}
 
-			if tunnelLocalLinkName != remoteTunnel.RemoteInterface {
+			if remoteTunnel.RemoteInterface != tunnel.LocalInterface ||
+				remoteTunnel.LocalInterface != tunnel.RemoteInterface {
 				// this specific tunnel does not match our local tunnel
 				continue
 			}
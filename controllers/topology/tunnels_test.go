@@ -394,6 +394,48 @@ func TestAllocateTunnelIds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "node-groups",
+			previousTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{},
+			processedTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+				"srl1": {
+					{
+						TunnelID:        0,
+						LocalNode:       "srl1",
+						Destination:     "topo-1-srl2.clabernetes.svc.cluster.local",
+						RemoteNode:      "srl2",
+						LocalInterface:  "e1-1",
+						RemoteInterface: "e1-1",
+					},
+					{
+						TunnelID:        0,
+						LocalNode:       "client1",
+						Destination:     "topo-1-srl2.clabernetes.svc.cluster.local",
+						RemoteNode:      "client2",
+						LocalInterface:  "eth1",
+						RemoteInterface: "eth1",
+					},
+				},
+				"srl2": {
+					{
+						TunnelID:        0,
+						LocalNode:       "client2",
+						Destination:     "topo-1-srl1.clabernetes.svc.cluster.local",
+						RemoteNode:      "client1",
+						LocalInterface:  "eth1",
+						RemoteInterface: "eth1",
+					},
+					{
+						TunnelID:        0,
+						LocalNode:       "srl2",
+						Destination:     "topo-1-srl1.clabernetes.svc.cluster.local",
+						RemoteNode:      "srl1",
+						LocalInterface:  "e1-1",
+						RemoteInterface: "e1-1",
+					},
+				},
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -2,10 +2,17 @@ package topology
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	clabernetesapis "github.com/srl-labs/clabernetes/apis"
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconfig "github.com/srl-labs/clabernetes/config"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
 )
 
 // GetTopologyKind returns the "kind" of topology this CR represents -- this will always be
@@ -39,6 +46,73 @@ func ResolveTopologyRemovePrefix(t *clabernetesapisv1alpha1.Topology) bool {
 	return *t.Status.RemoveTopologyPrefix
 }
 
+// getLauncherNodeNames returns the (sorted) names of the containerlab nodes that the launcher with
+// the given name is responsible for. Without node grouping this is only ever the launcher (node)
+// itself.
+func getLauncherNodeNames(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	launcherName string,
+) []string {
+	launcherConfig, ok := clabernetesConfigs[launcherName]
+	if !ok || launcherConfig == nil || launcherConfig.Topology == nil ||
+		len(launcherConfig.Topology.Nodes) == 0 {
+		return []string{launcherName}
+	}
+
+	return slices.Sorted(maps.Keys(launcherConfig.Topology.Nodes))
+}
+
+// getNodeLaunchers returns a mapping of containerlab node name to the name of the launcher that is
+// responsible for that node.
+func getNodeLaunchers(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+) map[string]string {
+	nodeLaunchers := make(map[string]string)
+
+	for launcherName := range clabernetesConfigs {
+		for _, nodeName := range getLauncherNodeNames(clabernetesConfigs, launcherName) {
+			nodeLaunchers[nodeName] = launcherName
+		}
+	}
+
+	return nodeLaunchers
+}
+
+// getLauncherWaitFor returns the (sorted) names of the containerlab nodes that the nodes of the
+// launcher with the given name wait for (via the containerlab "wait-for" setting) but that are
+// *not* handled by the launcher itself. Nodes waiting on other nodes in the same launcher are
+// taken care of by containerlab, the others are what the launcher has to wait on before launching.
+func getLauncherWaitFor(
+	clabernetesConfigs map[string]*clabernetesutilcontainerlab.Config,
+	launcherName string,
+) []string {
+	launcherConfig, ok := clabernetesConfigs[launcherName]
+	if !ok || launcherConfig == nil || launcherConfig.Topology == nil {
+		return nil
+	}
+
+	var waitFor []string
+
+	for _, nodeDefinition := range launcherConfig.Topology.Nodes {
+		if nodeDefinition == nil {
+			continue
+		}
+
+		for _, waitForNodeName := range nodeDefinition.WaitFor {
+			_, inLauncher := launcherConfig.Topology.Nodes[waitForNodeName]
+			if inLauncher || slices.Contains(waitFor, waitForNodeName) {
+				continue
+			}
+
+			waitFor = append(waitFor, waitForNodeName)
+		}
+	}
+
+	slices.Sort(waitFor)
+
+	return waitFor
+}
+
 func resolveConnectivityDestination(
 	topologyName,
 	uninterestingEndpointNodeName,
@@ -66,3 +140,43 @@ func resolveConnectivityDestination(
 
 	return destination
 }
+
+// resolveConnectivityPort returns the name, port number and protocol the fabric service and
+// launcher container use for the given topology's connectivity flavor.
+func resolveConnectivityPort(
+	owningTopology *clabernetesapisv1alpha1.Topology,
+) (name string, port int32, protocol k8scorev1.Protocol) {
+	switch owningTopology.Spec.Connectivity {
+	case clabernetesconstants.ConnectivityGENEVE:
+		return clabernetesconstants.ConnectivityGENEVE,
+			clabernetesconstants.GENEVEServicePort,
+			clabernetesconstants.UDP
+	case clabernetesconstants.ConnectivitySlurpeeth:
+		return clabernetesconstants.ConnectivitySlurpeeth,
+			clabernetesconstants.SlurpeethServicePort,
+			clabernetesconstants.TCP
+	default:
+		return clabernetesconstants.ConnectivityVXLAN,
+			clabernetesconstants.VXLANServicePort,
+			clabernetesconstants.UDP
+	}
+}
+
+// ownerReferencesWithout returns a copy of the given owner references with any reference to the
+// given uid removed.
+func ownerReferencesWithout(
+	ownerReferences []metav1.OwnerReference,
+	uid apimachinerytypes.UID,
+) []metav1.OwnerReference {
+	remainingOwnerReferences := make([]metav1.OwnerReference, 0, len(ownerReferences))
+
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.UID == uid {
+			continue
+		}
+
+		remainingOwnerReferences = append(remainingOwnerReferences, ownerReference)
+	}
+
+	return remainingOwnerReferences
+}
@@ -0,0 +1,64 @@
+package topologysnapshot
+
+import (
+	"fmt"
+
+	clabernetesapis "github.com/srl-labs/clabernetes/apis"
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetescontrollers "github.com/srl-labs/clabernetes/controllers"
+	clabernetesmanagertypes "github.com/srl-labs/clabernetes/manager/types"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimecontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	concurrentReconciles = 10
+)
+
+// NewController returns a new Controller.
+func NewController(
+	clabernetes clabernetesmanagertypes.Clabernetes,
+) clabernetescontrollers.Controller {
+	ctx := clabernetes.GetContext()
+
+	baseController := clabernetescontrollers.NewBaseController(
+		ctx,
+		clabernetesapis.TopologySnapshot,
+		clabernetes.GetAppName(),
+		clabernetes.GetKubeConfig(),
+		clabernetes.GetCtrlRuntimeClient(),
+		clabernetes.GetCtrlRuntimeMgr().GetEventRecorderFor(
+			fmt.Sprintf("%s-%s", clabernetes.GetAppName(), clabernetesapis.TopologySnapshot),
+		),
+	)
+
+	c := &Controller{
+		BaseController: baseController,
+	}
+
+	return c
+}
+
+// Controller is the TopologySnapshot controller object. The actual capturing of node
+// configurations is done by the launchers, this controller just keeps track of when all nodes of
+// the topology have reported in.
+type Controller struct {
+	*clabernetescontrollers.BaseController
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (c *Controller) SetupWithManager(mgr ctrlruntime.Manager) error {
+	c.BaseController.Log.Infof(
+		"setting up %s controller with manager",
+		clabernetesapis.TopologySnapshot,
+	)
+
+	return ctrlruntime.NewControllerManagedBy(mgr).
+		WithOptions(
+			ctrlruntimecontroller.Options{
+				MaxConcurrentReconciles: concurrentReconciles,
+			},
+		).
+		For(&clabernetesapisv1alpha1.TopologySnapshot{}).
+		Complete(c)
+}
@@ -0,0 +1,138 @@
+package topologysnapshot
+
+import (
+	"context"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	ctrlruntime "sigs.k8s.io/controller-runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reconcile handles reconciliation for this controller.
+func (c *Controller) Reconcile(
+	ctx context.Context,
+	req ctrlruntime.Request,
+) (ctrlruntime.Result, error) {
+	c.BaseController.LogReconcileStart(ctx, req)
+
+	topologySnapshot, err := c.getTopologySnapshotFromReq(ctx, req)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			c.BaseController.LogReconcileCompleteObjectNotExist(ctx, req)
+
+			return ctrlruntime.Result{}, nil
+		}
+
+		c.BaseController.LogReconcileFailedGettingObject(ctx, req, err)
+
+		return ctrlruntime.Result{}, err
+	}
+
+	if topologySnapshot.DeletionTimestamp != nil || topologySnapshot.Status.Complete {
+		return ctrlruntime.Result{}, nil
+	}
+
+	topology := &clabernetesapisv1alpha1.Topology{}
+
+	err = c.BaseController.Client.Get(
+		ctx,
+		apimachinerytypes.NamespacedName{
+			Namespace: topologySnapshot.Namespace,
+			Name:      topologySnapshot.Spec.TopologyName,
+		},
+		topology,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			c.BaseController.Log.Warnf(
+				"topology %q of snapshot %q does not exist, snapshot will never complete",
+				topologySnapshot.Spec.TopologyName,
+				topologySnapshot.Name,
+			)
+
+			return ctrlruntime.Result{}, nil
+		}
+
+		return ctrlruntime.Result{}, err
+	}
+
+	launcherDeployments := &k8sappsv1.DeploymentList{}
+
+	err = c.BaseController.Client.List(
+		ctx,
+		launcherDeployments,
+		ctrlruntimeclient.InNamespace(topology.Namespace),
+		ctrlruntimeclient.MatchingLabels{
+			clabernetesconstants.LabelTopologyOwner: topology.Name,
+		},
+	)
+	if err != nil {
+		return ctrlruntime.Result{}, err
+	}
+
+	if !allNodesReported(topology, topologySnapshot, launcherDeployments) {
+		// launchers patching in their node results will land us back here
+		c.BaseController.LogReconcileCompleteSuccess(ctx, req)
+
+		return ctrlruntime.Result{}, nil
+	}
+
+	topologySnapshot.Status.Complete = true
+
+	err = c.update(ctx, topologySnapshot)
+	if err != nil {
+		return ctrlruntime.Result{}, err
+	}
+
+	c.BaseController.LogReconcileCompleteSuccess(ctx, req)
+
+	return ctrlruntime.Result{}, nil
+}
+
+// allNodesReported returns true if every node of every launcher of the topology has a result in
+// the status of the snapshot. Launchers that did not exist yet when the snapshot was taken don't
+// take part in it (they skip snapshots older than themselves), so their nodes are not waited on.
+func allNodesReported(
+	topology *clabernetesapisv1alpha1.Topology,
+	topologySnapshot *clabernetesapisv1alpha1.TopologySnapshot,
+	launcherDeployments *k8sappsv1.DeploymentList,
+) bool {
+	if len(topology.Status.Configs) == 0 {
+		return false
+	}
+
+	launcherCreationTimes := make(map[string]metav1.Time)
+
+	for i := range launcherDeployments.Items {
+		launcherName := launcherDeployments.Items[i].Labels[clabernetesconstants.LabelTopologyNode]
+
+		launcherCreationTimes[launcherName] = launcherDeployments.Items[i].CreationTimestamp
+	}
+
+	for launcherName, rawConfig := range topology.Status.Configs {
+		launcherCreated, ok := launcherCreationTimes[launcherName]
+		if !ok || topologySnapshot.CreationTimestamp.Before(&launcherCreated) {
+			continue
+		}
+
+		config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(rawConfig)
+		if err != nil || config.Topology == nil {
+			return false
+		}
+
+		for nodeName := range config.Topology.Nodes {
+			_, ok := topologySnapshot.Status.Nodes[nodeName]
+			if !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -63,8 +63,10 @@ func TestContainerlabBasic(t *testing.T) {
 				},
 				"connectivity": {
 					{
-						Name:           testName,
-						NormalizeFuncs: []func(t *testing.T, objectData []byte) []byte{},
+						Name: testName,
+						NormalizeFuncs: []func(t *testing.T, objectData []byte) []byte{
+							clabernetestesthelper.NormalizeConnectivity,
+						},
 					},
 				},
 			},
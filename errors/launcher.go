@@ -8,3 +8,14 @@ var ErrConnectivity = errors.New("errConnectivity")
 // ErrLaunch is the error returned when encountering issues with launching things in a
 // clabernetes pod.
 var ErrLaunch = errors.New("errLaunch")
+
+// ErrCapture is the error returned when encountering issues with packet captures of clabernetes
+// nodes.
+var ErrCapture = errors.New("errCapture")
+
+// ErrSnapshot is the error returned when encountering issues capturing the configuration of
+// clabernetes nodes for a TopologySnapshot.
+var ErrSnapshot = errors.New("errSnapshot")
+
+// ErrProbe is the error returned when a status probe of a clabernetes node fails.
+var ErrProbe = errors.New("errProbe")
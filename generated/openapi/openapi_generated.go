@@ -28,41 +28,61 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Config":                schema_srl_labs_clabernetes_apis_v1alpha1_Config(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigDeployment":      schema_srl_labs_clabernetes_apis_v1alpha1_ConfigDeployment(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigImagePull":       schema_srl_labs_clabernetes_apis_v1alpha1_ConfigImagePull(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigList":            schema_srl_labs_clabernetes_apis_v1alpha1_ConfigList(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigMetadata":        schema_srl_labs_clabernetes_apis_v1alpha1_ConfigMetadata(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigSpec":            schema_srl_labs_clabernetes_apis_v1alpha1_ConfigSpec(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigStatus":          schema_srl_labs_clabernetes_apis_v1alpha1_ConfigStatus(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Connectivity":          schema_srl_labs_clabernetes_apis_v1alpha1_Connectivity(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConnectivityList":      schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivityList(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConnectivitySpec":      schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivitySpec(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConnectivityStatus":    schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivityStatus(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Definition":            schema_srl_labs_clabernetes_apis_v1alpha1_Definition(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Deployment":            schema_srl_labs_clabernetes_apis_v1alpha1_Deployment(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Expose":                schema_srl_labs_clabernetes_apis_v1alpha1_Expose(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ExposedPorts":          schema_srl_labs_clabernetes_apis_v1alpha1_ExposedPorts(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromConfigMap":     schema_srl_labs_clabernetes_apis_v1alpha1_FileFromConfigMap(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromURL":           schema_srl_labs_clabernetes_apis_v1alpha1_FileFromURL(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImagePull":             schema_srl_labs_clabernetes_apis_v1alpha1_ImagePull(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequest":          schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequest(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequestList":      schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestList(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequestSpec":      schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestSpec(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequestStatus":    schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestStatus(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.LinkEndpoint":          schema_srl_labs_clabernetes_apis_v1alpha1_LinkEndpoint(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Persistence":           schema_srl_labs_clabernetes_apis_v1alpha1_Persistence(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.PointToPointTunnel":    schema_srl_labs_clabernetes_apis_v1alpha1_PointToPointTunnel(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ProbeConfiguration":    schema_srl_labs_clabernetes_apis_v1alpha1_ProbeConfiguration(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.ReconcileHashes":       schema_srl_labs_clabernetes_apis_v1alpha1_ReconcileHashes(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.SSHProbeConfiguration": schema_srl_labs_clabernetes_apis_v1alpha1_SSHProbeConfiguration(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Scheduling":            schema_srl_labs_clabernetes_apis_v1alpha1_Scheduling(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.StatusProbes":          schema_srl_labs_clabernetes_apis_v1alpha1_StatusProbes(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.TCPProbeConfiguration": schema_srl_labs_clabernetes_apis_v1alpha1_TCPProbeConfiguration(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.Topology":              schema_srl_labs_clabernetes_apis_v1alpha1_Topology(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologyList":          schema_srl_labs_clabernetes_apis_v1alpha1_TopologyList(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySpec":          schema_srl_labs_clabernetes_apis_v1alpha1_TopologySpec(ref),
-		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologyStatus":        schema_srl_labs_clabernetes_apis_v1alpha1_TopologyStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Config":                    schema_srl_labs_clabernetes_apis_v1alpha1_Config(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigDeployment":          schema_srl_labs_clabernetes_apis_v1alpha1_ConfigDeployment(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigImagePull":           schema_srl_labs_clabernetes_apis_v1alpha1_ConfigImagePull(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigList":                schema_srl_labs_clabernetes_apis_v1alpha1_ConfigList(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigMetadata":            schema_srl_labs_clabernetes_apis_v1alpha1_ConfigMetadata(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigSpec":                schema_srl_labs_clabernetes_apis_v1alpha1_ConfigSpec(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConfigStatus":              schema_srl_labs_clabernetes_apis_v1alpha1_ConfigStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Connectivity":              schema_srl_labs_clabernetes_apis_v1alpha1_Connectivity(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConnectivityList":          schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivityList(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConnectivitySpec":          schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivitySpec(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ConnectivityStatus":        schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivityStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Definition":                schema_srl_labs_clabernetes_apis_v1alpha1_Definition(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Deployment":                schema_srl_labs_clabernetes_apis_v1alpha1_Deployment(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ExecProbeConfiguration":    schema_srl_labs_clabernetes_apis_v1alpha1_ExecProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Expose":                    schema_srl_labs_clabernetes_apis_v1alpha1_Expose(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ExposedPorts":              schema_srl_labs_clabernetes_apis_v1alpha1_ExposedPorts(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromConfigMap":         schema_srl_labs_clabernetes_apis_v1alpha1_FileFromConfigMap(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromURL":               schema_srl_labs_clabernetes_apis_v1alpha1_FileFromURL(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.GNMIProbeConfiguration":    schema_srl_labs_clabernetes_apis_v1alpha1_GNMIProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.HTTPProbeConfiguration":    schema_srl_labs_clabernetes_apis_v1alpha1_HTTPProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImagePull":                 schema_srl_labs_clabernetes_apis_v1alpha1_ImagePull(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequest":              schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequest(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequestList":          schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestList(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequestSpec":          schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestSpec(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ImageRequestStatus":        schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Impairment":                schema_srl_labs_clabernetes_apis_v1alpha1_Impairment(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.LinkAdminState":            schema_srl_labs_clabernetes_apis_v1alpha1_LinkAdminState(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.LinkEndpoint":              schema_srl_labs_clabernetes_apis_v1alpha1_LinkEndpoint(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.LinkImpairment":            schema_srl_labs_clabernetes_apis_v1alpha1_LinkImpairment(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Multus":                    schema_srl_labs_clabernetes_apis_v1alpha1_Multus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.NETCONFProbeConfiguration": schema_srl_labs_clabernetes_apis_v1alpha1_NETCONFProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.NativeMode":                schema_srl_labs_clabernetes_apis_v1alpha1_NativeMode(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.NodeGrouping":              schema_srl_labs_clabernetes_apis_v1alpha1_NodeGrouping(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.NodeSnapshotStatus":        schema_srl_labs_clabernetes_apis_v1alpha1_NodeSnapshotStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.PendingRestart":            schema_srl_labs_clabernetes_apis_v1alpha1_PendingRestart(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Persistence":               schema_srl_labs_clabernetes_apis_v1alpha1_Persistence(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.PointToPointTunnel":        schema_srl_labs_clabernetes_apis_v1alpha1_PointToPointTunnel(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.PointToPointTunnelStatus":  schema_srl_labs_clabernetes_apis_v1alpha1_PointToPointTunnelStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ProbeConfiguration":        schema_srl_labs_clabernetes_apis_v1alpha1_ProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ProbeResult":               schema_srl_labs_clabernetes_apis_v1alpha1_ProbeResult(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.ReconcileHashes":           schema_srl_labs_clabernetes_apis_v1alpha1_ReconcileHashes(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.RestartProgress":           schema_srl_labs_clabernetes_apis_v1alpha1_RestartProgress(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.RestartStrategy":           schema_srl_labs_clabernetes_apis_v1alpha1_RestartStrategy(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.SSHProbeConfiguration":     schema_srl_labs_clabernetes_apis_v1alpha1_SSHProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Scheduling":                schema_srl_labs_clabernetes_apis_v1alpha1_Scheduling(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.StatusProbes":              schema_srl_labs_clabernetes_apis_v1alpha1_StatusProbes(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TCPProbeConfiguration":     schema_srl_labs_clabernetes_apis_v1alpha1_TCPProbeConfiguration(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.Topology":                  schema_srl_labs_clabernetes_apis_v1alpha1_Topology(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologyList":              schema_srl_labs_clabernetes_apis_v1alpha1_TopologyList(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshot":          schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshot(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotList":      schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshotList(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotSpec":      schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshotSpec(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotStatus":    schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshotStatus(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySpec":              schema_srl_labs_clabernetes_apis_v1alpha1_TopologySpec(ref),
+		"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologyStatus":            schema_srl_labs_clabernetes_apis_v1alpha1_TopologyStatus(ref),
 	}
 }
 
@@ -226,6 +246,13 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_ConfigDeployment(ref common.Refer
 							Format:      "",
 						},
 					},
+					"launcherLogFormat": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LauncherLogFormat sets the launcher clabernetes worker log format -- \"text\" (the default) for humans or \"json\" for log pipelines. Note: omitempty because empty str does not satisfy enum of course.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"extraEnv": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -597,8 +624,33 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_ConnectivityStatus(ref common.Ref
 			SchemaProps: spec.SchemaProps{
 				Description: "ConnectivityStatus is the status for a Connectivity resource.",
 				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pointToPointTunnels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PointToPointTunnels holds the state of the point-to-point tunnels as reported by the launcher pods. The mapping mirrors the spec's PointToPointTunnels -- nodeName (i.e. srl1) -> p2p tunnel status data, with each launcher only ever reporting on its own tunnels.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type: []string{"array"},
+										Items: &spec.SchemaOrArray{
+											Schema: &spec.Schema{
+												SchemaProps: spec.SchemaProps{
+													Ref: ref("github.com/srl-labs/clabernetes/apis/v1alpha1.PointToPointTunnelStatus"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.PointToPointTunnelStatus"},
 	}
 }
 
@@ -702,6 +754,13 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_Deployment(ref common.ReferenceCa
 							},
 						},
 					},
+					"restoreFromSnapshot": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestoreFromSnapshot is the name of a TopologySnapshot (in the namespace of the Topology) whose saved node configurations should be used as the startup-config of the nodes of this Topology. The saved configurations are copied into ConfigMaps owned by the Topology and mounted just like FilesFromConfigMap, so the snapshot may be deleted once the Topology is restored. Nodes the snapshot failed to capture keep their own startup-config (if any). The Topology is not reconciled until the snapshot is complete.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"persistence": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Persistence holds configurations relating to persisting each nodes working containerlab directory.",
@@ -709,6 +768,27 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_Deployment(ref common.ReferenceCa
 							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.Persistence"),
 						},
 					},
+					"nodeGrouping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeGrouping holds configurations relating to co-locating multiple containerlab nodes in a single launcher pod. By default every node gets its own launcher.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.NodeGrouping"),
+						},
+					},
+					"restartStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartStrategy holds configurations relating to how nodes are restarted when their configuration changes. By default all nodes needing a restart are restarted at once.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.RestartStrategy"),
+						},
+					},
+					"nativeMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NativeMode holds configurations relating to running nodes directly as containers of their launcher pod rather than via docker and containerlab inside the launcher. By default all nodes are launched via containerlab.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.NativeMode"),
+						},
+					},
 					"containerlabDebug": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ContainerlabDebug sets the `--debug` flag when invoking containerlab in the launcher pods. This is disabled by default. If this value is unset, the global config value (default of \"false\") will be used.",
@@ -752,6 +832,13 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_Deployment(ref common.ReferenceCa
 							Format:      "",
 						},
 					},
+					"launcherLogFormat": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LauncherLogFormat sets the launcher clabernetes worker log format (\"text\" or \"json\") for this topology -- this overrides whatever is set in the global config CR. Note: omitempty because empty str does not satisfy enum of course.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"extraEnv": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -775,7 +862,48 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_Deployment(ref common.ReferenceCa
 			},
 		},
 		Dependencies: []string{
-			"github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromConfigMap", "github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromURL", "github.com/srl-labs/clabernetes/apis/v1alpha1.Persistence", "github.com/srl-labs/clabernetes/apis/v1alpha1.Scheduling", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromConfigMap", "github.com/srl-labs/clabernetes/apis/v1alpha1.FileFromURL", "github.com/srl-labs/clabernetes/apis/v1alpha1.NativeMode", "github.com/srl-labs/clabernetes/apis/v1alpha1.NodeGrouping", "github.com/srl-labs/clabernetes/apis/v1alpha1.Persistence", "github.com/srl-labs/clabernetes/apis/v1alpha1.RestartStrategy", "github.com/srl-labs/clabernetes/apis/v1alpha1.Scheduling", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_ExecProbeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ExecProbeConfiguration defines an \"exec\" probe -- the probe executes a command inside the node container (via docker exec) and reports true if the command exits zero and, if configured, its output matches the OutputRegex. Like the other probes, the probe is executed by the launcher and the result is placed into /clabernetes/.nodestatus.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"command": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Command is the command (and its arguments) to execute inside the node container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"outputRegex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OutputRegex is an optional regular expression the (combined stdout and stderr) output of the command must match.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"command"},
+			},
+		},
 	}
 }
 
@@ -966,6 +1094,98 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_FileFromURL(ref common.ReferenceC
 	}
 }
 
+func schema_srl_labs_clabernetes_apis_v1alpha1_GNMIProbeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GNMIProbeConfiguration defines a \"gnmi\" probe -- the probe sends a gNMI Capabilities RPC to the node and reports true if the node answers it successfully. Like the other probes, the probe is executed by the launcher and the result is placed into /clabernetes/.nodestatus.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is an optional override (default is 57400).",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"insecure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Insecure, when true, sends the RPC over a plain text connection rather than TLS. Note that the certificate of the node is not verified when using TLS.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username is the username sent along with the RPC. If not set, the username is taken from the \"username\" key of the CredentialsSecret.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecret is the name of a secret holding the credentials sent along with the RPC. The secret *must be present in the namespace of this topology*, the \"username\" and \"password\" keys of the secret are used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_HTTPProbeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPProbeConfiguration defines a \"http\" probe -- the probe sends a GET request to the node and reports true if the response has the expected status code and, if configured, a body matching the BodyRegex. Like the other probes, the probe is executed by the launcher and the result is placed into /clabernetes/.nodestatus.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the port to send the request to.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path to request, defaults to \"/\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scheme": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheme is the scheme to use for the request, defaults to \"HTTP\". Note that the certificate of the node is not verified when using \"HTTPS\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"statusCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatusCode is the expected status code of the response, defaults to 200.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"bodyRegex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BodyRegex is an optional regular expression the body of the response must match.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"port"},
+			},
+		},
+	}
+}
+
 func schema_srl_labs_clabernetes_apis_v1alpha1_ImagePull(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1227,198 +1447,835 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_ImageRequestStatus(ref common.Ref
 	}
 }
 
-func schema_srl_labs_clabernetes_apis_v1alpha1_LinkEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_srl_labs_clabernetes_apis_v1alpha1_Impairment(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LinkEndpoint is a simple struct to hold node/interface name info for a given link.",
+				Description: "Impairment holds the netem settings applied to a link. Unset fields are simply not applied.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"nodeName": {
+					"delay": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeName is the name of the node this link resides on.",
-							Default:     "",
+							Description: "Delay is the delay to add to frames on the link, i.e. \"50ms\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"interfaceName": {
+					"jitter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "InterfaceName is the name of the interface on the node this link is on.",
-							Default:     "",
+							Description: "Jitter is the variation of the delay, i.e. \"5ms\". Jitter is only applicable if Delay is set.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-				Required: []string{"nodeName", "interfaceName"},
-			},
-		},
-	}
-}
-
-func schema_srl_labs_clabernetes_apis_v1alpha1_Persistence(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Persistence holds information about how to persist the containlerab lab directory for each node in a topology.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"enabled": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Enabled indicates if persistence of hte containerlab lab/working directory will be placed in a mounted PVC.",
-							Default:     false,
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"claimSize": {
+					"loss": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ClaimSize is the size of the PVC for this topology -- if not provided this defaults to 5Gi. If provided, the string value must be a valid kubernetes storage requests style string. Note the claim size *cannot be made smaller* once created, but it *can* be expanded. If you need to make the claim smaller you must delete the topology (or the node from the topology) and re-add it.",
+							Description: "Loss is the percentage of frames to drop on the link, i.e. \"0.5\" for half a percent.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"storageClassName": {
+					"rate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "StorageClassName is the storage class to set in the PVC -- if not provided this will be left empty which will end up using your default storage class. Note that currently we assume you have (as default) or provide a dynamically provisionable storage class, hence no selector.",
+							Description: "Rate limits the rate of the link, in tc rate notation, i.e. \"100mbit\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"enabled"},
 			},
 		},
 	}
 }
 
-func schema_srl_labs_clabernetes_apis_v1alpha1_PointToPointTunnel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_srl_labs_clabernetes_apis_v1alpha1_LinkAdminState(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PointToPointTunnel holds information necessary for creating a tunnel between two interfaces on different nodes of a clabernetes Topology. This connection is established using VXLAN tunnels.",
+				Description: "LinkAdminState holds the administrative state for a single link in the topology.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tunnelID": {
-						SchemaProps: spec.SchemaProps{
-							Description: "TunnelID is the id number of the tunnel (vnid or segment id).",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"destination": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Destination is the destination service to connect to (qualified k8s service name).",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"localNode": {
+					"endpoint": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LocalNodeName is the name (in the clabernetes topology) of the local node for this side of the tunnel.",
+							Description: "Endpoint selects the link by one of its endpoints in the containerlab \"node:interface\" format, i.e. \"srl1:e1-1\".",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"localInterface": {
+					"adminState": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LocalInterface is the local termination of this tunnel.",
+							Description: "AdminState is the administrative state of the link, \"up\" or \"down\".",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"remoteNode": {
+				},
+				Required: []string{"endpoint", "adminState"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_LinkEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LinkEndpoint is a simple struct to hold node/interface name info for a given link.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RemoteNode is the name (in the clabernetes topology) of the remote node for this side of the tunnel.",
+							Description: "NodeName is the name of the node this link resides on.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"remoteInterface": {
+					"interfaceName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RemoteInterface is the remote termination interface of this tunnel -- necessary to store so can properly align tunnels (and ids!) between nodes; basically to know which tunnels are \"paired up\".",
+							Description: "InterfaceName is the name of the interface on the node this link is on.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"tunnelID", "destination", "localNode", "localInterface", "remoteNode", "remoteInterface"},
+				Required: []string{"nodeName", "interfaceName"},
 			},
 		},
 	}
 }
 
-func schema_srl_labs_clabernetes_apis_v1alpha1_ProbeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_srl_labs_clabernetes_apis_v1alpha1_LinkImpairment(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ProbeConfiguration holds information about how to probe a (containerlab) node in a Topology. If both style probes are configured, both will be used and both must succeed in order to report healthy.",
+				Description: "LinkImpairment holds network impairment settings for a single link in the topology.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"startupSeconds": {
-						SchemaProps: spec.SchemaProps{
-							Description: "StartupSeconds is the total amount of seconds to allow for the node to start. This defaults to ~13 minutes to hopefully account for slow to boot nodes. Note that there is also a 60 initial delay configured, so technically the default is ~14-15 minutes. Be careful with this delay as there must be time for c9s to (via whatever means) pull the image and load it into docker on the launcher and this can take a bit! Having this be bigger than you think you need is generally better since if the startup probe succeeds ever then the readiness probe takes over anyway.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
-					},
-					"sshProbeConfiguration": {
+					"endpoint": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SSHProbeConfiguration defines an SSH probe.",
-							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.SSHProbeConfiguration"),
+							Description: "Endpoint selects the link to impair by one of its endpoints in the containerlab \"node:interface\" format, i.e. \"srl1:e1-1\". The impairment applies to both directions of the link, so it does not matter which of the link's two endpoints is used here.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"tcpProbeConfiguration": {
+					"impairment": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TCPProbeConfiguration defines a TCP probe.",
-							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.TCPProbeConfiguration"),
+							Description: "Impairment is the impairment to apply to the link.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.Impairment"),
 						},
 					},
 				},
+				Required: []string{"endpoint", "impairment"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/srl-labs/clabernetes/apis/v1alpha1.SSHProbeConfiguration", "github.com/srl-labs/clabernetes/apis/v1alpha1.TCPProbeConfiguration"},
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.Impairment"},
 	}
 }
 
-func schema_srl_labs_clabernetes_apis_v1alpha1_ReconcileHashes(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_srl_labs_clabernetes_apis_v1alpha1_Multus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Multus holds the settings of the \"multus\" connectivity flavor -- that is the cni configuration the controller renders into the NetworkAttachmentDefinition it creates for each link that spans launchers. Links are kept apart from each other by their vlan id, which is the (per topology unique) tunnel id of the link.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the cni plugin used for the link attachments. With \"vlan\" (the default) each attachment is a vlan interface on the master interface of the kubernetes node. With \"macvlan\" each attachment is a macvlan interface on the vlan interface \"<master>.<vlan id>\", which must already exist on the kubernetes nodes. With \"bridge\" each attachment is connected to the bridge named by master (or the cni default bridge if unset) with the vlan id of the link as the vlan of the bridge port.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"master": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Master is the interface of the kubernetes nodes the attachments are created on, or the name of the bridge when using the \"bridge\" type.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mtu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MTU is the mtu of the attachments, if unset the cni plugin default is used.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"vlanBase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VLANBase is the vlan id of the link with tunnel id 1, every link gets the vlan id \"vlan base + tunnel id - 1\" -- tunnel ids are kept for as long as the link exists, so they are not necessarily consecutive. Topologies sharing a master interface (or bridge) must use vlan ranges that do not overlap, the validating webhook rejects vlan bases overlapping the range of another topology. Defaults to 2 as vlan 1 usually is the default (native) vlan.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_NETCONFProbeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NETCONFProbeConfiguration defines a \"netconf\" probe -- the probe opens the NETCONF ssh subsystem and reports true if the node sends its hello (we answer with ours before closing the session). Like the other probes, the probe is executed by the launcher and the result is placed into /clabernetes/.nodestatus.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is an optional override (default is 830).",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username is the username to use for auth. If not set, the username is taken from the \"username\" key of the CredentialsSecret.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecret is the name of a secret holding the credentials to use for auth. The secret *must be present in the namespace of this topology*, and may contain the keys \"username\", \"password\" and \"ssh-privatekey\" -- just like the credentials secret of the ssh probe.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"credentialsSecret"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_NativeMode(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NativeMode holds information about which nodes run in \"native\" mode. The image of a native mode node is rendered directly as a container of the launcher pod, the launcher itself shrinks to a sidecar that only plumbs the node interfaces and handles the tunnels to other nodes -- there is no docker daemon in the pod, so no double image storage and much faster startups. Only container native kinds (srl, ceos and linux) can run in native mode, and native mode nodes always get a launcher of their own (they cannot be part of a node group).",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes is the list of names of the nodes that should run in native mode.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_NodeGrouping(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeGrouping holds information about which containerlab nodes should share a launcher pod. Links between nodes in the same group are left as \"normal\" containerlab veth links, only links that leave the group are tunneled. Each group is handled by a single launcher that is named after the \"primary\" node of the group -- this node provides the image, resources, node selectors and probe configuration for the launcher, and its name is the one used for the deployment and services. Nodes that are not part of any group are deployed in their own launcher as usual.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"containerlabGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerlabGroups, when true, co-locates all nodes that share the same containerlab \"group\" (as set on the node, its kind, or the topology defaults) in a single launcher. The primary node of each group is the first node of the group in alphabetical order.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"groups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Groups is an explicit mapping of group name to the names of the nodes that should be co-located in a single launcher. The primary node of each group is the first node of the group in alphabetical order, so the order of the list does not matter. A node may only be a member of a single group. When set, this takes precedence over ContainerlabGroups.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type: []string{"array"},
+										Items: &spec.SchemaOrArray{
+											Schema: &spec.Schema{
+												SchemaProps: spec.SchemaProps{
+													Default: "",
+													Type:    []string{"string"},
+													Format:  "",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_NodeSnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeSnapshotStatus holds the result of the configuration capture of a single node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is the state of the configuration capture of the node, \"saved\" once the launcher has stored the configuration of the node, or \"failed\" if it could not do so.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configMapName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMapName is the name of the ConfigMap holding the saved configuration of the node.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastError is the error the launcher encountered capturing the configuration of the node, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the last time the launcher updated the state of this node.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"state", "lastUpdateTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_PendingRestart(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PendingRestart holds information about a node restart that is waiting to be requested.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is why the node needs to be restarted.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"diff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Diff is the (unified) diff of the containerlab configuration of the node since the restart became pending.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"reason"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_Persistence(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Persistence holds information about how to persist the containlerab lab directory for each node in a topology.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled indicates if persistence of hte containerlab lab/working directory will be placed in a mounted PVC.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"claimSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimSize is the size of the PVC for this topology -- if not provided this defaults to 5Gi. If provided, the string value must be a valid kubernetes storage requests style string. Note the claim size *cannot be made smaller* once created, but it *can* be expanded. If you need to make the claim smaller you must delete the topology (or the node from the topology) and re-add it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClassName is the storage class to set in the PVC -- if not provided this will be left empty which will end up using your default storage class. Note that currently we assume you have (as default) or provide a dynamically provisionable storage class, hence no selector.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"enabled"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_PointToPointTunnel(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PointToPointTunnel holds information necessary for creating a tunnel between two interfaces on different nodes of a clabernetes Topology. This connection is established using VXLAN tunnels.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tunnelID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TunnelID is the id number of the tunnel (vnid or segment id).",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"destination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Destination is the destination service to connect to (qualified k8s service name).",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"localNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalNodeName is the name (in the clabernetes topology) of the local node for this side of the tunnel.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"localInterface": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalInterface is the local termination of this tunnel.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"remoteNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoteNode is the name (in the clabernetes topology) of the remote node for this side of the tunnel.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"remoteInterface": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoteInterface is the remote termination interface of this tunnel -- necessary to store so can properly align tunnels (and ids!) between nodes; basically to know which tunnels are \"paired up\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"impairment": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Impairment holds the network impairment settings the launcher should apply to this tunnel, if any.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.Impairment"),
+						},
+					},
+					"adminState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdminState is the administrative state of the link carried by this tunnel, \"up\" or \"down\". An unset admin state means the link is up.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"tunnelID", "destination", "localNode", "localInterface", "remoteNode", "remoteInterface"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.Impairment"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_PointToPointTunnelStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PointToPointTunnelStatus holds the state of a single PointToPointTunnel as reported by the launcher pod that handles the local side of the tunnel.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tunnelID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TunnelID is the id number of the tunnel this status belongs to.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"localNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalNode is the name (in the clabernetes topology) of the local node for this side of the tunnel.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"localInterface": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalInterface is the local termination of this tunnel.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is the state of the tunnel, \"created\" once the launcher has set the tunnel up, or \"failed\" if it could not do so.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"adminState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdminState is the administrative state the launcher last set on the link of this tunnel.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resolvedRemote": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResolvedRemote is the address the tunnel destination (service) resolved to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastError is the last error the launcher encountered setting up this tunnel, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the last time the launcher updated the state of this tunnel.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"tunnelID", "localNode", "localInterface", "state", "lastUpdateTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_ProbeConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProbeConfiguration holds information about how to probe a (containerlab) node in a Topology. If multiple probes are configured, all of them will be used and all must succeed in order to report healthy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"startupSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartupSeconds is the total amount of seconds to allow for the node to start. This defaults to ~13 minutes to hopefully account for slow to boot nodes. Note that there is also a 60 initial delay configured, so technically the default is ~14-15 minutes. Be careful with this delay as there must be time for c9s to (via whatever means) pull the image and load it into docker on the launcher and this can take a bit! Having this be bigger than you think you need is generally better since if the startup probe succeeds ever then the readiness probe takes over anyway.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"sshProbeConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SSHProbeConfiguration defines an SSH probe.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.SSHProbeConfiguration"),
+						},
+					},
+					"tcpProbeConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TCPProbeConfiguration defines a TCP probe.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.TCPProbeConfiguration"),
+						},
+					},
+					"gnmiProbeConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GNMIProbeConfiguration defines a gNMI probe.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.GNMIProbeConfiguration"),
+						},
+					},
+					"netconfProbeConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NETCONFProbeConfiguration defines a NETCONF probe.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.NETCONFProbeConfiguration"),
+						},
+					},
+					"httpProbeConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTPProbeConfiguration defines an HTTP(S) probe.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.HTTPProbeConfiguration"),
+						},
+					},
+					"execProbeConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExecProbeConfiguration defines an exec probe.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.ExecProbeConfiguration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.ExecProbeConfiguration", "github.com/srl-labs/clabernetes/apis/v1alpha1.GNMIProbeConfiguration", "github.com/srl-labs/clabernetes/apis/v1alpha1.HTTPProbeConfiguration", "github.com/srl-labs/clabernetes/apis/v1alpha1.NETCONFProbeConfiguration", "github.com/srl-labs/clabernetes/apis/v1alpha1.SSHProbeConfiguration", "github.com/srl-labs/clabernetes/apis/v1alpha1.TCPProbeConfiguration"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_ProbeResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProbeResult holds the result of a single status probe of a node as reported by its launcher.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"probe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Probe is the kind of the status probe this result belongs to.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"result": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result is the result of the last run of the probe, \"success\" or \"failure\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSuccessTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessTime is the last time the probe succeeded, if it ever did. While the probe keeps succeeding this is only refreshed every few minutes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"consecutiveFailures": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveFailures is the number of times in a row the probe has failed.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastError is the error of the last failed run of the probe, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastUpdateTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdateTime is the last time the launcher updated the result of this probe.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"probe", "result", "consecutiveFailures", "lastUpdateTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_ReconcileHashes(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Description: "ReconcileHashes holds hashes of the last recorded reconciliation -- these are used to know if things have changed between the last and current reconciliation.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"config": {
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is the last stored hash of the rendered config(s) -- that is, the map of \"sub topologies\" representing the overall Topology.Spec.Definition.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"exposedPorts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExposedPorts is the last stored hash of the exposed ports mapping for this Topology. Note that while we obviously care about the exposed ports on a *per node basis*, we don't need to track that here -- this is here strictly to track differences in the load balancer service -- the actual sub-topologies (or sub-configs) effectively track the expose port status per node.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"filesFromURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesFromURL is the hash of the last stored mapping of files from URL (to node mapping). Note that this is tracked on a *per node basis* because the URL of a file could be updated without any change to the actual config/topology (or sub-config/sub-topology); as such we need to explicitly track this per node to know when a node needs to be restarted such that the new URL is \"picked up\" by the node/launcher.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullSecrets is the hash of hte last stored image pull secrets for this Topology.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"config", "exposedPorts", "filesFromURL", "imagePullSecrets"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_RestartProgress(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestartProgress holds information about the progress of restarting nodes whose configuration has changed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pending": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Pending is the list of nodes waiting to be restarted, in the order they will be restarted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"restarting": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Restarting is the list of nodes of the current batch, that is, nodes that have been restarted but have not come back up yet.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"batchStartedAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BatchStartedAt is the time (RFC3339) the current batch was restarted at, this is the value of the restartedAt annotation set on the deployments of the nodes of the batch.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"restarted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Restarted is the number of nodes that have been restarted (and came back up) since the restarts started.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"restarted"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_RestartStrategy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RestartStrategy holds information about how nodes whose configuration has changed are restarted. Nodes are restarted in batches, the next batch is only restarted once all nodes of the current batch have come back up.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"policy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Config is the last stored hash of the rendered config(s) -- that is, the map of \"sub topologies\" representing the overall Topology.Spec.Definition.",
-							Default:     "",
+							Description: "Policy is the restart policy of the topology. With the \"Automatic\" policy (the default) nodes are restarted as soon as their configuration changes. With the \"Manual\" policy nodes needing a restart are only reported in the pending restarts of the topology status, the restarts are carried out once requested by listing the nodes in the \"clabernetes/restartNodes\" annotation of the topology. Note: omitempty because empty str does not satisfy enum of course.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"exposedPorts": {
+					"maxConcurrent": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExposedPorts is the last stored hash of the exposed ports mapping for this Topology. Note that while we obviously care about the exposed ports on a *per node basis*, we don't need to track that here -- this is here strictly to track differences in the load balancer service -- the actual sub-topologies (or sub-configs) effectively track the expose port status per node.",
-							Default:     "",
-							Type:        []string{"string"},
+							Description: "MaxConcurrent is the maximum number of nodes (or rather launchers, when using node grouping) that are restarted at the same time. When unset (or zero) all nodes needing a restart are restarted at once.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"waitForReady": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitForReady, when true, waits for the nodes of a batch to report ready before restarting the next batch, rather than only waiting for their launcher pods to be recreated.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"filesFromURL": {
+					"order": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "FilesFromURL is the hash of the last stored mapping of files from URL (to node mapping). Note that this is tracked on a *per node basis* because the URL of a file could be updated without any change to the actual config/topology (or sub-config/sub-topology); as such we need to explicitly track this per node to know when a node needs to be restarted such that the new URL is \"picked up\" by the node/launcher.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Description: "Order is the order in which nodes should be restarted. Nodes listed here are restarted first in the order they are listed, any other nodes are restarted afterward in alphabetical order. When using node grouping, list the primary node of the group.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: "",
@@ -1429,16 +2286,7 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_ReconcileHashes(ref common.Refere
 							},
 						},
 					},
-					"imagePullSecrets": {
-						SchemaProps: spec.SchemaProps{
-							Description: "ImagePullSecrets is the hash of hte last stored image pull secrets for this Topology.",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 				},
-				Required: []string{"config", "exposedPorts", "filesFromURL", "imagePullSecrets"},
 			},
 		},
 	}
@@ -1453,7 +2301,7 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_SSHProbeConfiguration(ref common.
 				Properties: map[string]spec.Schema{
 					"username": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Username is the username to use for auth.",
+							Description: "Username is the username to use for auth. If not set, the username is taken from the \"username\" key of the CredentialsSecret.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
@@ -1461,8 +2309,14 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_SSHProbeConfiguration(ref common.
 					},
 					"password": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Password is the password to use for auth.",
-							Default:     "",
+							Description: "Password is the plain text password to use for auth.\n\nDeprecated: plain text passwords end up in the Topology and the launcher Deployment, use CredentialsSecret instead. New or updated Topologies setting a password are rejected by the validating webhook, existing ones get no ssh probe and a warning event instead.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"credentialsSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CredentialsSecret is the name of a secret holding the credentials to use for auth. The secret *must be present in the namespace of this topology*, and may contain the keys \"username\", \"password\" and \"ssh-privatekey\" -- that is, the keys of the \"kubernetes.io/basic-auth\" and \"kubernetes.io/ssh-auth\" secret types. The keys are projected into the launcher as environment variables referencing the secret, so the credentials never show up in the Topology or the launcher Deployment.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1476,7 +2330,6 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_SSHProbeConfiguration(ref common.
 						},
 					},
 				},
-				Required: []string{"username", "password"},
 			},
 		},
 	}
@@ -1715,6 +2568,163 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_TopologyList(ref common.Reference
 	}
 }
 
+func schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TopologySnapshot is an object that represents a request to capture the running configuration of all nodes of a Topology. Each launcher of the referenced Topology saves the configuration of its node(s) and stores the saved configuration in a ConfigMap owned by the TopologySnapshot.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotSpec", "github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshotStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshotList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TopologySnapshotList is a list of TopologySnapshot objects.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshot"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.TopologySnapshot", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshotSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TopologySnapshotSpec is the spec for a TopologySnapshot resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"topologyName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologyName is the name of the Topology (in the namespace of the TopologySnapshot) to capture the node configurations of.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"topologyName"},
+			},
+		},
+	}
+}
+
+func schema_srl_labs_clabernetes_apis_v1alpha1_TopologySnapshotStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TopologySnapshotStatus is the status for a TopologySnapshot resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nodes holds the result of the configuration capture of each node as reported by the launcher pods. The mapping is nodeName (i.e. srl1) -> snapshot result for that node.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.NodeSnapshotStatus"),
+									},
+								},
+							},
+						},
+					},
+					"complete": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Complete indicates that every node of the referenced Topology has reported the result of its configuration capture.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"complete"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.NodeSnapshotStatus"},
+	}
+}
+
 func schema_srl_labs_clabernetes_apis_v1alpha1_TopologySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1767,17 +2777,69 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_TopologySpec(ref common.Reference
 					},
 					"connectivity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Connectivity defines the type of connectivity to use between nodes in the topology. The default behavior is to use vxlan tunnels.",
+							Description: "Connectivity defines the type of connectivity to use between nodes in the topology. The default behavior is to use vxlan tunnels, alternatively geneve tunnels can be used -- in either case the tunnels are terminated in the launcher pods. For clusters that do not pass encapsulated udp traffic between pods there is also the \"slurpeeth\" flavor that carries frames over tcp streams between the launcher pods. Finally, on clusters running multus, the \"multus\" flavor carries links over multus network attachments (see the Multus field) instead of tunnels between the launcher pods.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"multus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Multus holds the settings of the \"multus\" connectivity flavor, it is ignored for any other connectivity flavor.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.Multus"),
+						},
+					},
+					"linkImpairments": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "LinkImpairments holds network impairment settings (delay, jitter, loss, rate) for links in the topology. Impairments are applied (via netem) by the launchers on both sides of links that span launchers, that is, links that are carried via the connectivity tunnels -- links within a launcher cannot be impaired.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.LinkImpairment"),
+									},
+								},
+							},
+						},
+					},
+					"linkAdminStates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "LinkAdminStates sets the administrative state of links in the topology, so links can be taken down (and brought back up) at runtime without touching the definition, and therefore without restarting any nodes. Like impairments, admin states apply to links that span launchers and are acted upon by the launchers on both sides of the link.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.LinkAdminState"),
+									},
+								},
+							},
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paused scales all node deployments of the topology down to zero replicas while leaving every other resource (config, connectivity, services, persistent volume claims and the allocated tunnel ids) in place. Setting this back to false scales the deployments back up, and the nodes come back exactly as they were -- with persistence enabled this includes the contents of the nodes' lab directories.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"definition", "naming"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/srl-labs/clabernetes/apis/v1alpha1.Definition", "github.com/srl-labs/clabernetes/apis/v1alpha1.Deployment", "github.com/srl-labs/clabernetes/apis/v1alpha1.Expose", "github.com/srl-labs/clabernetes/apis/v1alpha1.ImagePull", "github.com/srl-labs/clabernetes/apis/v1alpha1.StatusProbes"},
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.Definition", "github.com/srl-labs/clabernetes/apis/v1alpha1.Deployment", "github.com/srl-labs/clabernetes/apis/v1alpha1.Expose", "github.com/srl-labs/clabernetes/apis/v1alpha1.ImagePull", "github.com/srl-labs/clabernetes/apis/v1alpha1.LinkAdminState", "github.com/srl-labs/clabernetes/apis/v1alpha1.LinkImpairment", "github.com/srl-labs/clabernetes/apis/v1alpha1.Multus", "github.com/srl-labs/clabernetes/apis/v1alpha1.StatusProbes"},
 	}
 }
 
@@ -1856,6 +2918,27 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_TopologyStatus(ref common.Referen
 							},
 						},
 					},
+					"nodeProbeResults": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeProbeResults holds the results of the status probes of each node as reported by the launchers. The mapping is nodeName (i.e. srl1) -> results of the probes of that node. Only nodes that have status probes configured (and a running launcher) show up here.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type: []string{"array"},
+										Items: &spec.SchemaOrArray{
+											Schema: &spec.Schema{
+												SchemaProps: spec.SchemaProps{
+													Ref: ref("github.com/srl-labs/clabernetes/apis/v1alpha1.ProbeResult"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 					"topologyReady": {
 						SchemaProps: spec.SchemaProps{
 							Description: "TopologyReady indicates if all nodes in the topology have reported ready. This is duplicated from the conditions so we can easily snag it for print columns!",
@@ -1864,6 +2947,27 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_TopologyStatus(ref common.Referen
 							Format:      "",
 						},
 					},
+					"restartProgress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartProgress holds the progress of restarting nodes after configuration changes, this is only set while there are nodes waiting to be, or being, restarted.",
+							Ref:         ref("github.com/srl-labs/clabernetes/apis/v1alpha1.RestartProgress"),
+						},
+					},
+					"pendingRestarts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PendingRestarts holds the nodes that need to be restarted due to configuration changes but that have not been restarted yet because the restart policy of the topology is \"Manual\". The mapping is nodeName (i.e. srl1) -> pending restart for that node.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/srl-labs/clabernetes/apis/v1alpha1.PendingRestart"),
+									},
+								},
+							},
+						},
+					},
 					"conditions": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -1888,6 +2992,6 @@ func schema_srl_labs_clabernetes_apis_v1alpha1_TopologyStatus(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/srl-labs/clabernetes/apis/v1alpha1.ExposedPorts", "github.com/srl-labs/clabernetes/apis/v1alpha1.ReconcileHashes", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+			"github.com/srl-labs/clabernetes/apis/v1alpha1.ExposedPorts", "github.com/srl-labs/clabernetes/apis/v1alpha1.PendingRestart", "github.com/srl-labs/clabernetes/apis/v1alpha1.ProbeResult", "github.com/srl-labs/clabernetes/apis/v1alpha1.ReconcileHashes", "github.com/srl-labs/clabernetes/apis/v1alpha1.RestartProgress", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
 	}
 }
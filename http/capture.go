@@ -0,0 +1,365 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	k8sauthenticationv1 "k8s.io/api/authentication/v1"
+	k8sauthorizationv1 "k8s.io/api/authorization/v1"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	captureRoute      = "GET /capture/{namespace}/{topology}/{node}/{interface}"
+	captureBufferSize = 64 * 1024
+)
+
+// captureHandler streams a (pcap) packet capture of a node interface from the launcher running the
+// node back to the caller. The caller must be allowed to exec into pods in the namespace of the
+// topology, which is what they would need to run the capture "by hand" anyway -- this is checked
+// before looking up anything so callers cannot find out about topologies they have no access to.
+// The launcher is reached over tls with the client certificate of the manager, the caller's token
+// never leaves the manager. An optional "filter" query parameter holds a bpf filter for the
+// capture.
+func (m *manager) captureHandler(w http.ResponseWriter, r *http.Request) {
+	m.logRequest(r)
+
+	namespace := r.PathValue("namespace")
+	topologyName := r.PathValue("topology")
+	nodeName := r.PathValue("node")
+
+	statusCode, err := AuthorizeCapture(r, m.kubeClient, namespace)
+	if err != nil {
+		m.logger.Infof("rejecting capture request from %q, error: %s", r.RemoteAddr, err)
+
+		http.Error(w, err.Error(), statusCode)
+
+		return
+	}
+
+	topology := &clabernetesapisv1alpha1.Topology{}
+
+	err = m.client.Get(
+		r.Context(),
+		apimachinerytypes.NamespacedName{
+			Namespace: namespace,
+			Name:      topologyName,
+		},
+		topology,
+	)
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("topology %q not found", topologyName), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	launcherName, err := resolveNodeLauncher(topology, nodeName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	podAddr, err := m.resolveLauncherPodAddr(r, topology, launcherName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+		return
+	}
+
+	query := url.Values{}
+	query.Set("node", nodeName)
+	query.Set("interface", r.PathValue("interface"))
+
+	filter := r.URL.Query().Get("filter")
+	if filter != "" {
+		query.Set("filter", filter)
+	}
+
+	launcherRequest, err := http.NewRequestWithContext(
+		r.Context(),
+		http.MethodGet,
+		fmt.Sprintf("https://%s/capture?%s", podAddr, query.Encode()),
+		http.NoBody,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	launcherClient, err := launcherCaptureClient(topology)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	launcherResponse, err := launcherClient.Do(launcherRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+
+		return
+	}
+
+	defer func() {
+		_ = launcherResponse.Body.Close()
+	}()
+
+	// captures run for as long as the caller wants, so the server write timeout cannot apply here
+	responseController := http.NewResponseController(w)
+
+	err = responseController.SetWriteDeadline(time.Time{})
+	if err != nil {
+		m.logger.Warnf("failed clearing write deadline for capture, error: %s", err)
+	}
+
+	w.Header().Set("Content-Type", launcherResponse.Header.Get("Content-Type"))
+	w.WriteHeader(launcherResponse.StatusCode)
+
+	buf := make([]byte, captureBufferSize)
+
+	for {
+		n, readErr := launcherResponse.Body.Read(buf)
+		if n > 0 {
+			_, err = w.Write(buf[:n])
+			if err != nil {
+				return
+			}
+
+			_ = responseController.Flush()
+		}
+
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) && r.Context().Err() == nil {
+				m.logger.Warnf("capture stream from launcher failed, error: %s", readErr)
+			}
+
+			return
+		}
+	}
+}
+
+// AuthorizeCapture checks that the bearer token of the request belongs to someone allowed to exec
+// into pods in the given namespace, returns the http status code to use if the caller is not
+// authorized.
+func AuthorizeCapture(
+	r *http.Request,
+	kubeClient kubernetes.Interface,
+	namespace string,
+) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized, fmt.Errorf(
+			"%w: missing bearer token", claberneteserrors.ErrCapture,
+		)
+	}
+
+	tokenReview, err := kubeClient.AuthenticationV1().TokenReviews().Create(
+		r.Context(),
+		&k8sauthenticationv1.TokenReview{
+			Spec: k8sauthenticationv1.TokenReviewSpec{
+				Token: token,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf(
+			"%w: invalid bearer token", claberneteserrors.ErrCapture,
+		)
+	}
+
+	userInfo := tokenReview.Status.User
+
+	extra := make(map[string]k8sauthorizationv1.ExtraValue, len(userInfo.Extra))
+
+	for key, value := range userInfo.Extra {
+		extra[key] = k8sauthorizationv1.ExtraValue(value)
+	}
+
+	accessReview, err := kubeClient.AuthorizationV1().SubjectAccessReviews().Create(
+		r.Context(),
+		&k8sauthorizationv1.SubjectAccessReview{
+			Spec: k8sauthorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &k8sauthorizationv1.ResourceAttributes{
+					Namespace:   namespace,
+					Verb:        "create",
+					Resource:    "pods",
+					Subresource: "exec",
+				},
+				User:   userInfo.Username,
+				Groups: userInfo.Groups,
+				UID:    userInfo.UID,
+				Extra:  extra,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf(
+			"%w: not allowed to exec into pods in namespace %q",
+			claberneteserrors.ErrCapture,
+			namespace,
+		)
+	}
+
+	return http.StatusOK, nil
+}
+
+// launcherCaptureClient returns the http client to reach the capture servers of the launchers of
+// the given topology with -- they only accept clients presenting a certificate signed by our
+// certificate authority, so we present the client certificate of the manager. The launchers
+// present a server certificate our authority issued for their topology, we are reaching them by
+// pod address so we verify that certificate against the topology rather than the address.
+func launcherCaptureClient(topology *clabernetesapisv1alpha1.Topology) (*http.Client, error) {
+	clientCert, err := tls.LoadX509KeyPair(
+		fmt.Sprintf(
+			"%s/%s/tls.crt",
+			clabernetesconstants.CertificateDirectory,
+			clabernetesconstants.ClientCertificateSubDir,
+		),
+		fmt.Sprintf(
+			"%s/%s/tls.key",
+			clabernetesconstants.CertificateDirectory,
+			clabernetesconstants.ClientCertificateSubDir,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	caPEM, err := os.ReadFile(
+		fmt.Sprintf(
+			"%s/%s/ca.crt",
+			clabernetesconstants.CertificateDirectory,
+			clabernetesconstants.CertificateAuthoritySubDir,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCAs := x509.NewCertPool()
+
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf(
+			"%w: failed parsing certificate authority", claberneteserrors.ErrCapture,
+		)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			// captures are long-lived streams, no point in keeping connections around after
+			DisableKeepAlives: true,
+			TLSClientConfig: &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{clientCert},
+				RootCAs:      rootCAs,
+				ServerName: clabernetesutil.LauncherCaptureServerName(
+					topology.Namespace,
+					topology.Name,
+				),
+			},
+		},
+	}, nil
+}
+
+// resolveNodeLauncher returns the name of the launcher running the given node -- with node
+// grouping this is not necessarily the node name, so we look for the node in the per launcher
+// containerlab configs of the topology.
+func resolveNodeLauncher(
+	topology *clabernetesapisv1alpha1.Topology,
+	nodeName string,
+) (string, error) {
+	for launcherName, rawConfig := range topology.Status.Configs {
+		config, err := clabernetesutilcontainerlab.LoadContainerlabConfig(rawConfig)
+		if err != nil || config.Topology == nil {
+			continue
+		}
+
+		_, ok := config.Topology.Nodes[nodeName]
+		if ok {
+			return launcherName, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"%w: node %q not found in topology %q",
+		claberneteserrors.ErrCapture,
+		nodeName,
+		topology.Name,
+	)
+}
+
+// resolveLauncherPodAddr returns the address of the capture server of the running pod of the
+// given launcher.
+func (m *manager) resolveLauncherPodAddr(
+	r *http.Request,
+	topology *clabernetesapisv1alpha1.Topology,
+	launcherName string,
+) (string, error) {
+	pods, err := m.kubeClient.CoreV1().Pods(topology.Namespace).List(
+		r.Context(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf(
+				"%s=%s,%s=%s",
+				clabernetesconstants.LabelTopologyOwner,
+				topology.Name,
+				clabernetesconstants.LabelTopologyNode,
+				launcherName,
+			),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+
+		if pod.Status.Phase != k8scorev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		return net.JoinHostPort(
+			pod.Status.PodIP,
+			strconv.Itoa(clabernetesconstants.LauncherCapturePort),
+		), nil
+	}
+
+	return "", fmt.Errorf(
+		"%w: no running pod for launcher %q", claberneteserrors.ErrCapture, launcherName,
+	)
+}
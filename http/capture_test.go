@@ -0,0 +1,144 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	claberneteshttp "github.com/srl-labs/clabernetes/http"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	k8sauthenticationv1 "k8s.io/api/authentication/v1"
+	k8sauthorizationv1 "k8s.io/api/authorization/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+func TestAuthorizeCapture(t *testing.T) {
+	cases := []struct {
+		name               string
+		authorization      string
+		authenticated      bool
+		allowed            bool
+		expectedStatusCode int
+		expectErr          bool
+	}{
+		{
+			name:               "missing-token",
+			authorization:      "",
+			authenticated:      true,
+			allowed:            true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectErr:          true,
+		},
+		{
+			name:               "not-a-bearer-token",
+			authorization:      "Basic dXNlcjpwYXNzd29yZA==",
+			authenticated:      true,
+			allowed:            true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectErr:          true,
+		},
+		{
+			name:               "token-review-denied",
+			authorization:      "Bearer invalid",
+			authenticated:      false,
+			allowed:            true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectErr:          true,
+		},
+		{
+			name:               "subject-access-review-denied",
+			authorization:      "Bearer valid",
+			authenticated:      true,
+			allowed:            false,
+			expectedStatusCode: http.StatusForbidden,
+			expectErr:          true,
+		},
+		{
+			name:               "allowed",
+			authorization:      "Bearer valid",
+			authenticated:      true,
+			allowed:            true,
+			expectedStatusCode: http.StatusOK,
+			expectErr:          false,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				kubeClient := fake.NewClientset()
+
+				var reviewedNamespace string
+
+				kubeClient.PrependReactor(
+					"create",
+					"tokenreviews",
+					func(
+						action clientgotesting.Action,
+					) (bool, apimachineryruntime.Object, error) {
+						tokenReview, _ := action.(clientgotesting.CreateAction).
+							GetObject().(*k8sauthenticationv1.TokenReview)
+
+						tokenReview.Status.Authenticated = testCase.authenticated
+						tokenReview.Status.User.Username = "someone"
+
+						return true, tokenReview, nil
+					},
+				)
+
+				kubeClient.PrependReactor(
+					"create",
+					"subjectaccessreviews",
+					func(
+						action clientgotesting.Action,
+					) (bool, apimachineryruntime.Object, error) {
+						accessReview, _ := action.(clientgotesting.CreateAction).
+							GetObject().(*k8sauthorizationv1.SubjectAccessReview)
+
+						reviewedNamespace = accessReview.Spec.ResourceAttributes.Namespace
+
+						accessReview.Status.Allowed = testCase.allowed
+
+						return true, accessReview, nil
+					},
+				)
+
+				r := httptest.NewRequest(
+					http.MethodGet,
+					"/capture/clabernetes/topo-1/srl1/e1-1",
+					http.NoBody,
+				)
+
+				if testCase.authorization != "" {
+					r.Header.Set("Authorization", testCase.authorization)
+				}
+
+				actualStatusCode, err := claberneteshttp.AuthorizeCapture(
+					r,
+					kubeClient,
+					"clabernetes",
+				)
+
+				if (err != nil) != testCase.expectErr {
+					t.Fatalf("expected error %t, got error: %v", testCase.expectErr, err)
+				}
+
+				if actualStatusCode != testCase.expectedStatusCode {
+					clabernetestesthelper.FailOutput(
+						t,
+						actualStatusCode,
+						testCase.expectedStatusCode,
+					)
+				}
+
+				if actualStatusCode == http.StatusOK && reviewedNamespace != "clabernetes" {
+					clabernetestesthelper.FailOutput(t, reviewedNamespace, "clabernetes")
+				}
+			})
+	}
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	clabernetesmetrics "github.com/srl-labs/clabernetes/metrics"
+)
+
+const (
+	metricsRoute = "/metrics"
+)
+
+// registerMetrics registers the clabernetes controller metrics and serves them (along with the
+// controller-runtime metrics) on the metrics route of the given mux.
+func (m *manager) registerMetrics(mux *http.ServeMux) {
+	clabernetesmetrics.Register(m.client)
+
+	mux.Handle(
+		metricsRoute,
+		clabernetesmetrics.Handler(),
+	)
+}
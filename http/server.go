@@ -10,9 +10,11 @@ import (
 	"time"
 
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
 	clabernetesmanagertypes "github.com/srl-labs/clabernetes/manager/types"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -45,12 +47,14 @@ func InitManager(
 		)
 
 		m := &manager{
-			ctx:           c.GetContext(),
-			ctxCancel:     c.GetContextCancel(),
-			logger:        logger,
-			managerReadyF: c.IsReady,
-			client:        c.GetCtrlRuntimeClient(),
-			kubeClient:    c.GetKubeClient(),
+			ctx:                   c.GetContext(),
+			ctxCancel:             c.GetContextCancel(),
+			logger:                logger,
+			managerReadyF:         c.IsReady,
+			client:                c.GetCtrlRuntimeClient(),
+			kubeClient:            c.GetKubeClient(),
+			kubeClabernetesClient: c.GetKubeClabernetesClient(),
+			scheme:                c.GetScheme(),
 		}
 
 		managerInstance = m
@@ -78,15 +82,17 @@ type Manager interface {
 }
 
 type manager struct {
-	ctx           context.Context
-	ctxCancel     context.CancelFunc
-	logger        claberneteslogging.Instance
-	managerReadyF func() bool
-	returnedReady bool
-	client        ctrlruntimeclient.Client
-	kubeClient    *kubernetes.Clientset
-	server        *http.Server
-	stopping      bool
+	ctx                   context.Context
+	ctxCancel             context.CancelFunc
+	logger                claberneteslogging.Instance
+	managerReadyF         func() bool
+	returnedReady         bool
+	client                ctrlruntimeclient.Client
+	kubeClient            *kubernetes.Clientset
+	kubeClabernetesClient *clabernetesgeneratedclientset.Clientset
+	scheme                *apimachineryruntime.Scheme
+	server                *http.Server
+	stopping              bool
 }
 
 func (m *manager) Start() {
@@ -98,6 +104,15 @@ func (m *manager) Start() {
 		m.aliveHandler,
 	)
 
+	mux.HandleFunc(
+		captureRoute,
+		m.captureHandler,
+	)
+
+	m.registerWebhooks(mux)
+
+	m.registerMetrics(mux)
+
 	m.server = &http.Server{
 		BaseContext: func(_ net.Listener) context.Context {
 			return m.ctx
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteswebhooks "github.com/srl-labs/clabernetes/webhooks"
+	ctrlruntimeadmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// registerWebhooks registers the Topology and Config admission webhook handlers on the given mux.
+func (m *manager) registerWebhooks(mux *http.ServeMux) {
+	mux.Handle(
+		clabernetesconstants.WebhookMutateTopologyPath,
+		ctrlruntimeadmission.WithCustomDefaulter(
+			m.scheme,
+			&clabernetesapisv1alpha1.Topology{},
+			claberneteswebhooks.NewTopologyDefaulter(),
+		),
+	)
+
+	mux.Handle(
+		clabernetesconstants.WebhookValidateTopologyPath,
+		ctrlruntimeadmission.WithCustomValidator(
+			m.scheme,
+			&clabernetesapisv1alpha1.Topology{},
+			claberneteswebhooks.NewTopologyValidator(
+				m.kubeClient,
+				m.kubeClabernetesClient,
+			),
+		),
+	)
+
+	mux.Handle(
+		clabernetesconstants.WebhookMutateConfigPath,
+		ctrlruntimeadmission.WithCustomDefaulter(
+			m.scheme,
+			&clabernetesapisv1alpha1.Config{},
+			claberneteswebhooks.NewConfigDefaulter(),
+		),
+	)
+
+	mux.Handle(
+		clabernetesconstants.WebhookValidateConfigPath,
+		ctrlruntimeadmission.WithCustomValidator(
+			m.scheme,
+			&clabernetesapisv1alpha1.Config{},
+			claberneteswebhooks.NewConfigValidator(),
+		),
+	)
+}
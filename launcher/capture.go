@@ -0,0 +1,236 @@
+package launcher
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	captureRoute             = "GET /capture"
+	captureReadHeaderTimeout = 5 * time.Second
+	pcapContentType          = "application/vnd.tcpdump.pcap"
+)
+
+// serveCaptures runs the packet capture server -- it streams (pcap) captures of node interfaces to
+// the manager, which authorizes the actual requester (who must be allowed to exec into this
+// launcher pod, which is what they would need to do to run the capture "by hand" anyway) and
+// proxies the capture through. The server only talks tls and only accepts clients presenting a
+// certificate signed by the certificate authority of the manager.
+func (c *clabernetes) serveCaptures() {
+	tlsConfig, err := captureTLSConfig()
+	if err != nil {
+		c.logger.Warnf("not serving packet captures, error: %s", err)
+
+		return
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(captureRoute, c.captureHandler)
+
+	server := &http.Server{
+		BaseContext: func(_ net.Listener) context.Context {
+			return c.ctx
+		},
+		Addr:              fmt.Sprintf(":%d", clabernetesconstants.LauncherCapturePort),
+		Handler:           mux,
+		ReadHeaderTimeout: captureReadHeaderTimeout,
+		TLSConfig:         tlsConfig,
+	}
+
+	go func() {
+		<-c.ctx.Done()
+
+		_ = server.Close()
+	}()
+
+	c.logger.Debugf(
+		"starting packet capture server on port %d", clabernetesconstants.LauncherCapturePort,
+	)
+
+	err = server.ListenAndServeTLS("", "")
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		c.logger.Warnf("packet capture server has failed, error: %s", err)
+	}
+}
+
+// captureTLSConfig returns the tls config of the packet capture server -- clients must present a
+// certificate signed by the certificate authority of the manager. The server certificate is issued
+// by the manager too (and mounted from a Secret owned by our topology) so the manager can verify
+// us in turn.
+func captureTLSConfig() (*tls.Config, error) {
+	captureCA := os.Getenv(clabernetesconstants.LauncherCaptureCA)
+	if captureCA == "" {
+		return nil, fmt.Errorf(
+			"%w: no certificate authority to verify clients with", claberneteserrors.ErrCapture,
+		)
+	}
+
+	clientCAs := x509.NewCertPool()
+
+	if !clientCAs.AppendCertsFromPEM([]byte(captureCA)) {
+		return nil, fmt.Errorf(
+			"%w: failed parsing certificate authority", claberneteserrors.ErrCapture,
+		)
+	}
+
+	serverCert, err := tls.LoadX509KeyPair(
+		fmt.Sprintf("%s/tls.crt", clabernetesconstants.LauncherCaptureCertificateDirectory),
+		fmt.Sprintf("%s/tls.key", clabernetesconstants.LauncherCaptureCertificateDirectory),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}, nil
+}
+
+func (c *clabernetes) captureHandler(w http.ResponseWriter, r *http.Request) {
+	nodeName := r.URL.Query().Get("node")
+	interfaceName := r.URL.Query().Get("interface")
+	filter := r.URL.Query().Get("filter")
+
+	c.logger.Debugf(
+		"received capture request for node %q interface %q from %q",
+		nodeName,
+		interfaceName,
+		r.RemoteAddr,
+	)
+
+	if nodeName == "" || interfaceName == "" {
+		http.Error(w, "node and interface must be provided", http.StatusBadRequest)
+
+		return
+	}
+
+	cmd, statusCode, err := c.captureCommand(r, nodeName, interfaceName, filter)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode)
+
+		return
+	}
+
+	c.logger.Debugf("using following args for packet capture '%s'", cmd.Args)
+
+	stderr := &strings.Builder{}
+
+	writer := &captureWriter{w: w}
+
+	cmd.Stdout = writer
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+
+	if !writer.wroteHeader {
+		// tcpdump writes the pcap header as soon as it is capturing, so if we never wrote
+		// anything it failed to start (bad interface or filter most likely)
+		http.Error(
+			w,
+			fmt.Sprintf("failed starting capture, error: %s", strings.TrimSpace(stderr.String())),
+			http.StatusBadRequest,
+		)
+
+		return
+	}
+
+	if err != nil && r.Context().Err() == nil {
+		c.logger.Warnf(
+			"capture of node %q interface %q ended with error: %s",
+			nodeName,
+			interfaceName,
+			err,
+		)
+	}
+
+	c.logger.Debugf("capture of node %q interface %q ended", nodeName, interfaceName)
+}
+
+// captureCommand returns the tcpdump command capturing on the given node interface. Normally we
+// enter the network namespace of the node container to run it, in native mode the node shares the
+// network namespace of our pod so we can just run tcpdump directly.
+func (c *clabernetes) captureCommand(
+	r *http.Request,
+	nodeName, interfaceName, filter string,
+) (*exec.Cmd, int, error) {
+	tcpdumpArgs := []string{"-U", "-n", "-i", interfaceName, "-w", "-"}
+
+	if filter != "" {
+		// the filter comes from the caller, make sure tcpdump never takes it for options
+		tcpdumpArgs = append(tcpdumpArgs, "--", filter)
+	}
+
+	if c.nativeMode {
+		if nodeName != c.nodeName {
+			return nil, http.StatusNotFound, fmt.Errorf(
+				"%w: node %q not found", claberneteserrors.ErrCapture, nodeName,
+			)
+		}
+
+		return exec.CommandContext(r.Context(), "tcpdump", tcpdumpArgs...), http.StatusOK, nil
+	}
+
+	// node containers are named exactly as the node since launcher configs have no prefix
+	containerID, err := getContainerIDForNodeName(
+		fmt.Sprintf("^/?%s$", regexp.QuoteMeta(nodeName)),
+	)
+	if err != nil || containerID == "" {
+		return nil, http.StatusNotFound, fmt.Errorf(
+			"%w: node %q not found", claberneteserrors.ErrCapture, nodeName,
+		)
+	}
+
+	pid, err := getContainerPID(containerID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return exec.CommandContext( //nolint:gosec
+		r.Context(),
+		"nsenter",
+		slices.Concat([]string{"--target", pid, "--net", "tcpdump"}, tcpdumpArgs)...,
+	), http.StatusOK, nil
+}
+
+// captureWriter writes the capture to the response, flushing after every write so the capture is
+// streamed to the client as it happens.
+type captureWriter struct {
+	w           http.ResponseWriter
+	wroteHeader bool
+}
+
+func (cw *captureWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.w.Header().Set("Content-Type", pcapContentType)
+		cw.w.WriteHeader(http.StatusOK)
+
+		cw.wroteHeader = true
+	}
+
+	n, err := cw.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	err = http.NewResponseController(cw.w).Flush()
+
+	return n, err
+}
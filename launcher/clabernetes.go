@@ -2,18 +2,18 @@ package launcher
 
 import (
 	"context"
-	"fmt"
 	"math/rand"
-	"net"
 	"os"
 	"strings"
 	"time"
 
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
+	claberneteslauncherconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
 	clabernetesutil "github.com/srl-labs/clabernetes/util"
-	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -22,6 +22,7 @@ const (
 	statusProbeCheckInterval = 30 * time.Second
 	statusProbeCheckTimeout  = 5 * time.Second
 	clientDefaultTimeout     = time.Minute
+	containerlabSaveTimeout  = 20 * time.Second
 	defaultSSHPort           = 22
 )
 
@@ -33,33 +34,51 @@ func StartClabernetes() {
 
 	rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
 
-	claberneteslogging.InitManager()
+	formatter, _ := claberneteslogging.GetFormatters(
+		clabernetesutil.GetEnvStrOrDefault(
+			clabernetesconstants.LauncherLoggerFormatEnv,
+			clabernetesconstants.LogFormatText,
+		),
+	)
+
+	claberneteslogging.InitManager(claberneteslogging.WithFormatter(formatter))
 
 	logManager := claberneteslogging.GetManager()
 
+	// every launcher logger carries the topology, namespace and node of this launcher so the logs
+	// of all launchers can be told apart once they land in a log pipeline
+	launcherLogFields := map[string]string{
+		clabernetesconstants.LogFieldTopology: os.Getenv(
+			clabernetesconstants.LauncherTopologyNameEnv,
+		),
+		clabernetesconstants.LogFieldNamespace: os.Getenv(clabernetesconstants.PodNamespaceEnv),
+		clabernetesconstants.LogFieldNode:      os.Getenv(clabernetesconstants.LauncherNodeNameEnv),
+	}
+
 	clabernetesLogger := logManager.MustRegisterAndGetLogger(
 		clabernetesconstants.Clabernetes,
 		clabernetesutil.GetEnvStrOrDefault(
 			clabernetesconstants.LauncherLoggerLevelEnv,
 			clabernetesconstants.Info,
 		),
-	)
+	).WithFields(launcherLogFields)
 
 	containerlabLogger := logManager.MustRegisterAndGetLogger(
 		"containerlab",
 		clabernetesconstants.Info,
-	)
+	).WithFields(launcherLogFields)
 
 	nodeLogger := logManager.MustRegisterAndGetLogger(
 		"node",
 		clabernetesconstants.Info,
-	)
+	).WithFields(launcherLogFields)
 
 	ctx, cancel := clabernetesutil.SignalHandledContext(clabernetesLogger.Criticalf)
 
 	clabernetesInstance = &clabernetes{
 		ctx:                   ctx,
 		cancel:                cancel,
+		kubeClient:            mustNewKubeClient(clabernetesLogger),
 		kubeClabernetesClient: mustNewKubeClabernetesClient(clabernetesLogger),
 		appName: clabernetesutil.GetEnvStrOrDefault(
 			clabernetesconstants.AppNameEnv,
@@ -71,6 +90,10 @@ func StartClabernetes() {
 		nodeLogger:           nodeLogger,
 		imageName:            os.Getenv(clabernetesconstants.LauncherNodeImageEnv),
 		imagePullThroughMode: os.Getenv(clabernetesconstants.LauncherImagePullThroughModeEnv),
+		nativeMode: strings.EqualFold(
+			os.Getenv(clabernetesconstants.LauncherNativeModeEnv),
+			clabernetesconstants.True,
+		),
 	}
 
 	clabernetesInstance.startup()
@@ -82,6 +105,7 @@ type clabernetes struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	kubeClient            *kubernetes.Clientset
 	kubeClabernetesClient *clabernetesgeneratedclientset.Clientset
 
 	appName  string
@@ -94,12 +118,24 @@ type clabernetes struct {
 	imageName            string
 	imagePullThroughMode string
 
+	// nativeMode is true when the node runs directly as a container in our pod rather than via
+	// containerlab, in which case we are only a sidecar plumbing interfaces and tunnels
+	nativeMode bool
+	// nativeModeHostInterfaces holds the names of the "host" ends of the veth pairs we created for
+	// the node interfaces in native mode
+	nativeModeHostInterfaces []string
+
 	// containerIDs holds *all* ids of containers running --in theory we could have other side-car
 	// type stuff running so just catching all them here so we know if/when things fail
 	containerIDs []string
 	// meanwhile nodeContainerID is the container id of hte specific node this launcher represents
-	// -- meaning the single node from the original topology this launcher is representing
+	// -- meaning the single node (or the primary node of a node group) from the original topology
+	// this launcher is representing
 	nodeContainerID string
+
+	// connectivityManager is the connectivity manager that handles the tunnels of this launcher,
+	// we hang on to it so we can report tunnel interface counters
+	connectivityManager claberneteslauncherconnectivity.Manager
 }
 
 func (c *clabernetes) startup() {
@@ -107,23 +143,69 @@ func (c *clabernetes) startup() {
 
 	c.logger.Debugf("clabernetes version %s", clabernetesconstants.Version)
 
-	c.containerlabVersion()
-	c.setup()
-	c.image()
-	c.launch()
-	c.connectivity()
+	if c.nativeMode {
+		c.nativeModeStartup()
+	} else {
+		c.containerlabVersion()
+		c.setup()
+		c.image()
+		c.waitForNodes()
+		c.launch()
+		c.connectivity()
+
+		go c.imageCleanup()
+		go c.watchContainers()
+	}
 
-	go c.imageCleanup()
 	go c.runProbes()
-	go c.watchContainers()
+	go c.serveCaptures()
+	go c.serveMetrics()
+	go c.watchSnapshots()
 
 	c.logger.Info("running for forever or until sigint...")
 
 	<-c.ctx.Done()
 
+	if !c.nativeMode {
+		// nothing to save in native mode, there is no containerlab to save anything with
+		c.teardown()
+	}
+
 	claberneteslogging.GetManager().Flush()
 }
 
+// nativeModeStartup starts the launcher as a native mode sidecar -- there is no docker and no
+// containerlab, the node container is started by kubernetes once we have plumbed its interfaces
+// and set up the tunnels to the other nodes.
+func (c *clabernetes) nativeModeStartup() {
+	c.logger.Info("running in native mode, skipping docker and containerlab setup...")
+
+	c.waitForNodes()
+	c.plumbNativeModeInterfaces()
+	c.connectivity()
+	c.nativeModeReady()
+}
+
+// teardown gives containerlab the chance to save the running config of the node(s) before the
+// launcher exits -- when a topology is deleted the controller scales the launcher deployments down
+// which lands us here via sigterm. Kubernetes only gives us the termination grace period (default
+// of 30s) so we cap the save at containerlabSaveTimeout.
+func (c *clabernetes) teardown() {
+	c.logger.Info("saving node configuration(s) before exiting...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), containerlabSaveTimeout)
+	defer cancel()
+
+	err := c.runContainerlabSave(ctx)
+	if err != nil {
+		c.logger.Warnf("failed saving node configuration(s), err: %s", err)
+
+		return
+	}
+
+	c.logger.Info("node configuration(s) saved")
+}
+
 func (c *clabernetes) containerlabVersion() {
 	c.logger.Debug("checking containerlab version settings...")
 
@@ -241,38 +323,9 @@ func (c *clabernetes) launch() {
 func (c *clabernetes) runProbes() {
 	c.logger.Debug("starting status probe(s) if configured...")
 
-	tcpProbePort := clabernetesutil.GetEnvIntOrDefault(clabernetesconstants.LauncherTCPProbePort, 0)
-
-	sshProbePort := clabernetesutil.GetEnvIntOrDefault(
-		clabernetesconstants.LauncherSSHProbePort,
-		defaultSSHPort,
-	)
-
-	sshProbeUsername := os.Getenv(clabernetesconstants.LauncherSSHProbeUsername)
-
-	sshProbePassword := os.Getenv(clabernetesconstants.LauncherSSHProbePassword)
-
-	var runTCPProbe bool
-
-	var runSSHProbe bool
-
-	if tcpProbePort != 0 {
-		c.logger.Debugf("will run tcp status probe to port %d", tcpProbePort)
-
-		runTCPProbe = true
-	}
+	probes := c.statusProbes()
 
-	if sshProbeUsername != "" && sshProbePassword != "" {
-		c.logger.Debugf(
-			"will run ssh status probe using username %s to port %d",
-			sshProbeUsername,
-			sshProbePort,
-		)
-
-		runSSHProbe = true
-	}
-
-	if !runTCPProbe && !runSSHProbe {
+	if len(probes) == 0 {
 		c.logger.Debug("no probes configured, skipping status probes...")
 
 		return
@@ -282,8 +335,22 @@ func (c *clabernetes) runProbes() {
 
 	ticker := time.NewTicker(statusProbeCheckInterval)
 
+	probeResults := make([]*clabernetesapisv1alpha1.ProbeResult, len(probes))
+
+	for idx, probe := range probes {
+		probeResults[idx] = &clabernetesapisv1alpha1.ProbeResult{
+			Probe: probe.name,
+		}
+	}
+
+	var lastProbeResultsReport time.Time
+
 	var nodeAddr string
 
+	if c.nativeMode {
+		nodeAddr = nativeModeNodeAddr
+	}
+
 	for range ticker.C {
 		if nodeAddr == "" {
 			var err error
@@ -300,29 +367,33 @@ func (c *clabernetes) runProbes() {
 			}
 		}
 
-		tcpProbeOk := true
-		sshProbeOk := true
+		probesOk := true
+
+		probeResultsChanged := false
 
-		if runTCPProbe {
-			dialer := net.Dialer{
-				Timeout: statusProbeCheckTimeout,
+		for idx, probe := range probes {
+			probeErr := probe.probe(nodeAddr)
+			if probeErr != nil {
+				c.logger.Debugf("%s status probe failed, error: %s", probe.name, probeErr)
+
+				probesOk = false
 			}
 
-			tcpConn, err := dialer.Dial("tcp", fmt.Sprintf("%s:%d", nodeAddr, tcpProbePort))
-			if err != nil {
-				tcpProbeOk = false
-			} else {
-				_ = tcpConn.Close()
+			if updateProbeResult(probeResults[idx], probeErr) {
+				probeResultsChanged = true
 			}
 		}
 
-		if runSSHProbe {
-			sshProbeOk = probeSSH(sshProbePort, nodeAddr, sshProbeUsername, sshProbePassword)
+		if probeResultsChanged ||
+			time.Since(lastProbeResultsReport) > probeResultsRefreshInterval {
+			c.reportProbeResults(c.nodeName, probeResults)
+
+			lastProbeResultsReport = time.Now()
 		}
 
 		var writeErr error
 
-		if tcpProbeOk && sshProbeOk {
+		if probesOk {
 			writeErr = os.WriteFile(
 				clabernetesconstants.NodeStatusFile,
 				[]byte(clabernetesconstants.NodeStatusHealthy),
@@ -349,40 +420,6 @@ func (c *clabernetes) runProbes() {
 	}
 }
 
-func probeSSH(port int, nodeAddr, username, password string) bool {
-	sshConfig := &ssh.ClientConfig{
-		User: username,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(password),
-			ssh.KeyboardInteractive(
-				func(_, _ string, questions []string, _ []bool) ([]string, error) {
-					answers := make([]string, len(questions))
-					for i := range answers {
-						answers[i] = password
-					}
-
-					return answers, nil
-				},
-			),
-		},
-		Timeout:         statusProbeCheckTimeout,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
-	}
-
-	conn, err := ssh.Dial(
-		"tcp",
-		fmt.Sprintf("%s:%d", nodeAddr, port),
-		sshConfig,
-	)
-	if err != nil {
-		return false
-	}
-
-	_ = conn.Close()
-
-	return true
-}
-
 func (c *clabernetes) watchContainers() {
 	if len(c.containerIDs) == 0 {
 		return
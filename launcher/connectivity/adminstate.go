@@ -0,0 +1,66 @@
+package connectivity
+
+import (
+	"os/exec"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+// applyAdminState brings the host side of the node link of the tunnel down (or back up) as per the
+// admin state of the tunnel. With the host side down the node sees its interface lose carrier, and
+// whatever the connectivity flavor, no frames make it across the link anymore. Failing to change
+// the admin state does not break the link, so we just complain about it.
+func (c *common) applyAdminState(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) {
+	if c.downLinks == nil {
+		c.downLinks = clabernetesutil.NewStringSet()
+	}
+
+	hostLink := hostLinkName(tunnel)
+
+	adminState := clabernetesconstants.LinkAdminStateUp
+
+	if tunnel.AdminState == clabernetesconstants.LinkAdminStateDown {
+		adminState = clabernetesconstants.LinkAdminStateDown
+	}
+
+	if (adminState == clabernetesconstants.LinkAdminStateDown) == c.downLinks.Contains(hostLink) {
+		// already in the desired state, nothing to do
+		return
+	}
+
+	cmd := exec.Command("ip", "link", "set", "dev", hostLink, adminState) //nolint:gosec
+
+	c.logger.Debugf("using following args for link admin state change '%s'", cmd.Args)
+
+	cmd.Stdout = c.logger
+	cmd.Stderr = c.logger
+
+	err := cmd.Run()
+	if err != nil {
+		c.logger.Warnf(
+			"failed setting link '%s' admin state to '%s', error: %s", hostLink, adminState, err,
+		)
+
+		return
+	}
+
+	if adminState == clabernetesconstants.LinkAdminStateDown {
+		c.downLinks.Add(hostLink)
+	} else {
+		c.downLinks.Remove(hostLink)
+	}
+
+	c.setTunnelAdminStatus(tunnel)
+}
+
+// linkAdminState returns the admin state we last set on the host side of the node link of the
+// tunnel.
+func (c *common) linkAdminState(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	if c.downLinks != nil && c.downLinks.Contains(hostLinkName(tunnel)) {
+		return clabernetesconstants.LinkAdminStateDown
+	}
+
+	return clabernetesconstants.LinkAdminStateUp
+}
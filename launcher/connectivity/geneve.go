@@ -0,0 +1,218 @@
+package connectivity
+
+import (
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strconv"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	geneveInterfacePrefix = "gn"
+	ingressQdiscParent    = "ffff:"
+)
+
+type geneveManager struct {
+	*common
+	currentTunnels map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+}
+
+func (m *geneveManager) Run() {
+	m.currentTunnels = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	m.logger.Info(
+		"connectivity mode is 'geneve', setting up any required tunnels...",
+	)
+
+	for _, tunnel := range m.initialTunnels {
+		resolvedGeneveRemote, err := m.createGeneveTunnel(tunnel)
+
+		m.setTunnelStatus(tunnel, resolvedGeneveRemote, err)
+
+		if err != nil {
+			m.reportTunnelStatuses()
+
+			m.logger.Fatalf(
+				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+
+		m.applyLinkSettings(tunnel)
+		m.setTunnelInterface(geneveInterfaceName(tunnel), tunnel)
+
+		// unlike the vxlan manager we key tunnels by the host side link name (so node *and*
+		// interface) since grouped nodes in a launcher can share local interface names
+		m.currentTunnels[hostLinkName(tunnel)] = tunnel
+	}
+
+	m.logger.Debug("initial geneve tunnel creation complete")
+
+	m.reportTunnelStatuses()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	go watchConnectivity(
+		m.ctx,
+		m.logger,
+		m.clabernetesClient,
+		m.updateGeneveTunnels,
+	)
+
+	m.logger.Debug("geneve connectivity setup complete")
+}
+
+// geneveInterfaceName returns the name of the geneve interface for the tunnel -- the tunnel id is
+// unique per topology, so this keeps us nicely under the interface name length limit.
+func geneveInterfaceName(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return fmt.Sprintf("%s-%d", geneveInterfacePrefix, tunnel.TunnelID)
+}
+
+func (m *geneveManager) runCommand(args ...string) error {
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec
+
+	m.logger.Debugf("using following args for geneve tunnel setup '%s'", cmd.Args)
+
+	cmd.Stdout = m.logger
+	cmd.Stderr = m.logger
+
+	return cmd.Run()
+}
+
+// createGeneveTunnel creates the geneve interface for the given tunnel and, like containerlab does
+// for its vxlan tooling, stitches it to the host side of the node link via tc mirred redirects.
+func (m *geneveManager) createGeneveTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) (string, error) {
+	resolvedGeneveRemote, err := m.resolveTunnelDestination(tunnel.Destination)
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Debugf("resolved remote geneve tunnel service address as '%s'", resolvedGeneveRemote)
+
+	geneveInterface := geneveInterfaceName(tunnel)
+	hostLink := hostLinkName(tunnel)
+
+	commands := [][]string{
+		{
+			"ip", "link", "add", geneveInterface,
+			"type", "geneve",
+			"id", strconv.Itoa(tunnel.TunnelID),
+			"remote", resolvedGeneveRemote,
+			"dstport", strconv.Itoa(clabernetesconstants.GENEVEServicePort),
+		},
+		{"ip", "link", "set", "dev", geneveInterface, "up"},
+		{"tc", "qdisc", "add", "dev", hostLink, "ingress"},
+		{
+			"tc", "filter", "add", "dev", hostLink, "parent", ingressQdiscParent,
+			"matchall", "action", "mirred", "egress", "redirect", "dev", geneveInterface,
+		},
+		{"tc", "qdisc", "add", "dev", geneveInterface, "ingress"},
+		{
+			"tc", "filter", "add", "dev", geneveInterface, "parent", ingressQdiscParent,
+			"matchall", "action", "mirred", "egress", "redirect", "dev", hostLink,
+		},
+	}
+
+	for _, args := range commands {
+		err = m.runCommand(args...)
+		if err != nil {
+			return resolvedGeneveRemote, err
+		}
+	}
+
+	return resolvedGeneveRemote, nil
+}
+
+// deleteGeneveTunnel removes the geneve interface (and with it its ingress qdisc) and the ingress
+// qdisc of the host side of the node link.
+func (m *geneveManager) deleteGeneveTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	err := m.runCommand("ip", "link", "del", "dev", geneveInterfaceName(tunnel))
+	if err != nil {
+		return err
+	}
+
+	return m.runCommand("tc", "qdisc", "del", "dev", hostLinkName(tunnel), "ingress")
+}
+
+func (m *geneveManager) updateGeneveTunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	desiredTunnels := make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	for _, tunnel := range tunnels {
+		desiredTunnels[hostLinkName(tunnel)] = tunnel
+	}
+
+	for key, existingTunnel := range m.currentTunnels {
+		tunnel, ok := desiredTunnels[key]
+		if ok && tunnelsEqualIgnoringLinkSettings(existingTunnel, tunnel) {
+			// nothing changed for this link's tunnel, at most its link settings, which we can
+			// just change in place
+			if !reflect.DeepEqual(existingTunnel, tunnel) {
+				m.applyLinkSettings(tunnel)
+
+				m.currentTunnels[key] = tunnel
+			}
+
+			continue
+		}
+
+		// the link is either gone or its tunnel isn't our desired setup anymore, either way the
+		// old tunnel gets deleted (and re-created below if still desired)
+		err := m.deleteGeneveTunnel(existingTunnel)
+
+		m.clearTunnelStatus(existingTunnel)
+		m.clearLinkSettings(existingTunnel)
+		m.clearTunnelInterface(geneveInterfaceName(existingTunnel))
+
+		if err != nil {
+			m.logger.Fatalf(
+				"failed deleting tunnel to remote node '%s' for local interface '%s'"+
+					", error: %s",
+				existingTunnel.RemoteNode,
+				existingTunnel.LocalInterface,
+				err,
+			)
+		}
+
+		delete(m.currentTunnels, key)
+	}
+
+	for key, tunnel := range desiredTunnels {
+		_, ok := m.currentTunnels[key]
+		if ok {
+			continue
+		}
+
+		resolvedGeneveRemote, err := m.createGeneveTunnel(tunnel)
+
+		m.setTunnelStatus(tunnel, resolvedGeneveRemote, err)
+
+		if err != nil {
+			m.reportTunnelStatuses()
+
+			m.logger.Fatalf(
+				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+
+		m.applyLinkSettings(tunnel)
+		m.setTunnelInterface(geneveInterfaceName(tunnel), tunnel)
+
+		m.currentTunnels[key] = tunnel
+	}
+
+	m.reportTunnelStatuses()
+}
@@ -0,0 +1,91 @@
+package connectivity
+
+import (
+	"os/exec"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+// netemArgs returns the netem arguments for the given impairment.
+func netemArgs(impairment *clabernetesapisv1alpha1.Impairment) []string {
+	var args []string
+
+	if impairment.Delay != "" {
+		args = append(args, "delay", impairment.Delay)
+
+		if impairment.Jitter != "" {
+			args = append(args, impairment.Jitter)
+		}
+	}
+
+	if impairment.Loss != "" {
+		args = append(args, "loss", strings.TrimSuffix(impairment.Loss, "%")+"%")
+	}
+
+	if impairment.Rate != "" {
+		args = append(args, "rate", impairment.Rate)
+	}
+
+	return args
+}
+
+// applyImpairment sets up (or changes, or removes) netem on the host side of the node link of the
+// tunnel. Frames from the remote end are sent out of the host link toward the node, so with both
+// launchers of a link doing this, both directions of the link get impaired. Failing to apply an
+// impairment does not break the link, so we just complain about it.
+func (c *common) applyImpairment(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) {
+	if c.impairedLinks == nil {
+		c.impairedLinks = clabernetesutil.NewStringSet()
+	}
+
+	hostLink := hostLinkName(tunnel)
+
+	var args []string
+
+	if tunnel.Impairment != nil {
+		args = netemArgs(tunnel.Impairment)
+	}
+
+	if len(args) == 0 {
+		if !c.impairedLinks.Contains(hostLink) {
+			return
+		}
+
+		err := c.runTcCommand("qdisc", "del", "dev", hostLink, "root")
+		if err != nil {
+			c.logger.Warnf(
+				"failed removing impairment from link '%s', error: %s", hostLink, err,
+			)
+
+			return
+		}
+
+		c.impairedLinks.Remove(hostLink)
+
+		return
+	}
+
+	err := c.runTcCommand(
+		append([]string{"qdisc", "replace", "dev", hostLink, "root", "netem"}, args...)...,
+	)
+	if err != nil {
+		c.logger.Warnf("failed applying impairment to link '%s', error: %s", hostLink, err)
+
+		return
+	}
+
+	c.impairedLinks.Add(hostLink)
+}
+
+func (c *common) runTcCommand(args ...string) error {
+	cmd := exec.Command("tc", args...)
+
+	c.logger.Debugf("using following args for link impairment '%s'", cmd.Args)
+
+	cmd.Stdout = c.logger
+	cmd.Stderr = c.logger
+
+	return cmd.Run()
+}
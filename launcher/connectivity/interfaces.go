@@ -0,0 +1,43 @@
+package connectivity
+
+import (
+	"maps"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// linuxInterfaceNameMaxLen is the max length of a linux interface name (IFNAMSIZ less the null
+// terminator).
+const linuxInterfaceNameMaxLen = 15
+
+// setTunnelInterface records the (launcher side) interface that was created for the given tunnel.
+func (c *common) setTunnelInterface(
+	interfaceName string,
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	c.tunnelInterfacesLock.Lock()
+	defer c.tunnelInterfacesLock.Unlock()
+
+	if c.tunnelInterfaces == nil {
+		c.tunnelInterfaces = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+	}
+
+	c.tunnelInterfaces[interfaceName] = tunnel
+}
+
+// clearTunnelInterface forgets the given tunnel interface, this should be called when a tunnel is
+// deleted.
+func (c *common) clearTunnelInterface(interfaceName string) {
+	c.tunnelInterfacesLock.Lock()
+	defer c.tunnelInterfacesLock.Unlock()
+
+	delete(c.tunnelInterfaces, interfaceName)
+}
+
+// TunnelInterfaces returns a copy of the recorded tunnel interfaces keyed by interface name.
+func (c *common) TunnelInterfaces() map[string]*clabernetesapisv1alpha1.PointToPointTunnel {
+	c.tunnelInterfacesLock.Lock()
+	defer c.tunnelInterfacesLock.Unlock()
+
+	return maps.Clone(c.tunnelInterfaces)
+}
@@ -0,0 +1,42 @@
+package connectivity
+
+import (
+	"reflect"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// tunnelsEqualIgnoringLinkSettings returns true if the given tunnels are the same other than their
+// link settings (impairment and admin state) -- in that case the tunnel itself can stay and only
+// the link settings need to be (re)applied.
+func tunnelsEqualIgnoringLinkSettings(a, b *clabernetesapisv1alpha1.PointToPointTunnel) bool {
+	aCopy := *a
+	bCopy := *b
+
+	aCopy.Impairment = nil
+	bCopy.Impairment = nil
+
+	aCopy.AdminState = ""
+	bCopy.AdminState = ""
+
+	return reflect.DeepEqual(aCopy, bCopy)
+}
+
+// applyLinkSettings applies the link settings (impairment and admin state) of the tunnel to the
+// host side of the node link. This can be called on an existing tunnel to change its link settings
+// in place.
+func (c *common) applyLinkSettings(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) {
+	c.applyImpairment(tunnel)
+	c.applyAdminState(tunnel)
+}
+
+// clearLinkSettings removes any impairment from and brings back up the host side of the node link
+// of the tunnel, this should be called when a tunnel is deleted.
+func (c *common) clearLinkSettings(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) {
+	clearedTunnel := *tunnel
+
+	clearedTunnel.Impairment = nil
+	clearedTunnel.AdminState = ""
+
+	c.applyLinkSettings(&clearedTunnel)
+}
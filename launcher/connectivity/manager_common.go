@@ -2,23 +2,42 @@ package connectivity
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
 	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
 	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+)
+
+const (
+	resolveServiceMaxAttempts = 5
+	resolveServiceSleep       = 10 * time.Second
 )
 
 // Manager is an interface defining a connectivity manager -- basically a small abstraction around
 // the flavor of how we connect to other launcher pods and their containerlab nodes -- the standard
-// way is via vxlan, and there is also an experimental tool "slurpeeth" for connectivity over tcp
-// tunnels.
+// way is via vxlan, geneve tunnels are handled in the same fashion, and there is also the userspace
+// "slurpeeth" flavor for connectivity over tcp tunnels. Lastly, the "multus" flavor does not create
+// any tunnels but stitches the network attachments multus created for the launcher pod to the node
+// links.
 type Manager interface {
-	// Run "runs" the connectivity flavor -- in the case of vxlan this simply means spinning up
-	// the required tunnels, but for other flavors (slurpeeth) this means running the process that
-	// watches the connectivity cr, and handles updates to the slurpeeth process/config. It is
-	// expected for the Run method to just call logger.Fatal if there is any issue as this would
-	// prevent c9s from doing anything useful anyway!
+	// Run "runs" the connectivity flavor -- in the case of vxlan/geneve this means spinning up
+	// the required tunnels, for slurpeeth this also means running the userspace forwarders that
+	// shuffle frames between the node links and the tcp streams, and for multus this means
+	// stitching the network attachments to the node links. Every flavor watches the
+	// connectivity cr and updates its tunnels accordingly. It is expected for the Run method to
+	// just call logger.Fatal if there is any issue as this would prevent c9s from doing anything
+	// useful anyway!
 	Run()
+	// TunnelInterfaces returns the (launcher side) tunnel interfaces the manager created keyed by
+	// interface name, so that we can report their counters. Flavors that do not create tunnel
+	// interfaces (slurpeeth) return an empty map.
+	TunnelInterfaces() map[string]*clabernetesapisv1alpha1.PointToPointTunnel
 }
 
 type common struct {
@@ -27,4 +46,54 @@ type common struct {
 	logger            claberneteslogging.Instance
 	clabernetesClient *clabernetesgeneratedclientset.Clientset
 	initialTunnels    []*clabernetesapisv1alpha1.PointToPointTunnel
+
+	tunnelStatusesLock sync.Mutex
+	tunnelStatuses     map[string]*clabernetesapisv1alpha1.PointToPointTunnelStatus
+
+	tunnelInterfacesLock sync.Mutex
+	tunnelInterfaces     map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+
+	impairedLinks clabernetesutil.StringSet
+	downLinks     clabernetesutil.StringSet
+}
+
+// hostLinkName returns the name of the host side of the (containerlab) host link that the tunnel
+// gets stitched to.
+func hostLinkName(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return fmt.Sprintf("%s-%s", tunnel.LocalNode, tunnel.LocalInterface)
+}
+
+// resolveTunnelDestination resolves the (fabric service) destination of a tunnel to exactly one ip
+// address, retrying a handful of times since the service may not be resolvable just yet.
+func (c *common) resolveTunnelDestination(destination string) (string, error) {
+	var resolvedDestinations []net.IP
+
+	var err error
+
+	for range resolveServiceMaxAttempts {
+		resolvedDestinations, err = net.LookupIP(destination)
+		if err != nil {
+			c.logger.Warnf(
+				"failed resolving remote tunnel endpoint but under max attempts will try"+
+					" again in %s. error: %s",
+				resolveServiceSleep,
+				err,
+			)
+
+			time.Sleep(resolveServiceSleep)
+
+			continue
+		}
+
+		break
+	}
+
+	if len(resolvedDestinations) != 1 {
+		return "", fmt.Errorf(
+			"%w: did not get exactly one ip resolved for remote tunnel endpoint",
+			claberneteserrors.ErrConnectivity,
+		)
+	}
+
+	return resolvedDestinations[0].String(), nil
 }
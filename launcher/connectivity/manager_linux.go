@@ -36,6 +36,18 @@ func NewManager(
 		return &vxlanManager{
 			common: c,
 		}, nil
+	case clabernetesconstants.ConnectivityGENEVE:
+		return &geneveManager{
+			common: c,
+		}, nil
+	case clabernetesconstants.ConnectivitySlurpeeth:
+		return &slurpeethManager{
+			common: c,
+		}, nil
+	case clabernetesconstants.ConnectivityMultus:
+		return &multusManager{
+			common: c,
+		}, nil
 	default:
 		return nil, fmt.Errorf(
 			"%w: unknown connectivity kind, cannot create connectivity manager",
@@ -36,6 +36,14 @@ func NewManager(
 		return &vxlanManager{
 			common: c,
 		}, nil
+	case clabernetesconstants.ConnectivityGENEVE:
+		return &geneveManager{
+			common: c,
+		}, nil
+	case clabernetesconstants.ConnectivityMultus:
+		return &multusManager{
+			common: c,
+		}, nil
 	default:
 		// just excluding slurpeeth for easy testing/linting reasons basically since we assume this
 		// will only ever run on linux anyway
@@ -0,0 +1,216 @@
+package connectivity
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+type multusManager struct {
+	*common
+	currentTunnels map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+}
+
+func (m *multusManager) Run() {
+	m.currentTunnels = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	m.logger.Info(
+		"connectivity mode is 'multus', stitching network attachments to node links...",
+	)
+
+	for _, tunnel := range m.initialTunnels {
+		err := m.stitchMultusInterface(tunnel)
+
+		m.setTunnelStatus(tunnel, "", err)
+
+		if err != nil {
+			m.reportTunnelStatuses()
+
+			m.logger.Fatalf(
+				"failed stitching network attachment to remote node '%s' for local interface"+
+					" '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+
+		m.applyLinkSettings(tunnel)
+		m.setTunnelInterface(multusInterfaceName(tunnel), tunnel)
+
+		m.currentTunnels[hostLinkName(tunnel)] = tunnel
+	}
+
+	m.logger.Debug("initial network attachment stitching complete")
+
+	m.reportTunnelStatuses()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	go watchConnectivity(
+		m.ctx,
+		m.logger,
+		m.clabernetesClient,
+		m.updateMultusTunnels,
+	)
+
+	m.logger.Debug("multus connectivity setup complete")
+}
+
+// multusInterfaceName returns the name of the network attachment interface multus created in our
+// pod for the tunnel -- the controller names the attachments in the pod annotation the same way.
+func multusInterfaceName(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	return fmt.Sprintf("%s-%d", clabernetesconstants.MultusInterfacePrefix, tunnel.TunnelID)
+}
+
+func (m *multusManager) runCommand(args ...string) error {
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec
+
+	m.logger.Debugf("using following args for network attachment setup '%s'", cmd.Args)
+
+	cmd.Stdout = m.logger
+	cmd.Stderr = m.logger
+
+	return cmd.Run()
+}
+
+// stitchMultusInterface stitches the network attachment interface of the given tunnel to the host
+// side of the node link via tc mirred redirects -- just like the geneve manager does with its
+// tunnel interfaces, except that multus already created the interface for us.
+func (m *multusManager) stitchMultusInterface(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	multusInterface := multusInterfaceName(tunnel)
+	hostLink := hostLinkName(tunnel)
+
+	_, err := os.Stat(fmt.Sprintf("/sys/class/net/%s", multusInterface))
+	if err != nil {
+		return fmt.Errorf(
+			"%w: network attachment interface %q does not exist, the launcher pod may not have"+
+				" been recreated with the attachment yet",
+			claberneteserrors.ErrConnectivity,
+			multusInterface,
+		)
+	}
+
+	// unlike the host side of the node link the attachment interface outlives the launcher
+	// container, so clear out whatever a previous launcher may have left behind; this fails if
+	// there is nothing to clear out, which is fine
+	_ = m.runCommand("tc", "qdisc", "del", "dev", multusInterface, "ingress")
+
+	commands := [][]string{
+		{"ip", "link", "set", "dev", multusInterface, "up"},
+		{"tc", "qdisc", "add", "dev", hostLink, "ingress"},
+		{
+			"tc", "filter", "add", "dev", hostLink, "parent", ingressQdiscParent,
+			"matchall", "action", "mirred", "egress", "redirect", "dev", multusInterface,
+		},
+		{"tc", "qdisc", "add", "dev", multusInterface, "ingress"},
+		{
+			"tc", "filter", "add", "dev", multusInterface, "parent", ingressQdiscParent,
+			"matchall", "action", "mirred", "egress", "redirect", "dev", hostLink,
+		},
+	}
+
+	for _, args := range commands {
+		err = m.runCommand(args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unstitchMultusInterface removes the ingress qdiscs (and with them the redirects) of the network
+// attachment interface and of the host side of the node link. The attachment interface itself
+// belongs to multus and is only removed along with the launcher pod.
+func (m *multusManager) unstitchMultusInterface(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	err := m.runCommand("tc", "qdisc", "del", "dev", multusInterfaceName(tunnel), "ingress")
+	if err != nil {
+		return err
+	}
+
+	return m.runCommand("tc", "qdisc", "del", "dev", hostLinkName(tunnel), "ingress")
+}
+
+func (m *multusManager) updateMultusTunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	desiredTunnels := make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	for _, tunnel := range tunnels {
+		desiredTunnels[hostLinkName(tunnel)] = tunnel
+	}
+
+	for key, existingTunnel := range m.currentTunnels {
+		tunnel, ok := desiredTunnels[key]
+		if ok && tunnelsEqualIgnoringLinkSettings(existingTunnel, tunnel) {
+			if !reflect.DeepEqual(existingTunnel, tunnel) {
+				m.applyLinkSettings(tunnel)
+
+				m.currentTunnels[key] = tunnel
+			}
+
+			continue
+		}
+
+		err := m.unstitchMultusInterface(existingTunnel)
+
+		m.clearTunnelStatus(existingTunnel)
+		m.clearLinkSettings(existingTunnel)
+		m.clearTunnelInterface(multusInterfaceName(existingTunnel))
+
+		if err != nil {
+			m.logger.Fatalf(
+				"failed removing network attachment stitching to remote node '%s' for local"+
+					" interface '%s', error: %s",
+				existingTunnel.RemoteNode,
+				existingTunnel.LocalInterface,
+				err,
+			)
+		}
+
+		delete(m.currentTunnels, key)
+	}
+
+	for key, tunnel := range desiredTunnels {
+		_, ok := m.currentTunnels[key]
+		if ok {
+			continue
+		}
+
+		err := m.stitchMultusInterface(tunnel)
+
+		m.setTunnelStatus(tunnel, "", err)
+
+		if err != nil {
+			// network attachments are only added when a pod is created, so a new link only gets
+			// its attachment once the controller recreated our pod -- until then report the
+			// tunnel as failed and try again on the next update rather than bailing out
+			m.logger.Warnf(
+				"failed stitching network attachment to remote node '%s' for local interface"+
+					" '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+
+			continue
+		}
+
+		m.applyLinkSettings(tunnel)
+		m.setTunnelInterface(multusInterfaceName(tunnel), tunnel)
+
+		m.currentTunnels[key] = tunnel
+	}
+
+	m.reportTunnelStatuses()
+}
@@ -0,0 +1,507 @@
+//go:build linux
+// +build linux
+
+package connectivity
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+)
+
+const (
+	// frames are length prefixed with a uint16 on the wire, so that is also our max frame size.
+	slurpeethMaxFrameSize = 65535
+	slurpeethLengthSize   = 2
+	slurpeethTunnelIDSize = 4
+	slurpeethDialTimeout  = 5 * time.Second
+	slurpeethRedialSleep  = 2 * time.Second
+
+	// packetOutgoing is the linux PACKET_OUTGOING packet type -- frames we write to the host link
+	// show up on our packet socket with this type, we skip those so we don't loop them back.
+	packetOutgoing = 4
+)
+
+// slurpeethManager is the userspace connectivity flavor -- ethernet frames are read from the host
+// side of node links via packet sockets and shipped to the remote launcher over tcp streams. Each
+// launcher dials the remote side of a tunnel to send frames and accepts connections from remote
+// launchers to receive frames, so no udp encapsulation is ever involved.
+type slurpeethManager struct {
+	*common
+	currentTunnelsLock sync.Mutex
+	currentTunnels     map[string]*slurpeethTunnel
+}
+
+type slurpeethTunnel struct {
+	tunnel         *clabernetesapisv1alpha1.PointToPointTunnel
+	resolvedRemote string
+	// appliedTunnel is the tunnel as last applied -- link settings changes are applied in place,
+	// without touching the (concurrently read) tunnel itself, so this is what we compare against
+	appliedTunnel *clabernetesapisv1alpha1.PointToPointTunnel
+	socket        *os.File
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+func (m *slurpeethManager) Run() {
+	m.currentTunnels = make(map[string]*slurpeethTunnel)
+
+	m.logger.Info(
+		"connectivity mode is 'slurpeeth', setting up any required tunnels...",
+	)
+
+	listener, err := net.Listen(
+		"tcp",
+		fmt.Sprintf(":%d", clabernetesconstants.SlurpeethServicePort),
+	)
+	if err != nil {
+		m.logger.Fatalf("failed starting slurpeeth listener, error: %s", err)
+	}
+
+	go m.serve(listener)
+
+	for _, tunnel := range m.initialTunnels {
+		err = m.startTunnel(tunnel)
+		if err != nil {
+			m.reportTunnelStatuses()
+
+			m.logger.Fatalf(
+				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+	}
+
+	m.logger.Debug("initial slurpeeth tunnel creation complete")
+
+	m.reportTunnelStatuses()
+
+	m.logger.Debug("start connectivity custom resource watch...")
+
+	go watchConnectivity(
+		m.ctx,
+		m.logger,
+		m.clabernetesClient,
+		m.updateSlurpeethTunnels,
+	)
+
+	m.logger.Debug("slurpeeth connectivity setup complete")
+}
+
+func htons(v uint16) uint16 {
+	var b [2]byte
+
+	binary.BigEndian.PutUint16(b[:], v)
+
+	return binary.NativeEndian.Uint16(b[:])
+}
+
+// openPacketSocket opens a (non-blocking, so it plays nice w/ the go poller) packet socket bound
+// to the given interface.
+func openPacketSocket(interfaceName string) (*os.File, error) {
+	iface, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	protocol := htons(syscall.ETH_P_ALL)
+
+	fd, err := syscall.Socket(
+		syscall.AF_PACKET,
+		syscall.SOCK_RAW|syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC,
+		int(protocol),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = syscall.Bind(fd, &syscall.SockaddrLinklayer{
+		Protocol: protocol,
+		Ifindex:  iface.Index,
+	})
+	if err != nil {
+		_ = syscall.Close(fd)
+
+		return nil, err
+	}
+
+	return os.NewFile(uintptr(fd), interfaceName), nil
+}
+
+// readPacket reads the next frame received on the host link into buf, skipping any frames that
+// we wrote to the link ourselves.
+func readPacket(socket *os.File, buf []byte) (int, error) {
+	rawConn, err := socket.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		var n int
+
+		var from syscall.Sockaddr
+
+		var recvErr error
+
+		err = rawConn.Read(func(fd uintptr) bool {
+			n, from, recvErr = syscall.Recvfrom(int(fd), buf, 0)
+
+			return !errors.Is(recvErr, syscall.EAGAIN)
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if errors.Is(recvErr, syscall.ENETDOWN) {
+			// the link was taken down (admin state), the socket reports this once, after which
+			// it simply receives nothing until the link is back up
+			continue
+		}
+
+		if recvErr != nil {
+			return 0, recvErr
+		}
+
+		linkLayerFrom, ok := from.(*syscall.SockaddrLinklayer)
+		if ok && linkLayerFrom.Pkttype == packetOutgoing {
+			continue
+		}
+
+		return n, nil
+	}
+}
+
+func (m *slurpeethManager) startTunnel(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+) error {
+	resolvedSlurpeethRemote, err := m.resolveTunnelDestination(tunnel.Destination)
+	if err != nil {
+		m.setTunnelStatus(tunnel, "", err)
+
+		return err
+	}
+
+	m.logger.Debugf(
+		"resolved remote slurpeeth tunnel service address as '%s'", resolvedSlurpeethRemote,
+	)
+
+	socket, err := openPacketSocket(hostLinkName(tunnel))
+
+	m.setTunnelStatus(tunnel, resolvedSlurpeethRemote, err)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+
+	t := &slurpeethTunnel{
+		tunnel:         tunnel,
+		resolvedRemote: resolvedSlurpeethRemote,
+		appliedTunnel:  tunnel,
+		socket:         socket,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+
+	m.currentTunnelsLock.Lock()
+	m.currentTunnels[hostLinkName(tunnel)] = t
+	m.currentTunnelsLock.Unlock()
+
+	m.applyLinkSettings(tunnel)
+
+	go m.forwardFrames(t)
+
+	return nil
+}
+
+func (m *slurpeethManager) stopTunnel(key string) {
+	m.currentTunnelsLock.Lock()
+	defer m.currentTunnelsLock.Unlock()
+
+	t, ok := m.currentTunnels[key]
+	if !ok {
+		return
+	}
+
+	t.cancel()
+
+	m.clearTunnelStatus(t.tunnel)
+	m.clearLinkSettings(t.tunnel)
+
+	err := t.socket.Close()
+	if err != nil {
+		m.logger.Warnf("failed closing packet socket for link '%s', error: %s", key, err)
+	}
+
+	delete(m.currentTunnels, key)
+}
+
+func (m *slurpeethManager) tunnelByID(tunnelID int) *slurpeethTunnel {
+	m.currentTunnelsLock.Lock()
+	defer m.currentTunnelsLock.Unlock()
+
+	for _, t := range m.currentTunnels {
+		if t.tunnel.TunnelID == tunnelID {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (m *slurpeethManager) dial(t *slurpeethTunnel) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: slurpeethDialTimeout}
+
+	conn, err := dialer.DialContext(
+		t.ctx,
+		"tcp",
+		net.JoinHostPort(
+			t.resolvedRemote,
+			strconv.Itoa(clabernetesconstants.SlurpeethServicePort),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first thing on the wire is always the tunnel id so the remote end knows which of its
+	// links to deliver our frames to
+	header := make([]byte, slurpeethTunnelIDSize)
+
+	binary.BigEndian.PutUint32(header, uint32(t.tunnel.TunnelID)) //nolint:gosec
+
+	_, err = conn.Write(header)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// forwardFrames reads frames from the host link of the tunnel and writes them to the tcp stream
+// towards the remote launcher, (re)dialing as needed. Frames read while we have no connection to
+// the remote launcher are dropped, just like they would be on a broken wire.
+func (m *slurpeethManager) forwardFrames(t *slurpeethTunnel) {
+	var conn net.Conn
+
+	var lastDial time.Time
+
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	buf := make([]byte, slurpeethLengthSize+slurpeethMaxFrameSize)
+
+	for {
+		n, err := readPacket(t.socket, buf[slurpeethLengthSize:])
+		if err != nil {
+			if t.ctx.Err() == nil {
+				m.logger.Warnf(
+					"failed reading from link '%s', stopping tunnel, error: %s",
+					hostLinkName(t.tunnel),
+					err,
+				)
+			}
+
+			return
+		}
+
+		if conn == nil {
+			if time.Since(lastDial) < slurpeethRedialSleep {
+				continue
+			}
+
+			lastDial = time.Now()
+
+			conn, err = m.dial(t)
+			if err != nil {
+				m.logger.Debugf(
+					"failed dialing remote slurpeeth endpoint '%s', will retry, error: %s",
+					t.tunnel.Destination,
+					err,
+				)
+
+				continue
+			}
+		}
+
+		binary.BigEndian.PutUint16(buf, uint16(n)) //nolint:gosec
+
+		_, err = conn.Write(buf[:slurpeethLengthSize+n])
+		if err != nil {
+			m.logger.Debugf(
+				"failed writing to remote slurpeeth endpoint '%s', will redial, error: %s",
+				t.tunnel.Destination,
+				err,
+			)
+
+			_ = conn.Close()
+
+			conn = nil
+		}
+	}
+}
+
+func (m *slurpeethManager) serve(listener net.Listener) {
+	go func() {
+		<-m.ctx.Done()
+
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if m.ctx.Err() != nil {
+				return
+			}
+
+			m.logger.Warnf("failed accepting slurpeeth connection, error: %s", err)
+
+			continue
+		}
+
+		go m.handleConnection(conn)
+	}
+}
+
+// handleConnection receives frames from a remote launcher and writes them to the host link of the
+// tunnel the remote launcher asked for.
+func (m *slurpeethManager) handleConnection(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	header := make([]byte, slurpeethTunnelIDSize)
+
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		m.logger.Debugf("failed reading slurpeeth connection header, error: %s", err)
+
+		return
+	}
+
+	tunnelID := int(binary.BigEndian.Uint32(header))
+
+	t := m.tunnelByID(tunnelID)
+	if t == nil {
+		// the remote launcher will just redial, by then we may know about this tunnel
+		m.logger.Warnf("received slurpeeth connection for unknown tunnel id %d", tunnelID)
+
+		return
+	}
+
+	stop := context.AfterFunc(t.ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	buf := make([]byte, slurpeethMaxFrameSize)
+
+	for {
+		_, err = io.ReadFull(conn, buf[:slurpeethLengthSize])
+		if err != nil {
+			return
+		}
+
+		frameLength := int(binary.BigEndian.Uint16(buf[:slurpeethLengthSize]))
+
+		_, err = io.ReadFull(conn, buf[:frameLength])
+		if err != nil {
+			return
+		}
+
+		_, err = t.socket.Write(buf[:frameLength])
+		if errors.Is(err, syscall.ENETDOWN) {
+			// the link is administratively down, so the frame is dropped just like it would be
+			// on a real link that is down
+			continue
+		}
+
+		if err != nil {
+			if t.ctx.Err() == nil {
+				m.logger.Warnf(
+					"failed writing frame to link '%s', error: %s",
+					hostLinkName(t.tunnel),
+					err,
+				)
+			}
+
+			return
+		}
+	}
+}
+
+func (m *slurpeethManager) updateSlurpeethTunnels(
+	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) {
+	desiredTunnels := make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	for _, tunnel := range tunnels {
+		desiredTunnels[hostLinkName(tunnel)] = tunnel
+	}
+
+	m.currentTunnelsLock.Lock()
+
+	tunnelsToStop := make([]string, 0)
+
+	for key, existingTunnel := range m.currentTunnels {
+		tunnel, ok := desiredTunnels[key]
+		if ok && tunnelsEqualIgnoringLinkSettings(existingTunnel.tunnel, tunnel) {
+			// nothing changed for this link's tunnel, at most its link settings, which we can
+			// just change in place
+			if !reflect.DeepEqual(existingTunnel.appliedTunnel, tunnel) {
+				m.applyLinkSettings(tunnel)
+
+				existingTunnel.appliedTunnel = tunnel
+			}
+
+			delete(desiredTunnels, key)
+
+			continue
+		}
+
+		tunnelsToStop = append(tunnelsToStop, key)
+	}
+
+	m.currentTunnelsLock.Unlock()
+
+	for _, key := range tunnelsToStop {
+		m.stopTunnel(key)
+	}
+
+	for _, tunnel := range desiredTunnels {
+		err := m.startTunnel(tunnel)
+		if err != nil {
+			m.reportTunnelStatuses()
+
+			m.logger.Fatalf(
+				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
+				tunnel.RemoteNode,
+				tunnel.LocalInterface,
+				err,
+			)
+		}
+	}
+
+	m.reportTunnelStatuses()
+}
@@ -0,0 +1,129 @@
+package connectivity
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+// setTunnelStatus records the state of the given tunnel -- created if err is nil, otherwise
+// failed. The recorded states are pushed to the connectivity cr by reportTunnelStatuses.
+func (c *common) setTunnelStatus(
+	tunnel *clabernetesapisv1alpha1.PointToPointTunnel,
+	resolvedRemote string,
+	err error,
+) {
+	c.tunnelStatusesLock.Lock()
+	defer c.tunnelStatusesLock.Unlock()
+
+	if c.tunnelStatuses == nil {
+		c.tunnelStatuses = make(map[string]*clabernetesapisv1alpha1.PointToPointTunnelStatus)
+	}
+
+	tunnelStatus := &clabernetesapisv1alpha1.PointToPointTunnelStatus{
+		TunnelID:       tunnel.TunnelID,
+		LocalNode:      tunnel.LocalNode,
+		LocalInterface: tunnel.LocalInterface,
+		State:          clabernetesconstants.TunnelStateCreated,
+		AdminState:     c.linkAdminState(tunnel),
+		ResolvedRemote: resolvedRemote,
+		LastUpdateTime: metav1.Now(),
+	}
+
+	if err != nil {
+		tunnelStatus.State = clabernetesconstants.TunnelStateFailed
+		tunnelStatus.LastError = err.Error()
+	}
+
+	c.tunnelStatuses[hostLinkName(tunnel)] = tunnelStatus
+}
+
+// setTunnelAdminStatus updates the recorded admin state of the given tunnel, if we have a recorded
+// state for the tunnel at all.
+func (c *common) setTunnelAdminStatus(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) {
+	c.tunnelStatusesLock.Lock()
+	defer c.tunnelStatusesLock.Unlock()
+
+	tunnelStatus, ok := c.tunnelStatuses[hostLinkName(tunnel)]
+	if !ok {
+		return
+	}
+
+	tunnelStatus.AdminState = c.linkAdminState(tunnel)
+	tunnelStatus.LastUpdateTime = metav1.Now()
+}
+
+// clearTunnelStatus forgets the state of the given tunnel, this should be called when a tunnel is
+// deleted.
+func (c *common) clearTunnelStatus(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) {
+	c.tunnelStatusesLock.Lock()
+	defer c.tunnelStatusesLock.Unlock()
+
+	delete(c.tunnelStatuses, hostLinkName(tunnel))
+}
+
+// reportTunnelStatuses pushes the recorded tunnel states to the status of the connectivity cr. We
+// merge patch only our own (launcher's) entry so we never step on the toes of other launchers.
+// Failing to report is not fatal -- the tunnels work just the same, so we just complain about it.
+func (c *common) reportTunnelStatuses() {
+	c.tunnelStatusesLock.Lock()
+
+	tunnelStatuses := make(
+		[]*clabernetesapisv1alpha1.PointToPointTunnelStatus,
+		0,
+		len(c.tunnelStatuses),
+	)
+
+	for _, tunnelStatus := range c.tunnelStatuses {
+		tunnelStatuses = append(tunnelStatuses, tunnelStatus)
+	}
+
+	c.tunnelStatusesLock.Unlock()
+
+	slices.SortFunc(
+		tunnelStatuses,
+		func(a, b *clabernetesapisv1alpha1.PointToPointTunnelStatus) int {
+			if a.LocalNode != b.LocalNode {
+				return strings.Compare(a.LocalNode, b.LocalNode)
+			}
+
+			return strings.Compare(a.LocalInterface, b.LocalInterface)
+		},
+	)
+
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"pointToPointTunnels": map[string]any{
+				os.Getenv(clabernetesconstants.LauncherNodeNameEnv): tunnelStatuses,
+			},
+		},
+	})
+	if err != nil {
+		c.logger.Warnf("failed marshaling tunnel status patch, error: %s", err)
+
+		return
+	}
+
+	_, err = c.clabernetesClient.ClabernetesV1alpha1().
+		Connectivities(os.Getenv(clabernetesconstants.PodNamespaceEnv)).
+		Patch(
+			c.ctx,
+			os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+			apimachinerytypes.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+	if err != nil {
+		c.logger.Warnf("failed reporting tunnel status to connectivity cr, error: %s", err)
+
+		return
+	}
+
+	c.logger.Debug("reported tunnel status to connectivity cr")
+}
@@ -0,0 +1,74 @@
+package connectivity
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+)
+
+// TunnelUpdates holds what needs to happen to get from the tunnels a connectivity manager currently
+// has set up to the desired tunnels of its launcher.
+type TunnelUpdates struct {
+	// Delete holds the existing tunnels that need to be torn down, either because their link is
+	// gone or because the tunnel setup changed -- in the latter case the desired tunnel is also in
+	// Create.
+	Delete []*clabernetesapisv1alpha1.PointToPointTunnel
+	// Create holds the desired tunnels that need to be set up.
+	Create []*clabernetesapisv1alpha1.PointToPointTunnel
+	// UpdateLinkSettings holds the desired tunnels whose tunnel can stay as is but whose link
+	// settings (impairment and/or admin state) changed.
+	UpdateLinkSettings []*clabernetesapisv1alpha1.PointToPointTunnel
+}
+
+// PlanTunnelUpdates returns the TunnelUpdates to get from the current tunnels -- keyed by host
+// link name like the managers store them -- to the desired tunnels. Tunnels are matched by their
+// host link name (so local node *and* interface) since grouped nodes in a launcher can share local
+// interface names. All slices are sorted by host link name.
+func PlanTunnelUpdates(
+	currentTunnels map[string]*clabernetesapisv1alpha1.PointToPointTunnel,
+	desiredTunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
+) *TunnelUpdates {
+	updates := &TunnelUpdates{}
+
+	desiredTunnelsByKey := make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	for _, tunnel := range desiredTunnels {
+		desiredTunnelsByKey[hostLinkName(tunnel)] = tunnel
+	}
+
+	for key, existingTunnel := range currentTunnels {
+		tunnel, ok := desiredTunnelsByKey[key]
+		if ok && tunnelsEqualIgnoringLinkSettings(existingTunnel, tunnel) {
+			if !reflect.DeepEqual(existingTunnel, tunnel) {
+				updates.UpdateLinkSettings = append(updates.UpdateLinkSettings, tunnel)
+			}
+
+			continue
+		}
+
+		updates.Delete = append(updates.Delete, existingTunnel)
+	}
+
+	for key, tunnel := range desiredTunnelsByKey {
+		existingTunnel, ok := currentTunnels[key]
+		if ok && tunnelsEqualIgnoringLinkSettings(existingTunnel, tunnel) {
+			continue
+		}
+
+		updates.Create = append(updates.Create, tunnel)
+	}
+
+	sortTunnels := func(tunnels []*clabernetesapisv1alpha1.PointToPointTunnel) {
+		slices.SortFunc(tunnels, func(a, b *clabernetesapisv1alpha1.PointToPointTunnel) int {
+			return strings.Compare(hostLinkName(a), hostLinkName(b))
+		})
+	}
+
+	sortTunnels(updates.Delete)
+	sortTunnels(updates.Create)
+	sortTunnels(updates.UpdateLinkSettings)
+
+	return updates
+}
@@ -0,0 +1,141 @@
+package connectivity_test
+
+import (
+	"reflect"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesconnectivity "github.com/srl-labs/clabernetes/launcher/connectivity"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func testTunnel(
+	tunnelID int,
+	localNode, localInterface, remoteNode string,
+) *clabernetesapisv1alpha1.PointToPointTunnel {
+	return &clabernetesapisv1alpha1.PointToPointTunnel{
+		TunnelID:        tunnelID,
+		LocalNode:       localNode,
+		LocalInterface:  localInterface,
+		RemoteNode:      remoteNode,
+		RemoteInterface: localInterface,
+		Destination:     "topo-1-" + remoteNode + ".clabernetes.svc.cluster.local",
+	}
+}
+
+func testTunnelMap(
+	tunnels ...*clabernetesapisv1alpha1.PointToPointTunnel,
+) map[string]*clabernetesapisv1alpha1.PointToPointTunnel {
+	tunnelMap := make(map[string]*clabernetesapisv1alpha1.PointToPointTunnel)
+
+	for _, tunnel := range tunnels {
+		tunnelMap[tunnel.LocalNode+"-"+tunnel.LocalInterface] = tunnel
+	}
+
+	return tunnelMap
+}
+
+func TestPlanTunnelUpdates(t *testing.T) {
+	downTunnel := testTunnel(1, "srl1", "e1-1", "srl3")
+	downTunnel.AdminState = clabernetesconstants.LinkAdminStateDown
+
+	cases := []struct {
+		name           string
+		currentTunnels map[string]*clabernetesapisv1alpha1.PointToPointTunnel
+		desiredTunnels []*clabernetesapisv1alpha1.PointToPointTunnel
+		expected       *clabernetesconnectivity.TunnelUpdates
+	}{
+		{
+			name:           "initial",
+			currentTunnels: testTunnelMap(),
+			desiredTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+			},
+			expected: &clabernetesconnectivity.TunnelUpdates{
+				Create: []*clabernetesapisv1alpha1.PointToPointTunnel{
+					testTunnel(1, "srl1", "e1-1", "srl3"),
+				},
+			},
+		},
+		{
+			name: "grouped-nodes-shared-interface-unchanged",
+			currentTunnels: testTunnelMap(
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			),
+			desiredTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			},
+			expected: &clabernetesconnectivity.TunnelUpdates{},
+		},
+		{
+			name: "grouped-nodes-shared-interface-one-removed",
+			currentTunnels: testTunnelMap(
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			),
+			desiredTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			},
+			expected: &clabernetesconnectivity.TunnelUpdates{
+				Delete: []*clabernetesapisv1alpha1.PointToPointTunnel{
+					testTunnel(1, "srl1", "e1-1", "srl3"),
+				},
+			},
+		},
+		{
+			name: "grouped-nodes-shared-interface-one-changed",
+			currentTunnels: testTunnelMap(
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			),
+			desiredTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+				testTunnel(3, "srl2", "e1-1", "srl5"),
+			},
+			expected: &clabernetesconnectivity.TunnelUpdates{
+				Delete: []*clabernetesapisv1alpha1.PointToPointTunnel{
+					testTunnel(2, "srl2", "e1-1", "srl4"),
+				},
+				Create: []*clabernetesapisv1alpha1.PointToPointTunnel{
+					testTunnel(3, "srl2", "e1-1", "srl5"),
+				},
+			},
+		},
+		{
+			name: "link-settings-changed",
+			currentTunnels: testTunnelMap(
+				testTunnel(1, "srl1", "e1-1", "srl3"),
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			),
+			desiredTunnels: []*clabernetesapisv1alpha1.PointToPointTunnel{
+				downTunnel,
+				testTunnel(2, "srl2", "e1-1", "srl4"),
+			},
+			expected: &clabernetesconnectivity.TunnelUpdates{
+				UpdateLinkSettings: []*clabernetesapisv1alpha1.PointToPointTunnel{
+					downTunnel,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual := clabernetesconnectivity.PlanTunnelUpdates(
+					testCase.currentTunnels,
+					testCase.desiredTunnels,
+				)
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
@@ -2,20 +2,11 @@ package connectivity
 
 import (
 	"fmt"
-	"net"
 	"os/exec"
-	"reflect"
 	"strconv"
-	"time"
 
 	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
-	claberneteserrors "github.com/srl-labs/clabernetes/errors"
-)
-
-const (
-	resolveServiceMaxAttempts = 5
-	resolveServiceSleep       = 10 * time.Second
 )
 
 type vxlanManager struct {
@@ -31,13 +22,18 @@ func (m *vxlanManager) Run() {
 	)
 
 	for _, tunnel := range m.initialTunnels {
-		err := m.runContainerlabVxlanToolsCreate(
+		resolvedVxlanRemote, err := m.runContainerlabVxlanToolsCreate(
 			tunnel.LocalNode,
 			tunnel.LocalInterface,
 			tunnel.Destination,
 			tunnel.TunnelID,
 		)
+
+		m.setTunnelStatus(tunnel, resolvedVxlanRemote, err)
+
 		if err != nil {
+			m.reportTunnelStatuses()
+
 			m.logger.Fatalf(
 				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
 				tunnel.RemoteNode,
@@ -46,13 +42,19 @@ func (m *vxlanManager) Run() {
 			)
 		}
 
-		// we store them in a nice little map by local interface name so they're easy to
+		m.applyLinkSettings(tunnel)
+		m.setTunnelInterface(vxlanInterfaceName(tunnel), tunnel)
+
+		// we store them in a nice little map by host side link name (so node *and* interface,
+		// grouped nodes in a launcher can share local interface names) so they're easy to
 		// reconcile on connectivity cr updates
-		m.currentTunnels[tunnel.LocalInterface] = tunnel
+		m.currentTunnels[hostLinkName(tunnel)] = tunnel
 	}
 
 	m.logger.Debug("initial vxlan tunnel creation complete")
 
+	m.reportTunnelStatuses()
+
 	m.logger.Debug("start connectivity custom resource watch...")
 
 	go watchConnectivity(
@@ -65,46 +67,25 @@ func (m *vxlanManager) Run() {
 	m.logger.Debug("vxlan connectivity setup complete")
 }
 
-func (m *vxlanManager) resolveVXLANService(vxlanRemote string) (string, error) {
-	var resolvedVxlanRemotes []net.IP
-
-	var err error
+// vxlanInterfaceName returns the name of the vxlan interface containerlab creates for the tunnel --
+// that is the host side link name prefixed with "vx-", cut to the interface name length limit.
+func vxlanInterfaceName(tunnel *clabernetesapisv1alpha1.PointToPointTunnel) string {
+	interfaceName := fmt.Sprintf("vx-%s", hostLinkName(tunnel))
 
-	for range resolveServiceMaxAttempts {
-		resolvedVxlanRemotes, err = net.LookupIP(vxlanRemote)
-		if err != nil {
-			m.logger.Warnf(
-				"failed resolving remote vxlan endpoint but under max attempts will try"+
-					" again in %s. error: %s",
-				resolveServiceSleep,
-				err,
-			)
-
-			time.Sleep(resolveServiceSleep)
-
-			continue
-		}
-
-		break
-	}
-
-	if len(resolvedVxlanRemotes) != 1 {
-		return "", fmt.Errorf(
-			"%w: did not get exactly one ip resolved for remote vxlan endpoint",
-			claberneteserrors.ErrConnectivity,
-		)
+	if len(interfaceName) > linuxInterfaceNameMaxLen {
+		interfaceName = interfaceName[:linuxInterfaceNameMaxLen]
 	}
 
-	return resolvedVxlanRemotes[0].String(), nil
+	return interfaceName
 }
 
 func (m *vxlanManager) runContainerlabVxlanToolsCreate(
 	localNodeName, cntLink, vxlanRemote string,
 	vxlanID int,
-) error {
-	resolvedVxlanRemote, err := m.resolveVXLANService(vxlanRemote)
+) (string, error) {
+	resolvedVxlanRemote, err := m.resolveTunnelDestination(vxlanRemote)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	m.logger.Debugf("resolved remote vxlan tunnel service address as '%s'", resolvedVxlanRemote)
@@ -133,10 +114,10 @@ func (m *vxlanManager) runContainerlabVxlanToolsCreate(
 
 	err = cmd.Run()
 	if err != nil {
-		return err
+		return resolvedVxlanRemote, err
 	}
 
-	return nil
+	return resolvedVxlanRemote, nil
 }
 
 func (m *vxlanManager) runContainerlabVxlanToolsDelete(
@@ -169,77 +150,53 @@ func (m *vxlanManager) runContainerlabVxlanToolsDelete(
 func (m *vxlanManager) updateVxlanTunnels(
 	tunnels []*clabernetesapisv1alpha1.PointToPointTunnel,
 ) {
-	// start with deleting extraneous tunnels...
-	for _, existingTunnel := range m.currentTunnels {
-		var found bool
-
-		for _, tunnel := range tunnels {
-			if tunnel.LocalInterface == existingTunnel.LocalInterface {
-				found = true
+	updates := PlanTunnelUpdates(m.currentTunnels, tunnels)
 
-				break
-			}
-		}
+	for _, tunnel := range updates.UpdateLinkSettings {
+		// we've already got a tunnel setup for this link with the same destination, so we can skip
+		// doing anything to this one other than changing its link settings in place.
+		m.applyLinkSettings(tunnel)
 
-		if found {
-			// the existing tunnel (or rather its local interface) is represented in the "new"
-			// tunnels, nothing to do here
-			continue
-		}
+		m.currentTunnels[hostLinkName(tunnel)] = tunnel
+	}
 
+	// delete extraneous tunnels and tunnels that are not our desired setup anymore, the latter
+	// are re-created below
+	for _, existingTunnel := range updates.Delete {
 		err := m.runContainerlabVxlanToolsDelete(
 			existingTunnel.LocalNode, existingTunnel.LocalInterface,
 		)
+
+		m.clearTunnelStatus(existingTunnel)
+		m.clearLinkSettings(existingTunnel)
+		m.clearTunnelInterface(vxlanInterfaceName(existingTunnel))
+
 		if err != nil {
 			m.logger.Fatalf(
-				"failed deleting extraneous tunnel to remote node '%s' for local interface '%s'"+
+				"failed deleting tunnel to remote node '%s' for local interface '%s'"+
 					", error: %s",
 				existingTunnel.RemoteNode,
 				existingTunnel.LocalInterface,
 				err,
 			)
 		}
-	}
-
-	tunnelsToReCreate := make([]*clabernetesapisv1alpha1.PointToPointTunnel, 0)
-
-	for _, tunnel := range tunnels {
-		existingTunnel, ok := m.currentTunnels[tunnel.LocalInterface]
-		if ok && reflect.DeepEqual(existingTunnel, tunnel) {
-			// we've already got a tunnel setup for this interface, so we gotta check to see if our
-			// previously setup destination is the same -- if "yes" we can skip doing anything to
-			// this one.
-			continue
-		}
-
-		if ok {
-			// tunnel for this interface exists but isnt the same as our desired setup, delete the
-			// old tunnel before we create the new one
-			err := m.runContainerlabVxlanToolsDelete(
-				tunnel.LocalNode, tunnel.LocalInterface,
-			)
-			if err != nil {
-				m.logger.Fatalf(
-					"failed deleting existing tunnel to remote node '%s' for local interface '%s'"+
-						" before re-configuring, error: %s",
-					tunnel.RemoteNode,
-					tunnel.LocalInterface,
-					err,
-				)
-			}
-		}
 
-		tunnelsToReCreate = append(tunnelsToReCreate, tunnel)
+		delete(m.currentTunnels, hostLinkName(existingTunnel))
 	}
 
-	for _, tunnel := range tunnelsToReCreate {
-		err := m.runContainerlabVxlanToolsCreate(
+	for _, tunnel := range updates.Create {
+		resolvedVxlanRemote, err := m.runContainerlabVxlanToolsCreate(
 			tunnel.LocalNode,
 			tunnel.LocalInterface,
 			tunnel.Destination,
 			tunnel.TunnelID,
 		)
+
+		m.setTunnelStatus(tunnel, resolvedVxlanRemote, err)
+
 		if err != nil {
+			m.reportTunnelStatuses()
+
 			m.logger.Fatalf(
 				"failed setting up tunnel to remote node '%s' for local interface '%s', error: %s",
 				tunnel.RemoteNode,
@@ -247,5 +204,12 @@ func (m *vxlanManager) updateVxlanTunnels(
 				err,
 			)
 		}
+
+		m.applyLinkSettings(tunnel)
+		m.setTunnelInterface(vxlanInterfaceName(tunnel), tunnel)
+
+		m.currentTunnels[hostLinkName(tunnel)] = tunnel
 	}
+
+	m.reportTunnelStatuses()
 }
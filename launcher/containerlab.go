@@ -143,3 +143,12 @@ func (c *clabernetes) runContainerlab() error {
 
 	return nil
 }
+
+func (c *clabernetes) runContainerlabSave(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "containerlab", "save", "-t", "topo.clab.yaml")
+
+	cmd.Stdout = c.containerlabLogger
+	cmd.Stderr = c.containerlabLogger
+
+	return cmd.Run()
+}
@@ -264,3 +264,20 @@ func getContainerAddr(containerID string) (string, error) {
 
 	return strings.TrimSpace(string(output)), nil
 }
+
+func getContainerPID(containerID string) (string, error) {
+	inspectCmd := exec.Command(
+		"docker",
+		"inspect",
+		"--format",
+		"{{.State.Pid}}",
+		containerID,
+	)
+
+	output, err := inspectCmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,314 @@
+package launcher
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+)
+
+const (
+	metricsRoute             = "GET /metrics"
+	metricsReadHeaderTimeout = 5 * time.Second
+	launcherNetDevPath       = "/proc/net/dev"
+	loopbackInterface        = "lo"
+	netDevHeaderLines        = 2
+	netDevFields             = 16
+)
+
+// interfaceCounter maps a counter we report to its field in /proc/net/dev.
+type interfaceCounter struct {
+	name  string
+	help  string
+	field int
+}
+
+//nolint:gochecknoglobals,mnd
+var interfaceCounters = []interfaceCounter{
+	{name: "receive_bytes_total", help: "Number of bytes received", field: 0},
+	{name: "receive_packets_total", help: "Number of packets received", field: 1},
+	{name: "receive_errors_total", help: "Number of receive errors", field: 2},
+	{name: "receive_drops_total", help: "Number of dropped received packets", field: 3},
+	{name: "transmit_bytes_total", help: "Number of bytes transmitted", field: 8},
+	{name: "transmit_packets_total", help: "Number of packets transmitted", field: 9},
+	{name: "transmit_errors_total", help: "Number of transmit errors", field: 10},
+	{name: "transmit_drops_total", help: "Number of dropped transmitted packets", field: 11},
+}
+
+// serveMetrics runs the metrics server -- it serves the interface counters of the node container
+// as well as those of the tunnel interfaces the connectivity manager created.
+func (c *clabernetes) serveMetrics() {
+	registry := prometheus.NewRegistry()
+
+	err := registry.Register(newInterfaceCollector(c))
+	if err != nil {
+		c.logger.Warnf("failed registering interface metrics collector, error: %s", err)
+
+		return
+	}
+
+	mux := http.NewServeMux()
+
+	mux.Handle(metricsRoute, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		BaseContext: func(_ net.Listener) context.Context {
+			return c.ctx
+		},
+		Addr:              fmt.Sprintf(":%d", clabernetesconstants.LauncherMetricsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	go func() {
+		<-c.ctx.Done()
+
+		_ = server.Close()
+	}()
+
+	c.logger.Debugf("starting metrics server on port %d", clabernetesconstants.LauncherMetricsPort)
+
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		c.logger.Warnf("metrics server has failed, error: %s", err)
+	}
+}
+
+// interfaceCollector is a prometheus collector that reports the counters of the node container
+// interfaces and of the tunnel interfaces -- the counters are read from /proc/net/dev (of the
+// respective network namespace) at scrape time.
+type interfaceCollector struct {
+	c            *clabernetes
+	topologyName string
+
+	nodeInterfaceDescs   []*prometheus.Desc
+	tunnelInterfaceDescs []*prometheus.Desc
+}
+
+func newInterfaceCollector(c *clabernetes) *interfaceCollector {
+	collector := &interfaceCollector{
+		c:                    c,
+		topologyName:         os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+		nodeInterfaceDescs:   make([]*prometheus.Desc, len(interfaceCounters)),
+		tunnelInterfaceDescs: make([]*prometheus.Desc, len(interfaceCounters)),
+	}
+
+	for idx, counter := range interfaceCounters {
+		collector.nodeInterfaceDescs[idx] = prometheus.NewDesc(
+			prometheus.BuildFQName(
+				clabernetesconstants.Clabernetes,
+				"node_interface",
+				counter.name,
+			),
+			fmt.Sprintf("%s on an interface of the node container.", counter.help),
+			[]string{"topology", "node", "interface"},
+			nil,
+		)
+
+		collector.tunnelInterfaceDescs[idx] = prometheus.NewDesc(
+			prometheus.BuildFQName(
+				clabernetesconstants.Clabernetes,
+				"tunnel_interface",
+				counter.name,
+			),
+			fmt.Sprintf("%s on a tunnel interface towards a remote node.", counter.help),
+			[]string{"topology", "node", "interface", "remote_node"},
+			nil,
+		)
+	}
+
+	return collector
+}
+
+// Describe implements prometheus.Collector.
+func (ic *interfaceCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ic.nodeInterfaceDescs {
+		ch <- desc
+	}
+
+	for _, desc := range ic.tunnelInterfaceDescs {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (ic *interfaceCollector) Collect(ch chan<- prometheus.Metric) {
+	ic.collectNodeInterfaces(ch)
+	ic.collectTunnelInterfaces(ch)
+}
+
+func (ic *interfaceCollector) collectNodeInterfaces(ch chan<- prometheus.Metric) {
+	counters, skipInterfaces, err := ic.readNodeNetDev()
+	if err != nil {
+		ic.c.logger.Warnf(
+			"failed reading node %q interface counters, error: %s", ic.c.nodeName, err,
+		)
+
+		return
+	}
+
+	for interfaceName, fields := range counters {
+		if interfaceName == loopbackInterface || slices.Contains(skipInterfaces, interfaceName) {
+			continue
+		}
+
+		for idx, counter := range interfaceCounters {
+			ch <- prometheus.MustNewConstMetric(
+				ic.nodeInterfaceDescs[idx],
+				prometheus.CounterValue,
+				float64(fields[counter.field]),
+				ic.topologyName,
+				ic.c.nodeName,
+				interfaceName,
+			)
+		}
+	}
+}
+
+// readNodeNetDev reads the counters of the node interfaces, also returning the names of interfaces
+// that show up in there but are not node interfaces. In native mode the node shares the network
+// namespace with us, so the tunnel interfaces and the host ends of the node veths show up too.
+func (ic *interfaceCollector) readNodeNetDev() (map[string][]uint64, []string, error) {
+	if ic.c.nativeMode {
+		skipInterfaces := slices.Clone(ic.c.nativeModeHostInterfaces)
+
+		if ic.c.connectivityManager != nil {
+			skipInterfaces = slices.AppendSeq(
+				skipInterfaces,
+				maps.Keys(ic.c.connectivityManager.TunnelInterfaces()),
+			)
+		}
+
+		counters, err := readNetDev(launcherNetDevPath)
+
+		return counters, skipInterfaces, err
+	}
+
+	if ic.c.nodeContainerID == "" {
+		return nil, nil, nil
+	}
+
+	pid, err := getContainerPID(ic.c.nodeContainerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"%w: failed determining node container pid: %w", claberneteserrors.ErrLaunch, err,
+		)
+	}
+
+	// /proc/<pid>/net/dev shows the interfaces of the network namespace of the given process, so
+	// this gets us the counters of the node container interfaces without entering its namespace
+	counters, err := readNetDev(fmt.Sprintf("/proc/%s/net/dev", pid))
+
+	return counters, nil, err
+}
+
+func (ic *interfaceCollector) collectTunnelInterfaces(ch chan<- prometheus.Metric) {
+	if ic.c.connectivityManager == nil {
+		return
+	}
+
+	tunnelInterfaces := ic.c.connectivityManager.TunnelInterfaces()
+	if len(tunnelInterfaces) == 0 {
+		return
+	}
+
+	counters, err := readNetDev(launcherNetDevPath)
+	if err != nil {
+		ic.c.logger.Warnf("failed reading tunnel interface counters, error: %s", err)
+
+		return
+	}
+
+	for interfaceName, tunnel := range tunnelInterfaces {
+		fields, ok := counters[interfaceName]
+		if !ok {
+			continue
+		}
+
+		for idx, counter := range interfaceCounters {
+			ch <- prometheus.MustNewConstMetric(
+				ic.tunnelInterfaceDescs[idx],
+				prometheus.CounterValue,
+				float64(fields[counter.field]),
+				ic.topologyName,
+				tunnel.LocalNode,
+				tunnel.LocalInterface,
+				tunnel.RemoteNode,
+			)
+		}
+	}
+}
+
+// readNetDev reads the /proc/net/dev formatted file at the given path, returning the counter fields
+// keyed by interface name.
+func readNetDev(path string) (map[string][]uint64, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return parseNetDev(f)
+}
+
+func parseNetDev(r io.Reader) (map[string][]uint64, error) {
+	counters := make(map[string][]uint64)
+
+	scanner := bufio.NewScanner(r)
+
+	var lineNumber int
+
+	for scanner.Scan() {
+		lineNumber++
+
+		if lineNumber <= netDevHeaderLines {
+			continue
+		}
+
+		interfaceName, rawFields, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		rawCounters := strings.Fields(rawFields)
+		if len(rawCounters) != netDevFields {
+			return nil, fmt.Errorf(
+				"%w: unexpected number of interface counters for interface %q",
+				claberneteserrors.ErrParse,
+				strings.TrimSpace(interfaceName),
+			)
+		}
+
+		fields := make([]uint64, netDevFields)
+
+		for idx, rawCounter := range rawCounters {
+			var err error
+
+			fields[idx], err = strconv.ParseUint(rawCounter, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		counters[strings.TrimSpace(interfaceName)] = fields
+	}
+
+	return counters, scanner.Err()
+}
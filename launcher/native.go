@@ -0,0 +1,176 @@
+package launcher
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+)
+
+const (
+	// nativeModeNodeAddr is the address the node container can be reached at in native mode --
+	// all containers of a pod share the network namespace, so the node is just localhost to us.
+	nativeModeNodeAddr = "127.0.0.1"
+	vethEndpointCount  = 2
+)
+
+// nativeModeInterface is a node interface we plumb in native mode: a veth pair where one end is
+// the node interface and the other end is the "host" interface the connectivity manager attaches
+// the tunnel to -- exactly what containerlab would have set up for a host link.
+type nativeModeInterface struct {
+	nodeInterface string
+	hostInterface string
+	mac           string
+	mtu           int
+}
+
+// plumbNativeModeInterfaces creates the node interfaces in native mode. Without containerlab there
+// is nobody else to create them, and the node container is only started once we are done (we are
+// its sidecar, and it waits on our startup probe), so the node sees its interfaces when booting.
+func (c *clabernetes) plumbNativeModeInterfaces() {
+	c.logger.Debug("plumbing native mode node interfaces...")
+
+	containerlabConfig, err := loadLauncherContainerlabConfig()
+	if err != nil {
+		c.logger.Fatalf("failed loading containerlab config, err: %s", err)
+	}
+
+	for _, nodeInterface := range c.nativeModeInterfaces(containerlabConfig.Topology) {
+		err = c.plumbNativeModeInterface(nodeInterface)
+		if err != nil {
+			c.logger.Fatalf(
+				"failed plumbing node interface %q, err: %s", nodeInterface.nodeInterface, err,
+			)
+		}
+
+		c.nativeModeHostInterfaces = append(
+			c.nativeModeHostInterfaces,
+			nodeInterface.hostInterface,
+		)
+	}
+
+	c.logger.Debug("native mode node interfaces plumbed successfully")
+}
+
+// nativeModeInterfaces returns the interfaces of our node that are connected to the "host" in the
+// launcher topology -- links between nodes were all turned into host links when the topology was
+// split up between the launchers, so these are all the interfaces of the node.
+func (c *clabernetes) nativeModeInterfaces(
+	topology *clabernetesutilcontainerlab.Topology,
+) []nativeModeInterface {
+	var nodeInterfaces []nativeModeInterface
+
+	for _, link := range topology.Links {
+		if link == nil {
+			continue
+		}
+
+		endpoints, err := link.ResolveEndpoints()
+		if err != nil {
+			c.logger.Warnf("failed resolving link endpoints, skipping link, err: %s", err)
+
+			continue
+		}
+
+		switch {
+		case link.Type == clabernetesconstants.LinkTypeHost:
+			nodeInterfaces = append(nodeInterfaces, nativeModeInterface{
+				nodeInterface: endpoints[0].Interface,
+				hostInterface: link.HostInterface,
+				mac:           endpoints[0].MAC,
+				mtu:           link.MTU,
+			})
+		case (link.Type == "" || link.Type == clabernetesconstants.LinkTypeVeth) &&
+			len(endpoints) == vethEndpointCount:
+			nodeEndpoint, hostEndpoint := endpoints[0], endpoints[1]
+			if nodeEndpoint.Node == clabernetesconstants.HostKeyword {
+				nodeEndpoint, hostEndpoint = hostEndpoint, nodeEndpoint
+			}
+
+			if hostEndpoint.Node != clabernetesconstants.HostKeyword {
+				c.logger.Warnf(
+					"link between %q and %q is not a host link, cannot plumb it in native mode",
+					nodeEndpoint.Node,
+					hostEndpoint.Node,
+				)
+
+				continue
+			}
+
+			nodeInterfaces = append(nodeInterfaces, nativeModeInterface{
+				nodeInterface: nodeEndpoint.Interface,
+				hostInterface: hostEndpoint.Interface,
+				mac:           nodeEndpoint.MAC,
+				mtu:           link.MTU,
+			})
+		default:
+			c.logger.Warnf("cannot plumb link of type %q in native mode, skipping", link.Type)
+		}
+	}
+
+	return nodeInterfaces
+}
+
+func (c *clabernetes) plumbNativeModeInterface(nodeInterface nativeModeInterface) error {
+	_, err := os.Stat(fmt.Sprintf("/sys/class/net/%s", nodeInterface.nodeInterface))
+	if err == nil {
+		// the launcher (sidecar) was restarted, but the pod (and so the interface) is still there
+		c.logger.Debugf(
+			"node interface %q already exists, skipping", nodeInterface.nodeInterface,
+		)
+
+		return nil
+	}
+
+	args := []string{"link", "add", nodeInterface.nodeInterface}
+
+	if nodeInterface.mac != "" {
+		args = append(args, "address", nodeInterface.mac)
+	}
+
+	if nodeInterface.mtu != 0 {
+		args = append(args, "mtu", strconv.Itoa(nodeInterface.mtu))
+	}
+
+	args = append(args, "type", "veth", "peer", "name", nodeInterface.hostInterface)
+
+	if nodeInterface.mtu != 0 {
+		args = append(args, "mtu", strconv.Itoa(nodeInterface.mtu))
+	}
+
+	for _, cmdArgs := range [][]string{
+		args,
+		{"link", "set", nodeInterface.nodeInterface, "up"},
+		{"link", "set", nodeInterface.hostInterface, "up"},
+	} {
+		cmd := exec.Command("ip", cmdArgs...) //nolint:gosec
+
+		cmd.Stdout = c.logger
+		cmd.Stderr = c.logger
+
+		err = cmd.Run()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nativeModeReady writes the native mode ready file -- the startup probe of the launcher (sidecar)
+// checks for this file, and the node container is only started once that probe succeeds.
+func (c *clabernetes) nativeModeReady() {
+	err := os.WriteFile(
+		clabernetesconstants.NativeModeReadyFile,
+		nil,
+		clabernetesconstants.PermissionsEveryoneAllPermissions,
+	)
+	if err != nil {
+		c.logger.Fatalf("failed writing native mode ready file, err: %s", err)
+	}
+
+	c.logger.Info("native mode interfaces and connectivity ready, node can start")
+}
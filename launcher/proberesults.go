@@ -0,0 +1,92 @@
+package launcher
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+)
+
+// probeResultsRefreshInterval is how often we report the probe results even if nothing but the
+// last success time changed -- this keeps us from patching the probe results (and so waking up
+// the controller) on every probe run while everything is healthy.
+const probeResultsRefreshInterval = 5 * time.Minute
+
+// updateProbeResult records the outcome of a run of a probe in the given result, returning true if
+// anything other than the timestamps changed, i.e. if the result is worth reporting right away.
+func updateProbeResult(probeResult *clabernetesapisv1alpha1.ProbeResult, probeErr error) bool {
+	previousProbeResult := *probeResult
+
+	now := metav1.Now()
+
+	probeResult.LastUpdateTime = now
+
+	if probeErr == nil {
+		probeResult.Result = clabernetesconstants.ProbeResultSuccess
+		probeResult.LastSuccessTime = &now
+		probeResult.ConsecutiveFailures = 0
+		probeResult.LastError = ""
+	} else {
+		probeResult.Result = clabernetesconstants.ProbeResultFailure
+		probeResult.ConsecutiveFailures++
+		probeResult.LastError = probeErr.Error()
+	}
+
+	return previousProbeResult.Result != probeResult.Result ||
+		previousProbeResult.ConsecutiveFailures != probeResult.ConsecutiveFailures ||
+		previousProbeResult.LastError != probeResult.LastError
+}
+
+// reportProbeResults pushes the probe results of the given node to the probe results ConfigMap of
+// the topology, from where the controller copies them to the status of the topology. The results
+// are keyed by node name and we merge patch only the entry of our own node, so launchers never
+// step on each other. We deliberately don't report to the connectivity cr since every launcher
+// watches that, and would be woken up by every report. Failing to report is not fatal, we just
+// complain about it.
+func (c *clabernetes) reportProbeResults(
+	nodeName string,
+	probeResults []*clabernetesapisv1alpha1.ProbeResult,
+) {
+	rawProbeResults, err := json.Marshal(probeResults)
+	if err != nil {
+		c.logger.Warnf("failed marshaling probe results, error: %s", err)
+
+		return
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"data": map[string]string{
+			nodeName: string(rawProbeResults),
+		},
+	})
+	if err != nil {
+		c.logger.Warnf("failed marshaling probe results patch, error: %s", err)
+
+		return
+	}
+
+	_, err = c.kubeClient.CoreV1().
+		ConfigMaps(os.Getenv(clabernetesconstants.PodNamespaceEnv)).
+		Patch(
+			c.ctx,
+			clabernetesutilkubernetes.SafeConcatNameKubernetes(
+				os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+				clabernetesconstants.ProbeResultsConfigMapSuffix,
+			),
+			apimachinerytypes.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+	if err != nil {
+		c.logger.Warnf("failed reporting probe results, error: %s", err)
+
+		return
+	}
+
+	c.logger.Debugf("reported node %q probe results", nodeName)
+}
@@ -0,0 +1,666 @@
+package launcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	defaultHTTPStatusCode  = 200
+	execProbeCheckTimeout  = 15 * time.Second
+	probeMaxResponseSize   = 1024 * 1024
+	probeMaxErrorOutputLen = 256
+	grpcFrameHeaderLen     = 5
+	netconfMessageEnd      = "]]>]]>"
+	netconfClientHelloBody = `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>urn:ietf:params:netconf:base:1.0</capability>` +
+		`</capabilities></hello>`
+)
+
+// statusProbe is a single configured status probe, the probe func accepts the address of the node
+// and returns an error describing why the probe failed, or nil if it succeeded.
+type statusProbe struct {
+	name  string
+	probe func(nodeAddr string) error
+}
+
+// statusProbes returns the status probes configured (via env vars) for the launcher.
+func (c *clabernetes) statusProbes() []statusProbe {
+	var probes []statusProbe
+
+	tcpProbePort := clabernetesutil.GetEnvIntOrDefault(clabernetesconstants.LauncherTCPProbePort, 0)
+	if tcpProbePort != 0 {
+		c.logger.Debugf("will run tcp status probe to port %d", tcpProbePort)
+
+		probes = append(probes, statusProbe{
+			name: "tcp",
+			probe: func(nodeAddr string) error {
+				return probeTCP(nodeAddr, tcpProbePort)
+			},
+		})
+	}
+
+	probes = append(probes, c.sshStatusProbes()...)
+
+	if os.Getenv(clabernetesconstants.LauncherGNMIProbePort) != "" {
+		probes = append(probes, c.gnmiStatusProbe())
+	}
+
+	if os.Getenv(clabernetesconstants.LauncherHTTPProbePort) != "" {
+		probes = append(probes, c.httpStatusProbe())
+	}
+
+	if os.Getenv(clabernetesconstants.LauncherExecProbeCommand) != "" {
+		probes = append(probes, c.execStatusProbe())
+	}
+
+	return probes
+}
+
+// sshStatusProbes returns the configured ssh based status probes -- that is the plain ssh probe
+// and the netconf probe. Either only runs if there is a username and something to authenticate
+// with.
+func (c *clabernetes) sshStatusProbes() []statusProbe {
+	var probes []statusProbe
+
+	sshProbeUsername := os.Getenv(clabernetesconstants.LauncherSSHProbeUsername)
+
+	sshProbeAuthMethods := c.sshProbeAuthMethods(
+		os.Getenv(clabernetesconstants.LauncherSSHProbePassword),
+		os.Getenv(clabernetesconstants.LauncherSSHProbePrivateKey),
+	)
+
+	if sshProbeUsername != "" && len(sshProbeAuthMethods) > 0 {
+		sshProbePort := clabernetesutil.GetEnvIntOrDefault(
+			clabernetesconstants.LauncherSSHProbePort,
+			defaultSSHPort,
+		)
+
+		c.logger.Debugf(
+			"will run ssh status probe using username %s to port %d",
+			sshProbeUsername,
+			sshProbePort,
+		)
+
+		probes = append(probes, statusProbe{
+			name: "ssh",
+			probe: func(nodeAddr string) error {
+				return probeSSH(sshProbePort, nodeAddr, sshProbeUsername, sshProbeAuthMethods)
+			},
+		})
+	}
+
+	netconfProbeUsername := os.Getenv(clabernetesconstants.LauncherNETCONFProbeUsername)
+
+	netconfProbeAuthMethods := c.sshProbeAuthMethods(
+		os.Getenv(clabernetesconstants.LauncherNETCONFProbePassword),
+		os.Getenv(clabernetesconstants.LauncherNETCONFProbePrivateKey),
+	)
+
+	if netconfProbeUsername != "" && len(netconfProbeAuthMethods) > 0 {
+		netconfProbePort := clabernetesutil.GetEnvIntOrDefault(
+			clabernetesconstants.LauncherNETCONFProbePort,
+			clabernetesconstants.PortNETCONF,
+		)
+
+		c.logger.Debugf(
+			"will run netconf status probe using username %s to port %d",
+			netconfProbeUsername,
+			netconfProbePort,
+		)
+
+		probes = append(probes, statusProbe{
+			name: "netconf",
+			probe: func(nodeAddr string) error {
+				return probeNETCONF(
+					netconfProbePort,
+					nodeAddr,
+					netconfProbeUsername,
+					netconfProbeAuthMethods,
+				)
+			},
+		})
+	}
+
+	return probes
+}
+
+func (c *clabernetes) gnmiStatusProbe() statusProbe {
+	gnmiProbePort := clabernetesutil.GetEnvIntOrDefault(
+		clabernetesconstants.LauncherGNMIProbePort,
+		clabernetesconstants.PortGNMINokia,
+	)
+
+	gnmiProbeInsecure := os.Getenv(
+		clabernetesconstants.LauncherGNMIProbeInsecure,
+	) == clabernetesconstants.True
+
+	gnmiProbeUsername := os.Getenv(clabernetesconstants.LauncherGNMIProbeUsername)
+
+	gnmiProbePassword := os.Getenv(clabernetesconstants.LauncherGNMIProbePassword)
+
+	c.logger.Debugf("will run gnmi status probe to port %d", gnmiProbePort)
+
+	return statusProbe{
+		name: "gnmi",
+		probe: func(nodeAddr string) error {
+			return probeGNMI(
+				gnmiProbePort,
+				nodeAddr,
+				gnmiProbeInsecure,
+				gnmiProbeUsername,
+				gnmiProbePassword,
+			)
+		},
+	}
+}
+
+func (c *clabernetes) httpStatusProbe() statusProbe {
+	httpProbePort := clabernetesutil.GetEnvIntOrDefault(
+		clabernetesconstants.LauncherHTTPProbePort,
+		0,
+	)
+
+	httpProbeScheme := strings.ToLower(
+		clabernetesutil.GetEnvStrOrDefault(clabernetesconstants.LauncherHTTPProbeScheme, "http"),
+	)
+
+	httpProbePath := clabernetesutil.GetEnvStrOrDefault(
+		clabernetesconstants.LauncherHTTPProbePath,
+		"/",
+	)
+
+	httpProbeStatusCode := clabernetesutil.GetEnvIntOrDefault(
+		clabernetesconstants.LauncherHTTPProbeStatusCode,
+		defaultHTTPStatusCode,
+	)
+
+	httpProbeBodyRegex, httpProbeBodyRegexErr := c.compileProbeRegex(
+		"http",
+		os.Getenv(clabernetesconstants.LauncherHTTPProbeBodyRegex),
+	)
+
+	c.logger.Debugf(
+		"will run http status probe to %s port %d path %s",
+		httpProbeScheme,
+		httpProbePort,
+		httpProbePath,
+	)
+
+	return statusProbe{
+		name: "http",
+		probe: func(nodeAddr string) error {
+			if httpProbeBodyRegexErr != nil {
+				return httpProbeBodyRegexErr
+			}
+
+			return probeHTTP(
+				fmt.Sprintf(
+					"%s://%s%s",
+					httpProbeScheme,
+					net.JoinHostPort(nodeAddr, strconv.Itoa(httpProbePort)),
+					httpProbePath,
+				),
+				httpProbeStatusCode,
+				httpProbeBodyRegex,
+			)
+		},
+	}
+}
+
+func (c *clabernetes) execStatusProbe() statusProbe {
+	var execProbeCommand []string
+
+	execProbeCommandErr := json.Unmarshal(
+		[]byte(os.Getenv(clabernetesconstants.LauncherExecProbeCommand)),
+		&execProbeCommand,
+	)
+	if execProbeCommandErr == nil && len(execProbeCommand) == 0 {
+		execProbeCommandErr = fmt.Errorf(
+			"%w: exec probe command is empty",
+			claberneteserrors.ErrProbe,
+		)
+	}
+
+	if c.nativeMode {
+		// there is no docker to exec with, and we cannot exec in the node container ourselves
+		execProbeCommandErr = fmt.Errorf(
+			"%w: exec probes are not supported for native mode nodes",
+			claberneteserrors.ErrProbe,
+		)
+	}
+
+	if execProbeCommandErr != nil {
+		c.logger.Warnf(
+			"failed parsing exec status probe command, probe will fail, err: %s",
+			execProbeCommandErr,
+		)
+	}
+
+	execProbeOutputRegex, execProbeOutputRegexErr := c.compileProbeRegex(
+		"exec",
+		os.Getenv(clabernetesconstants.LauncherExecProbeOutputRegex),
+	)
+
+	c.logger.Debugf("will run exec status probe command %q", execProbeCommand)
+
+	return statusProbe{
+		name: "exec",
+		probe: func(_ string) error {
+			if execProbeCommandErr != nil {
+				return execProbeCommandErr
+			}
+
+			if execProbeOutputRegexErr != nil {
+				return execProbeOutputRegexErr
+			}
+
+			return probeExec(c.nodeContainerID, execProbeCommand, execProbeOutputRegex)
+		},
+	}
+}
+
+// compileProbeRegex compiles the given (possibly empty) probe regex, an empty regex compiles to a
+// nil regex. If the regex is invalid we log and return the error, that probe then always fails.
+func (c *clabernetes) compileProbeRegex(probeName, rawRegex string) (*regexp.Regexp, error) {
+	if rawRegex == "" {
+		return nil, nil
+	}
+
+	compiled, err := regexp.Compile(rawRegex)
+	if err != nil {
+		c.logger.Warnf(
+			"failed compiling %s status probe regex %q, probe will fail, err: %s",
+			probeName,
+			rawRegex,
+			err,
+		)
+
+		return nil, fmt.Errorf(
+			"%w: invalid %s probe regex %q: %w",
+			claberneteserrors.ErrProbe,
+			probeName,
+			rawRegex,
+			err,
+		)
+	}
+
+	return compiled, nil
+}
+
+// sshProbeAuthMethods returns the ssh auth methods for the given password and (pem encoded)
+// private key, either of which may be empty. A private key we cannot parse is logged and skipped.
+func (c *clabernetes) sshProbeAuthMethods(password, privateKey string) []ssh.AuthMethod {
+	var authMethods []ssh.AuthMethod
+
+	if privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			c.logger.Warnf("failed parsing probe private key, ignoring it, err: %s", err)
+		} else {
+			authMethods = append(authMethods, ssh.PublicKeys(signer))
+		}
+	}
+
+	if password != "" {
+		authMethods = append(
+			authMethods,
+			ssh.Password(password),
+			ssh.KeyboardInteractive(
+				func(_, _ string, questions []string, _ []bool) ([]string, error) {
+					answers := make([]string, len(questions))
+					for i := range answers {
+						answers[i] = password
+					}
+
+					return answers, nil
+				},
+			),
+		)
+	}
+
+	return authMethods
+}
+
+func probeTCP(nodeAddr string, port int) error {
+	dialer := net.Dialer{
+		Timeout: statusProbeCheckTimeout,
+	}
+
+	tcpConn, err := dialer.Dial(
+		"tcp",
+		net.JoinHostPort(nodeAddr, strconv.Itoa(port)),
+	)
+	if err != nil {
+		return err
+	}
+
+	_ = tcpConn.Close()
+
+	return nil
+}
+
+func sshProbeClientConfig(username string, authMethods []ssh.AuthMethod) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User:            username,
+		Auth:            authMethods,
+		Timeout:         statusProbeCheckTimeout,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+	}
+}
+
+func probeSSH(port int, nodeAddr, username string, authMethods []ssh.AuthMethod) error {
+	conn, err := ssh.Dial(
+		"tcp",
+		net.JoinHostPort(nodeAddr, strconv.Itoa(port)),
+		sshProbeClientConfig(username, authMethods),
+	)
+	if err != nil {
+		return err
+	}
+
+	_ = conn.Close()
+
+	return nil
+}
+
+// probeNETCONF opens the netconf subsystem and waits for the hello of the node, answering with our
+// own hello. The whole exchange has to happen within the probe timeout.
+func probeNETCONF(port int, nodeAddr, username string, authMethods []ssh.AuthMethod) error {
+	addr := net.JoinHostPort(nodeAddr, strconv.Itoa(port))
+
+	netConn, err := net.DialTimeout("tcp", addr, statusProbeCheckTimeout)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = netConn.Close()
+	}()
+
+	err = netConn.SetDeadline(time.Now().Add(statusProbeCheckTimeout))
+	if err != nil {
+		return err
+	}
+
+	sshConn, sshChannels, sshRequests, err := ssh.NewClientConn(
+		netConn,
+		addr,
+		sshProbeClientConfig(username, authMethods),
+	)
+	if err != nil {
+		return err
+	}
+
+	client := ssh.NewClient(sshConn, sshChannels, sshRequests)
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	err = session.RequestSubsystem("netconf")
+	if err != nil {
+		return err
+	}
+
+	hello, err := readNETCONFMessage(stdout)
+	if err != nil {
+		return fmt.Errorf("%w: failed reading netconf hello: %w", claberneteserrors.ErrProbe, err)
+	}
+
+	if !bytes.Contains(hello, []byte("capabilities")) {
+		return fmt.Errorf("%w: netconf hello has no capabilities", claberneteserrors.ErrProbe)
+	}
+
+	_, err = io.WriteString(stdin, netconfClientHelloBody+netconfMessageEnd)
+
+	return err
+}
+
+// readNETCONFMessage reads a single (end of message delimited, as hellos always are) netconf
+// message.
+func readNETCONFMessage(r io.Reader) ([]byte, error) {
+	reader := bufio.NewReader(io.LimitReader(r, probeMaxResponseSize))
+
+	var message []byte
+
+	for {
+		chunk, err := reader.ReadBytes(netconfMessageEnd[len(netconfMessageEnd)-1])
+		message = append(message, chunk...)
+
+		if bytes.HasSuffix(message, []byte(netconfMessageEnd)) {
+			return bytes.TrimSuffix(message, []byte(netconfMessageEnd)), nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// probeGNMI sends a gNMI Capabilities RPC to the node. Rather than pulling in all of grpc for a
+// single unary call with an empty request we speak just enough grpc over plain http/2 -- the
+// CapabilityRequest is an empty message, so the request body is only the grpc frame header, and
+// the call succeeded if the grpc-status is zero.
+func probeGNMI(port int, nodeAddr string, insecure bool, username, password string) error {
+	protocols := &http.Protocols{}
+
+	scheme := "https"
+
+	if insecure {
+		protocols.SetUnencryptedHTTP2(true)
+
+		scheme = "http"
+	} else {
+		protocols.SetHTTP2(true)
+	}
+
+	client := &http.Client{
+		Timeout: statusProbeCheckTimeout,
+		Transport: &http.Transport{
+			Protocols: protocols,
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, //nolint:gosec
+			},
+		},
+	}
+
+	defer client.CloseIdleConnections()
+
+	request, err := http.NewRequestWithContext(
+		context.Background(),
+		http.MethodPost,
+		fmt.Sprintf(
+			"%s://%s/gnmi.gNMI/Capabilities",
+			scheme,
+			net.JoinHostPort(nodeAddr, strconv.Itoa(port)),
+		),
+		bytes.NewReader(make([]byte, grpcFrameHeaderLen)),
+	)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/grpc")
+	request.Header.Set("Te", "trailers")
+
+	if username != "" {
+		request.Header.Set("Username", username)
+	}
+
+	if password != "" {
+		request.Header.Set("Password", password)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// trailers are only populated once the body has been read
+	_, err = io.Copy(io.Discard, io.LimitReader(response.Body, probeMaxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"%w: unexpected gnmi http status code %d",
+			claberneteserrors.ErrProbe,
+			response.StatusCode,
+		)
+	}
+
+	grpcStatus := response.Trailer.Get("Grpc-Status")
+	if grpcStatus == "" {
+		// "trailers only" responses (i.e. errors) carry the status in the headers
+		grpcStatus = response.Header.Get("Grpc-Status")
+	}
+
+	if grpcStatus != "0" {
+		return fmt.Errorf(
+			"%w: gnmi capabilities failed with grpc status %q: %s",
+			claberneteserrors.ErrProbe,
+			grpcStatus,
+			response.Trailer.Get("Grpc-Message")+response.Header.Get("Grpc-Message"),
+		)
+	}
+
+	return nil
+}
+
+func probeHTTP(url string, statusCode int, bodyRegex *regexp.Regexp) error {
+	client := &http.Client{
+		Timeout: statusProbeCheckTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, //nolint:gosec
+			},
+		},
+	}
+
+	defer client.CloseIdleConnections()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != statusCode {
+		return fmt.Errorf(
+			"%w: unexpected http status code %d, expected %d",
+			claberneteserrors.ErrProbe,
+			response.StatusCode,
+			statusCode,
+		)
+	}
+
+	if bodyRegex == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, probeMaxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if !bodyRegex.Match(body) {
+		return fmt.Errorf(
+			"%w: http body does not match %q",
+			claberneteserrors.ErrProbe,
+			bodyRegex.String(),
+		)
+	}
+
+	return nil
+}
+
+func probeExec(containerID string, command []string, outputRegex *regexp.Regexp) error {
+	ctx, cancel := context.WithTimeout(context.Background(), execProbeCheckTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext( //nolint:gosec
+		ctx,
+		"docker",
+		slices.Concat([]string{"exec", containerID}, command)...,
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf(
+			"%w: exec probe command failed: %w, output: %s",
+			claberneteserrors.ErrProbe,
+			err,
+			truncateProbeOutput(output),
+		)
+	}
+
+	if outputRegex != nil && !outputRegex.Match(output) {
+		return fmt.Errorf(
+			"%w: exec probe output does not match %q, output: %s",
+			claberneteserrors.ErrProbe,
+			outputRegex.String(),
+			truncateProbeOutput(output),
+		)
+	}
+
+	return nil
+}
+
+// truncateProbeOutput trims (exec) probe output to something reasonable to stick in an error (and
+// so eventually in the status of the topology).
+func truncateProbeOutput(output []byte) string {
+	trimmed := strings.TrimSpace(string(output))
+
+	if len(trimmed) > probeMaxErrorOutputLen {
+		return trimmed[:probeMaxErrorOutputLen] + "..."
+	}
+
+	return trimmed
+}
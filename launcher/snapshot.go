@@ -0,0 +1,405 @@
+package launcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	clabernetesutilkubernetes "github.com/srl-labs/clabernetes/util/kubernetes"
+	k8scorev1 "k8s.io/api/core/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	apimachinerywatch "k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	snapshotSaveTimeout   = 2 * time.Minute
+	snapshotRewatchPeriod = 5 * time.Second
+)
+
+// savedConfigPath returns the path (relative to the node's lab directory) that containerlab save
+// writes the configuration of nodes of the given kind to, or an empty string if we don't know it.
+func savedConfigPath(containerlabKind string) string {
+	switch containerlabKind {
+	case "srl", "nokia_srlinux":
+		return "config/config.json"
+	case "ceos", "arista_ceos":
+		return "flash/startup-config"
+	case "crpd", "juniper_crpd":
+		return "config/juniper.conf"
+	default:
+		return ""
+	}
+}
+
+// launcherCreationTime returns the creation time of our launcher deployment -- that is when our
+// node(s) came to be as far as snapshots are concerned, unlike the pod this does not change when
+// the launcher restarts.
+func (c *clabernetes) launcherCreationTime(namespace, topologyName string) (metav1.Time, error) {
+	deployments, err := c.kubeClient.AppsV1().Deployments(namespace).List(
+		c.ctx,
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf(
+				"%s=%s,%s=%s",
+				clabernetesconstants.LabelTopologyOwner,
+				topologyName,
+				clabernetesconstants.LabelTopologyNode,
+				c.nodeName,
+			),
+		},
+	)
+	if err != nil {
+		return metav1.Time{}, err
+	}
+
+	if len(deployments.Items) != 1 {
+		return metav1.Time{}, fmt.Errorf(
+			"%w: expected exactly one launcher deployment, found %d",
+			claberneteserrors.ErrSnapshot,
+			len(deployments.Items),
+		)
+	}
+
+	return deployments.Items[0].CreationTimestamp, nil
+}
+
+// watchSnapshots watches for TopologySnapshots of our topology and captures the configuration of
+// our node(s) for each one we have not reported on yet. Snapshots are handled one at a time, in
+// the order we see them. Snapshots that are already complete or that were taken before our
+// launcher existed are none of our business -- saving into those would overwrite what was captured
+// back then with our current configuration.
+func (c *clabernetes) watchSnapshots() {
+	namespace := os.Getenv(clabernetesconstants.PodNamespaceEnv)
+	topologyName := os.Getenv(clabernetesconstants.LauncherTopologyNameEnv)
+
+	var launcherCreated *metav1.Time
+
+	for {
+		if launcherCreated == nil {
+			creationTime, err := c.launcherCreationTime(namespace, topologyName)
+			if err != nil {
+				c.logger.Warnf("failed fetching launcher creation time, err: %s", err)
+			} else {
+				launcherCreated = &creationTime
+			}
+		}
+
+		if launcherCreated != nil {
+			c.watchSnapshotsOnce(namespace, topologyName, launcherCreated)
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(snapshotRewatchPeriod):
+		}
+	}
+}
+
+// watchSnapshotsOnce runs a single snapshot watch, handling snapshots until the watch ends.
+func (c *clabernetes) watchSnapshotsOnce(
+	namespace, topologyName string,
+	launcherCreated *metav1.Time,
+) {
+	watch, err := c.kubeClabernetesClient.ClabernetesV1alpha1().
+		TopologySnapshots(namespace).
+		Watch(c.ctx, metav1.ListOptions{Watch: true})
+	if err != nil {
+		c.logger.Warnf("failed watching topology snapshots, err: %s", err)
+
+		return
+	}
+
+	for event := range watch.ResultChan() {
+		// existing snapshots are sent as added events when the watch starts, so we'll see
+		// snapshots created while we were not watching too; modifications are just launchers
+		// reporting status so we ignore those
+		if event.Type != apimachinerywatch.Added {
+			continue
+		}
+
+		snapshot, ok := event.Object.(*clabernetesapisv1alpha1.TopologySnapshot)
+		if !ok || snapshot.Spec.TopologyName != topologyName {
+			continue
+		}
+
+		if snapshot.Status.Complete || snapshot.CreationTimestamp.Before(launcherCreated) {
+			continue
+		}
+
+		c.handleSnapshot(snapshot)
+	}
+}
+
+func (c *clabernetes) handleSnapshot(snapshot *clabernetesapisv1alpha1.TopologySnapshot) {
+	containerlabConfig, err := loadLauncherContainerlabConfig()
+	if err != nil {
+		c.logger.Warnf(
+			"failed loading containerlab config, cannot handle snapshot %q, err: %s",
+			snapshot.Name,
+			err,
+		)
+
+		return
+	}
+
+	var pendingNodeNames []string
+
+	for nodeName := range containerlabConfig.Topology.Nodes {
+		_, reported := snapshot.Status.Nodes[nodeName]
+		if !reported {
+			pendingNodeNames = append(pendingNodeNames, nodeName)
+		}
+	}
+
+	if len(pendingNodeNames) == 0 {
+		return
+	}
+
+	c.logger.Infof("saving node configuration(s) for snapshot %q...", snapshot.Name)
+
+	saveStart := time.Now()
+
+	ctx, cancel := context.WithTimeout(c.ctx, snapshotSaveTimeout)
+	defer cancel()
+
+	var saveErr error
+
+	if c.nativeMode {
+		// still report in so the snapshot can complete rather than waiting on us forever
+		saveErr = fmt.Errorf(
+			"%w: snapshots are not supported for native mode nodes",
+			claberneteserrors.ErrSnapshot,
+		)
+	} else {
+		saveErr = c.runContainerlabSave(ctx)
+	}
+
+	for _, nodeName := range pendingNodeNames {
+		nodeStatus := clabernetesapisv1alpha1.NodeSnapshotStatus{
+			State: clabernetesconstants.SnapshotStateSaved,
+		}
+
+		err = saveErr
+		if err == nil {
+			nodeStatus.ConfigMapName, err = c.storeNodeSnapshot(
+				snapshot,
+				containerlabConfig,
+				nodeName,
+				saveStart,
+			)
+		}
+
+		if err != nil {
+			c.logger.Warnf(
+				"failed capturing configuration of node %q for snapshot %q, err: %s",
+				nodeName,
+				snapshot.Name,
+				err,
+			)
+
+			nodeStatus.State = clabernetesconstants.SnapshotStateFailed
+			nodeStatus.ConfigMapName = ""
+			nodeStatus.LastError = err.Error()
+		}
+
+		nodeStatus.LastUpdateTime = metav1.Now()
+
+		c.reportNodeSnapshotStatus(snapshot.Name, nodeName, nodeStatus)
+	}
+
+	c.logger.Infof("snapshot %q handled", snapshot.Name)
+}
+
+func loadLauncherContainerlabConfig() (*clabernetesutilcontainerlab.Config, error) {
+	rawConfig, err := os.ReadFile("topo.clab.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		string(rawConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if containerlabConfig.Topology == nil {
+		return nil, fmt.Errorf(
+			"%w: containerlab config has no topology", claberneteserrors.ErrSnapshot,
+		)
+	}
+
+	return containerlabConfig, nil
+}
+
+// findSavedNodeConfig returns the path of the configuration containerlab save wrote for the given
+// node. For kinds we know we look where that kind saves its configuration, for everything else we
+// take the newest file in the node's lab directory that was written since we started saving.
+func findSavedNodeConfig(
+	containerlabConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+	saveStart time.Time,
+) (string, error) {
+	nodeDir := filepath.Join(fmt.Sprintf("clab-%s", containerlabConfig.Name), nodeName)
+
+	containerlabKind, _ := containerlabConfig.Topology.GetNodeKindType(nodeName)
+
+	knownPath := savedConfigPath(containerlabKind)
+	if knownPath != "" {
+		return filepath.Join(nodeDir, knownPath), nil
+	}
+
+	var newestPath string
+
+	var newestModTime time.Time
+
+	err := filepath.WalkDir(nodeDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.ModTime().Before(saveStart) || !info.ModTime().After(newestModTime) {
+			return nil
+		}
+
+		newestPath = path
+		newestModTime = info.ModTime()
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if newestPath == "" {
+		return "", fmt.Errorf(
+			"%w: no saved configuration found for node %q of kind %q",
+			claberneteserrors.ErrSnapshot,
+			nodeName,
+			containerlabKind,
+		)
+	}
+
+	return newestPath, nil
+}
+
+// storeNodeSnapshot stores the saved configuration of the given node in a ConfigMap owned by the
+// snapshot, returning the name of the ConfigMap.
+func (c *clabernetes) storeNodeSnapshot(
+	snapshot *clabernetesapisv1alpha1.TopologySnapshot,
+	containerlabConfig *clabernetesutilcontainerlab.Config,
+	nodeName string,
+	saveStart time.Time,
+) (string, error) {
+	savedConfigFile, err := findSavedNodeConfig(containerlabConfig, nodeName, saveStart)
+	if err != nil {
+		return "", err
+	}
+
+	savedConfig, err := os.ReadFile(savedConfigFile) //nolint:gosec
+	if err != nil {
+		return "", err
+	}
+
+	configMap := &k8scorev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clabernetesutilkubernetes.SafeConcatNameKubernetes(snapshot.Name, nodeName),
+			Namespace: snapshot.Namespace,
+			Labels: map[string]string{
+				clabernetesconstants.LabelApp:              c.appName,
+				clabernetesconstants.LabelTopologySnapshot: snapshot.Name,
+				clabernetesconstants.LabelTopologyNode:     nodeName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clabernetesapisv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "TopologySnapshot",
+					Name:       snapshot.Name,
+					UID:        snapshot.UID,
+				},
+			},
+		},
+		Data: map[string]string{
+			clabernetesconstants.SnapshotConfigKey: string(savedConfig),
+		},
+	}
+
+	configMaps := c.kubeClient.CoreV1().ConfigMaps(snapshot.Namespace)
+
+	_, err = configMaps.Create(c.ctx, configMap, metav1.CreateOptions{})
+	if err == nil {
+		return configMap.Name, nil
+	}
+
+	if !apimachineryerrors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	// we may have stored the config before failing to report it, in that case just overwrite it
+	existingConfigMap, err := configMaps.Get(c.ctx, configMap.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	existingConfigMap.Data = configMap.Data
+
+	_, err = configMaps.Update(c.ctx, existingConfigMap, metav1.UpdateOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return configMap.Name, nil
+}
+
+// reportNodeSnapshotStatus merge patches the status of the given node into the snapshot, as with
+// tunnel status we only ever patch our own node(s) entries so launchers don't clobber each other.
+func (c *clabernetes) reportNodeSnapshotStatus(
+	snapshotName, nodeName string,
+	nodeStatus clabernetesapisv1alpha1.NodeSnapshotStatus,
+) {
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"nodes": map[string]any{
+				nodeName: nodeStatus,
+			},
+		},
+	})
+	if err != nil {
+		c.logger.Warnf("failed marshaling snapshot status patch, err: %s", err)
+
+		return
+	}
+
+	_, err = c.kubeClabernetesClient.ClabernetesV1alpha1().
+		TopologySnapshots(os.Getenv(clabernetesconstants.PodNamespaceEnv)).
+		Patch(
+			c.ctx,
+			snapshotName,
+			apimachinerytypes.MergePatchType,
+			patch,
+			metav1.PatchOptions{},
+		)
+	if err != nil {
+		c.logger.Warnf(
+			"failed reporting node %q status to snapshot %q, err: %s",
+			nodeName,
+			snapshotName,
+			err,
+		)
+	}
+}
@@ -0,0 +1,86 @@
+package launcher
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const waitForCheckInterval = 10 * time.Second
+
+// waitForNodes blocks until the nodes in other launchers that our node(s) wait for (via the
+// containerlab wait-for setting) are reported ready in the node readiness of our topology.
+// Containerlab can only enforce wait-for within a single deployment, so this is how we extend that
+// across launchers -- once we launch containerlab still applies any startup-delay of the node(s)
+// on top. If the nodes are not ready within the wait for timeout we log it and launch anyway,
+// better a node that comes up too early than one that never comes up at all.
+func (c *clabernetes) waitForNodes() {
+	waitFor := os.Getenv(clabernetesconstants.LauncherWaitFor)
+	if waitFor == "" {
+		return
+	}
+
+	waitForNodeNames := strings.Split(waitFor, ",")
+
+	c.logger.Infof("waiting for node(s) %q to be ready before launching...", waitForNodeNames)
+
+	ctx, cancel := context.WithTimeout(c.ctx, clabernetesconstants.LauncherWaitForTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(waitForCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		notReadyNodeNames := c.notReadyNodes(ctx, waitForNodeNames)
+		if len(notReadyNodeNames) == 0 {
+			c.logger.Info("all waited for node(s) ready, continuing...")
+
+			return
+		}
+
+		c.logger.Debugf("still waiting for node(s) %q to be ready", notReadyNodeNames)
+
+		select {
+		case <-ctx.Done():
+			c.logger.Warnf(
+				"node(s) %q not ready after %s, launching anyway",
+				notReadyNodeNames,
+				clabernetesconstants.LauncherWaitForTimeout,
+			)
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notReadyNodes returns the given node names that are not (yet) reported ready in the node
+// readiness of our topology -- if fetching the topology fails all nodes count as not ready.
+func (c *clabernetes) notReadyNodes(ctx context.Context, nodeNames []string) []string {
+	topology, err := c.kubeClabernetesClient.ClabernetesV1alpha1().
+		Topologies(os.Getenv(clabernetesconstants.PodNamespaceEnv)).
+		Get(
+			ctx,
+			os.Getenv(clabernetesconstants.LauncherTopologyNameEnv),
+			metav1.GetOptions{},
+		)
+	if err != nil {
+		c.logger.Warnf("failed fetching topology to check node readiness, err: %s", err)
+
+		return nodeNames
+	}
+
+	var notReadyNodeNames []string
+
+	for _, nodeName := range nodeNames {
+		if topology.Status.NodeReadiness[nodeName] != clabernetesconstants.NodeStatusReady {
+			notReadyNodeNames = append(notReadyNodeNames, nodeName)
+		}
+	}
+
+	return notReadyNodeNames
+}
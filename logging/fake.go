@@ -36,3 +36,11 @@ func (i *FakeInstance) GetName() string {
 func (i *FakeInstance) GetLevel() string {
 	return ""
 }
+
+func (i *FakeInstance) GetFields() map[string]string {
+	return nil
+}
+
+func (i *FakeInstance) WithFields(_ map[string]string) Instance {
+	return i
+}
@@ -1,8 +1,11 @@
 package logging
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
+	"time"
 
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
 )
@@ -10,6 +13,12 @@ import (
 const (
 	colorStop               = "\033[0m"
 	klogMessageMinimumParts = 5
+
+	jsonTimeKey   = "time"
+	jsonLevelKey  = "level"
+	jsonLoggerKey = "logger"
+	jsonMsgKey    = "msg"
+	jsonSourceKey = "source"
 )
 
 var colorMap = map[string]string{ //nolint:gochecknoglobals
@@ -73,3 +82,78 @@ func DefaultKlogFormatter(i Instance, l, m string) string {
 
 	return DefaultFormatter(i, clabernetesconstants.Info, newM)
 }
+
+// JSONFormatter is a logging instance formatter that renders each message as a single line json
+// object holding the timestamp, level, logger name and message along with any contextual fields of
+// the logging instance -- this is meant for log pipelines rather than humans.
+func JSONFormatter(i Instance, l, m string) string {
+	return formatJSON(i, l, m, nil)
+}
+
+// JSONKlogFormatter is the json counterpart of DefaultKlogFormatter -- it strips the klog header
+// from the message, keeping the klog severity as level and the source file/line as "source" field.
+// Messages without a klog header are rendered as they are at info level.
+func JSONKlogFormatter(i Instance, l, m string) string {
+	_ = l
+
+	parts := strings.Fields(m)
+
+	if len(parts) < klogMessageMinimumParts {
+		return formatJSON(i, clabernetesconstants.Info, m, nil)
+	}
+
+	return formatJSON(
+		i,
+		klogSeverityLevel(parts[0]),
+		strings.Join(parts[4:], " "),
+		map[string]string{
+			jsonSourceKey: strings.TrimRight(parts[3], "]"),
+		},
+	)
+}
+
+// klogSeverityLevel returns our log level for the severity character leading a klog header.
+func klogSeverityLevel(header string) string {
+	switch header[0] {
+	case 'W':
+		return clabernetesconstants.Warn
+	case 'E':
+		return clabernetesconstants.Critical
+	case 'F':
+		return clabernetesconstants.Fatal
+	default:
+		return clabernetesconstants.Info
+	}
+}
+
+func formatJSON(i Instance, l, m string, extraFields map[string]string) string {
+	instanceFields := i.GetFields()
+
+	entry := make(map[string]string, len(instanceFields)+len(extraFields)+4) //nolint:mnd
+
+	maps.Copy(entry, instanceFields)
+	maps.Copy(entry, extraFields)
+
+	entry[jsonTimeKey] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry[jsonLevelKey] = l
+	entry[jsonLoggerKey] = i.GetName()
+	entry[jsonMsgKey] = strings.TrimRight(m, "\n")
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		// can't really happen with a map of strings, but better a text message than none at all
+		return DefaultFormatter(i, l, m)
+	}
+
+	return string(b)
+}
+
+// GetFormatters returns the formatter and the klog formatter for the given log format (text or
+// json), unknown formats get the default (text) formatters.
+func GetFormatters(format string) (formatter, klogFormatter Formatter) {
+	if strings.EqualFold(format, clabernetesconstants.LogFormatJSON) {
+		return JSONFormatter, JSONKlogFormatter
+	}
+
+	return DefaultFormatter, DefaultKlogFormatter
+}
@@ -0,0 +1,299 @@
+package logging_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteslogging "github.com/srl-labs/clabernetes/logging"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+)
+
+func testLogger(t *testing.T, fields ...map[string]string) claberneteslogging.Instance {
+	t.Helper()
+
+	claberneteslogging.InitManager(claberneteslogging.WithLogger(func(...any) {}))
+
+	logger := claberneteslogging.GetManager().MustRegisterAndGetLogger(
+		t.Name(),
+		clabernetesconstants.Info,
+	)
+
+	t.Cleanup(func() {
+		claberneteslogging.GetManager().DeleteLogger(t.Name())
+	})
+
+	for _, f := range fields {
+		logger = logger.WithFields(f)
+	}
+
+	return logger
+}
+
+// unmarshalJSONEntry unmarshals a json formatted log line, ensuring it is a single line and that
+// it has a sane timestamp which is then dropped as it differs on every run.
+func unmarshalJSONEntry(t *testing.T, line string) map[string]string {
+	t.Helper()
+
+	if strings.Contains(line, "\n") {
+		t.Fatalf("expected single line entry, got %q", line)
+	}
+
+	var entry map[string]string
+
+	err := json.Unmarshal([]byte(line), &entry)
+	if err != nil {
+		t.Fatalf("failed unmarshaling entry %q, error: %s", line, err)
+	}
+
+	_, err = time.Parse(time.RFC3339Nano, entry["time"])
+	if err != nil {
+		t.Fatalf("failed parsing entry time %q, error: %s", entry["time"], err)
+	}
+
+	delete(entry, "time")
+
+	return entry
+}
+
+func TestJSONFormatter(t *testing.T) {
+	cases := []struct {
+		name     string
+		fields   []map[string]string
+		level    string
+		message  string
+		expected map[string]string
+	}{
+		{
+			name:    "simple",
+			level:   clabernetesconstants.Info,
+			message: "hello there\n",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Info,
+				"logger": "TestJSONFormatter/simple",
+				"msg":    "hello there",
+			},
+		},
+		{
+			name: "escaping",
+			fields: []map[string]string{
+				{`some "quoted" key`: "multi\nline\tvalue"},
+			},
+			level:   clabernetesconstants.Warn,
+			message: `saying "hi" \ bye`,
+			expected: map[string]string{
+				`some "quoted" key`: "multi\nline\tvalue",
+				"level":             clabernetesconstants.Warn,
+				"logger":            "TestJSONFormatter/escaping",
+				"msg":               `saying "hi" \ bye`,
+			},
+		},
+		{
+			name: "merged-fields",
+			fields: []map[string]string{
+				{"topology": "topo01", "namespace": "one"},
+				{"namespace": "two", "node": "srl1"},
+			},
+			level:   clabernetesconstants.Debug,
+			message: "merged",
+			expected: map[string]string{
+				"topology":  "topo01",
+				"namespace": "two",
+				"node":      "srl1",
+				"level":     clabernetesconstants.Debug,
+				"logger":    "TestJSONFormatter/merged-fields",
+				"msg":       "merged",
+			},
+		},
+		{
+			name: "reserved-fields",
+			fields: []map[string]string{
+				{"msg": "nope", "level": "nope", "logger": "nope", "time": "nope"},
+			},
+			level:   clabernetesconstants.Critical,
+			message: "reserved",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Critical,
+				"logger": "TestJSONFormatter/reserved-fields",
+				"msg":    "reserved",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				logger := testLogger(t, testCase.fields...)
+
+				actual := unmarshalJSONEntry(
+					t,
+					claberneteslogging.JSONFormatter(logger, testCase.level, testCase.message),
+				)
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestJSONKlogFormatter(t *testing.T) {
+	cases := []struct {
+		name     string
+		fields   []map[string]string
+		message  string
+		expected map[string]string
+	}{
+		{
+			name:    "info",
+			message: "I1016 10:33:16.123456       1 reflector.go:351] Caches populated\n",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Info,
+				"logger": "TestJSONKlogFormatter/info",
+				"msg":    "Caches populated",
+				"source": "reflector.go:351",
+			},
+		},
+		{
+			name:    "warning",
+			message: "W1016 10:33:16.123456       1 warnings.go:70] some warning",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Warn,
+				"logger": "TestJSONKlogFormatter/warning",
+				"msg":    "some warning",
+				"source": "warnings.go:70",
+			},
+		},
+		{
+			name: "error",
+			fields: []map[string]string{
+				{"controller": "topology"},
+			},
+			message: `E1016 10:33:16.123456       1 leaderelection.go:332] failed "lease"`,
+			expected: map[string]string{
+				"controller": "topology",
+				"level":      clabernetesconstants.Critical,
+				"logger":     "TestJSONKlogFormatter/error",
+				"msg":        `failed "lease"`,
+				"source":     "leaderelection.go:332",
+			},
+		},
+		{
+			name:    "fatal",
+			message: "F1016 10:33:16.123456       1 main.go:42] boom",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Fatal,
+				"logger": "TestJSONKlogFormatter/fatal",
+				"msg":    "boom",
+				"source": "main.go:42",
+			},
+		},
+		{
+			name:    "no-header",
+			message: "not a klog line\n",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Info,
+				"logger": "TestJSONKlogFormatter/no-header",
+				"msg":    "not a klog line",
+			},
+		},
+		{
+			name:    "empty",
+			message: "",
+			expected: map[string]string{
+				"level":  clabernetesconstants.Info,
+				"logger": "TestJSONKlogFormatter/empty",
+				"msg":    "",
+			},
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				logger := testLogger(t, testCase.fields...)
+
+				actual := unmarshalJSONEntry(
+					t,
+					claberneteslogging.JSONKlogFormatter(
+						logger,
+						clabernetesconstants.Info,
+						testCase.message,
+					),
+				)
+
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					clabernetestesthelper.FailOutput(t, actual, testCase.expected)
+				}
+			})
+	}
+}
+
+func TestGetFormatters(t *testing.T) {
+	cases := []struct {
+		name                  string
+		format                string
+		expectedFormatter     claberneteslogging.Formatter
+		expectedKlogFormatter claberneteslogging.Formatter
+	}{
+		{
+			name:                  "text",
+			format:                clabernetesconstants.LogFormatText,
+			expectedFormatter:     claberneteslogging.DefaultFormatter,
+			expectedKlogFormatter: claberneteslogging.DefaultKlogFormatter,
+		},
+		{
+			name:                  "json",
+			format:                clabernetesconstants.LogFormatJSON,
+			expectedFormatter:     claberneteslogging.JSONFormatter,
+			expectedKlogFormatter: claberneteslogging.JSONKlogFormatter,
+		},
+		{
+			name:                  "json-upper-case",
+			format:                "JSON",
+			expectedFormatter:     claberneteslogging.JSONFormatter,
+			expectedKlogFormatter: claberneteslogging.JSONKlogFormatter,
+		},
+		{
+			name:                  "unset",
+			format:                "",
+			expectedFormatter:     claberneteslogging.DefaultFormatter,
+			expectedKlogFormatter: claberneteslogging.DefaultKlogFormatter,
+		},
+		{
+			name:                  "unknown",
+			format:                "yaml",
+			expectedFormatter:     claberneteslogging.DefaultFormatter,
+			expectedKlogFormatter: claberneteslogging.DefaultKlogFormatter,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				formatter, klogFormatter := claberneteslogging.GetFormatters(testCase.format)
+
+				if reflect.ValueOf(formatter).Pointer() !=
+					reflect.ValueOf(testCase.expectedFormatter).Pointer() {
+					t.Fatalf("unexpected formatter for format %q", testCase.format)
+				}
+
+				if reflect.ValueOf(klogFormatter).Pointer() !=
+					reflect.ValueOf(testCase.expectedKlogFormatter).Pointer() {
+					t.Fatalf("unexpected klog formatter for format %q", testCase.format)
+				}
+			})
+	}
+}
@@ -2,6 +2,7 @@ package logging
 
 import (
 	"fmt"
+	"maps"
 	"sync"
 
 	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
@@ -26,9 +27,23 @@ type Instance interface { //nolint: interfacebloat
 	Write(p []byte) (n int, err error)
 	GetName() string
 	GetLevel() string
+	// GetFields returns the contextual fields (topology, node, reconcile id and the like) of the
+	// instance.
+	GetFields() map[string]string
+	// WithFields returns an instance that logs just like this instance (same name, level, formatter
+	// and output) but carries the given contextual fields in addition to the fields this instance
+	// already has. Only structured formatters (the JSONFormatter) render the fields.
+	WithFields(fields map[string]string) Instance
 }
 
 type instance struct {
+	*instanceState
+	fields map[string]string
+}
+
+// instanceState is the state of a logging instance that is shared with all instances derived from
+// it via WithFields, this way changing the level or formatter of a logger applies to all of them.
+type instanceState struct {
 	lock      sync.Mutex
 	name      string
 	level     string
@@ -45,6 +60,22 @@ func (i *instance) GetLevel() string {
 	return i.level
 }
 
+func (i *instance) GetFields() map[string]string {
+	return i.fields
+}
+
+func (i *instance) WithFields(fields map[string]string) Instance {
+	mergedFields := make(map[string]string, len(i.fields)+len(fields))
+
+	maps.Copy(mergedFields, i.fields)
+	maps.Copy(mergedFields, fields)
+
+	return &instance{
+		instanceState: i.instanceState,
+		fields:        mergedFields,
+	}
+}
+
 // Debug accepts a Debug level log message with no formatting.
 func (i *instance) Debug(f string) {
 	i.lock.Lock()
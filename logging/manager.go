@@ -125,12 +125,14 @@ func (m *manager) RegisterLogger(name, level string) error {
 	}
 
 	m.instances[name] = &instance{
-		lock:      sync.Mutex{},
-		name:      name,
-		level:     level,
-		formatter: m.formatter,
-		c:         make(chan string),
-		done:      make(chan interface{}),
+		instanceState: &instanceState{
+			lock:      sync.Mutex{},
+			name:      name,
+			level:     level,
+			formatter: m.formatter,
+			c:         make(chan string),
+			done:      make(chan interface{}),
+		},
 	}
 
 	go m.start(m.instances[name])
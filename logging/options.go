@@ -10,3 +10,10 @@ func WithLogger(logger func(...interface{})) Option {
 		m.loggers = append(m.loggers, logger)
 	}
 }
+
+// WithFormatter sets the formatter the logging Manager applies to the logging instances it creates.
+func WithFormatter(formatter Formatter) Option {
+	return func(m *manager) {
+		m.formatter = formatter
+	}
+}
@@ -34,7 +34,14 @@ func StartClabernetes(initializer bool) {
 
 	rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
 
-	claberneteslogging.InitManager()
+	formatter, klogFormatter := claberneteslogging.GetFormatters(
+		clabernetesutil.GetEnvStrOrDefault(
+			clabernetesconstants.ManagerLoggerFormatEnv,
+			clabernetesconstants.LogFormatText,
+		),
+	)
+
+	claberneteslogging.InitManager(claberneteslogging.WithFormatter(formatter))
 
 	logManager := claberneteslogging.GetManager()
 
@@ -46,7 +53,7 @@ func StartClabernetes(initializer bool) {
 		),
 	)
 
-	err := createNewKlogLogger(logManager)
+	err := createNewKlogLogger(logManager, klogFormatter)
 	if err != nil {
 		clabernetesLogger.Fatalf("failed patching klog, err: %s", err)
 	}
@@ -33,6 +33,17 @@ func (c *clabernetes) init(ctx context.Context) {
 
 	c.logger.Debug("initializing global config complete...")
 
+	// webhooks last -- the manager bootstraps the global config above, and on a fresh install
+	// there is no manager serving the webhooks yet
+	c.logger.Info("initializing webhooks...")
+
+	err = initializeWebhooks(c)
+	if err != nil {
+		c.logger.Fatalf("failed initializing webhooks, err: %s", err)
+	}
+
+	c.logger.Debug("initializing webhooks complete...")
+
 	c.logger.Info("init complete...")
 
 	c.baseCtxCancel()
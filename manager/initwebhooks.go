@@ -0,0 +1,251 @@
+package manager
+
+import (
+	"fmt"
+
+	clabernetesapis "github.com/srl-labs/clabernetes/apis"
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesmanagertypes "github.com/srl-labs/clabernetes/manager/types"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	k8sadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	webhookServicePort = 443
+
+	topologiesResource = "topologies"
+	configsResource    = "configs"
+)
+
+// initializeWebhooks creates (or updates) the mutating and validating webhook configurations for
+// the Topology and Config resources, pointing them at the manager http server via the webhook
+// service and injecting the webhook ca bundle from the certificates secret. Topology webhooks
+// fail closed (that is the point of them), Config webhooks fail open since the manager itself
+// bootstraps the Config and must not be blocked by its own (possibly not yet running) webhook.
+func initializeWebhooks(c clabernetesmanagertypes.Clabernetes) error {
+	secret, err := getCertificatesSecret(c)
+	if err != nil {
+		return fmt.Errorf("getting certificates secret: %w", err)
+	}
+
+	caBundle, ok := secret.Data["webhook-ca.crt"]
+	if !ok {
+		return fmt.Errorf(
+			"%w: certificates secret is missing webhook ca data",
+			claberneteserrors.ErrPrepare,
+		)
+	}
+
+	err = applyMutatingWebhookConfiguration(c, renderMutatingWebhookConfiguration(c, caBundle))
+	if err != nil {
+		return fmt.Errorf("apply mutating webhook configuration: %w", err)
+	}
+
+	err = applyValidatingWebhookConfiguration(c, renderValidatingWebhookConfiguration(c, caBundle))
+	if err != nil {
+		return fmt.Errorf("apply validating webhook configuration: %w", err)
+	}
+
+	return nil
+}
+
+func webhookConfigurationObjectMeta(c clabernetesmanagertypes.Clabernetes) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: fmt.Sprintf("%s-webhook", c.GetAppName()),
+		Labels: map[string]string{
+			clabernetesconstants.LabelApp:       c.GetAppName(),
+			clabernetesconstants.LabelName:      fmt.Sprintf("%s-webhook", c.GetAppName()),
+			clabernetesconstants.LabelComponent: "webhook",
+		},
+	}
+}
+
+func webhookClientConfig(
+	c clabernetesmanagertypes.Clabernetes,
+	caBundle []byte,
+	path string,
+) k8sadmissionregistrationv1.WebhookClientConfig {
+	return k8sadmissionregistrationv1.WebhookClientConfig{
+		Service: &k8sadmissionregistrationv1.ServiceReference{
+			Namespace: c.GetNamespace(),
+			Name:      fmt.Sprintf("%s-webhook", c.GetAppName()),
+			Path:      clabernetesutil.ToPointer(path),
+			Port:      clabernetesutil.ToPointer(int32(webhookServicePort)),
+		},
+		CABundle: caBundle,
+	}
+}
+
+func webhookRules(resource string) []k8sadmissionregistrationv1.RuleWithOperations {
+	return []k8sadmissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []k8sadmissionregistrationv1.OperationType{
+				k8sadmissionregistrationv1.Create,
+				k8sadmissionregistrationv1.Update,
+			},
+			Rule: k8sadmissionregistrationv1.Rule{
+				APIGroups:   []string{clabernetesapis.Group},
+				APIVersions: []string{clabernetesapisv1alpha1.Version},
+				Resources:   []string{resource},
+			},
+		},
+	}
+}
+
+func renderMutatingWebhookConfiguration(
+	c clabernetesmanagertypes.Clabernetes,
+	caBundle []byte,
+) *k8sadmissionregistrationv1.MutatingWebhookConfiguration {
+	return &k8sadmissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: webhookConfigurationObjectMeta(c),
+		Webhooks: []k8sadmissionregistrationv1.MutatingWebhook{
+			{
+				Name: fmt.Sprintf("mutate.%s.%s", topologiesResource, clabernetesapis.Group),
+				ClientConfig: webhookClientConfig(
+					c,
+					caBundle,
+					clabernetesconstants.WebhookMutateTopologyPath,
+				),
+				Rules: webhookRules(topologiesResource),
+				FailurePolicy: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.Fail,
+				),
+				SideEffects: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.SideEffectClassNone,
+				),
+				TimeoutSeconds: clabernetesutil.ToPointer(
+					int32(clabernetesconstants.WebhookTimeoutSeconds),
+				),
+				AdmissionReviewVersions: []string{"v1"},
+			},
+			{
+				Name: fmt.Sprintf("mutate.%s.%s", configsResource, clabernetesapis.Group),
+				ClientConfig: webhookClientConfig(
+					c,
+					caBundle,
+					clabernetesconstants.WebhookMutateConfigPath,
+				),
+				Rules: webhookRules(configsResource),
+				FailurePolicy: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.Ignore,
+				),
+				SideEffects: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.SideEffectClassNone,
+				),
+				TimeoutSeconds: clabernetesutil.ToPointer(
+					int32(clabernetesconstants.WebhookTimeoutSeconds),
+				),
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+}
+
+func renderValidatingWebhookConfiguration(
+	c clabernetesmanagertypes.Clabernetes,
+	caBundle []byte,
+) *k8sadmissionregistrationv1.ValidatingWebhookConfiguration {
+	return &k8sadmissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: webhookConfigurationObjectMeta(c),
+		Webhooks: []k8sadmissionregistrationv1.ValidatingWebhook{
+			{
+				Name: fmt.Sprintf("validate.%s.%s", topologiesResource, clabernetesapis.Group),
+				ClientConfig: webhookClientConfig(
+					c,
+					caBundle,
+					clabernetesconstants.WebhookValidateTopologyPath,
+				),
+				Rules: webhookRules(topologiesResource),
+				FailurePolicy: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.Fail,
+				),
+				SideEffects: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.SideEffectClassNone,
+				),
+				TimeoutSeconds: clabernetesutil.ToPointer(
+					int32(clabernetesconstants.WebhookTimeoutSeconds),
+				),
+				AdmissionReviewVersions: []string{"v1"},
+			},
+			{
+				Name: fmt.Sprintf("validate.%s.%s", configsResource, clabernetesapis.Group),
+				ClientConfig: webhookClientConfig(
+					c,
+					caBundle,
+					clabernetesconstants.WebhookValidateConfigPath,
+				),
+				Rules: webhookRules(configsResource),
+				FailurePolicy: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.Ignore,
+				),
+				SideEffects: clabernetesutil.ToPointer(
+					k8sadmissionregistrationv1.SideEffectClassNone,
+				),
+				TimeoutSeconds: clabernetesutil.ToPointer(
+					int32(clabernetesconstants.WebhookTimeoutSeconds),
+				),
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+}
+
+func applyMutatingWebhookConfiguration(
+	c clabernetesmanagertypes.Clabernetes,
+	webhookConfiguration *k8sadmissionregistrationv1.MutatingWebhookConfiguration,
+) error {
+	ctx, ctxCancel := c.NewContextWithTimeout()
+	defer ctxCancel()
+
+	client := c.GetKubeClient().AdmissionregistrationV1().MutatingWebhookConfigurations()
+
+	current, err := client.Get(ctx, webhookConfiguration.Name, metav1.GetOptions{})
+	if err != nil && apimachineryerrors.IsNotFound(err) {
+		_, err = client.Create(ctx, webhookConfiguration, metav1.CreateOptions{})
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	// the api server defaults a handful of webhook fields we dont set, so comparing is more
+	// trouble than its worth -- this only runs on init anyway, so just always update
+	current.Labels = webhookConfiguration.Labels
+	current.Webhooks = webhookConfiguration.Webhooks
+
+	_, err = client.Update(ctx, current, metav1.UpdateOptions{})
+
+	return err
+}
+
+func applyValidatingWebhookConfiguration(
+	c clabernetesmanagertypes.Clabernetes,
+	webhookConfiguration *k8sadmissionregistrationv1.ValidatingWebhookConfiguration,
+) error {
+	ctx, ctxCancel := c.NewContextWithTimeout()
+	defer ctxCancel()
+
+	client := c.GetKubeClient().AdmissionregistrationV1().ValidatingWebhookConfigurations()
+
+	current, err := client.Get(ctx, webhookConfiguration.Name, metav1.GetOptions{})
+	if err != nil && apimachineryerrors.IsNotFound(err) {
+		_, err = client.Create(ctx, webhookConfiguration, metav1.CreateOptions{})
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	// the api server defaults a handful of webhook fields we dont set, so comparing is more
+	// trouble than its worth -- this only runs on init anyway, so just always update
+	current.Labels = webhookConfiguration.Labels
+	current.Webhooks = webhookConfiguration.Webhooks
+
+	_, err = client.Update(ctx, current, metav1.UpdateOptions{})
+
+	return err
+}
@@ -14,13 +14,16 @@ const (
 	klogAlsoLogToStderr = "alsologtostderr"
 )
 
-func createNewKlogLogger(logManager claberneteslogging.Manager) error {
+func createNewKlogLogger(
+	logManager claberneteslogging.Manager,
+	klogFormatter claberneteslogging.Formatter,
+) error {
 	err := logManager.RegisterLogger(klogLoggerName, clabernetesconstants.Info)
 	if err != nil {
 		return err
 	}
 
-	err = logManager.SetLoggerFormatter(klogLoggerName, claberneteslogging.DefaultKlogFormatter)
+	err = logManager.SetLoggerFormatter(klogLoggerName, klogFormatter)
 	if err != nil {
 		return err
 	}
@@ -74,6 +74,14 @@ func newManager(scheme *apimachineryruntime.Scheme, appName string) (ctrlruntime
 							},
 						},
 					},
+					// snapshots are created by users, so we cant expect them to have our label
+					&clabernetesapisv1alpha1.TopologySnapshot{}: {
+						Namespaces: map[string]ctrlruntimecache.Config{
+							ctrlruntimecache.AllNamespaces: {
+								LabelSelector: labels.Everything(),
+							},
+						},
+					},
 				}
 
 				return ctrlruntimecache.New(config, opts)
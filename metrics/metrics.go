@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var (
+	registerOnce sync.Once //nolint:gochecknoglobals
+
+	nodeRestarts = prometheus.NewCounterVec( //nolint:gochecknoglobals
+		prometheus.CounterOpts{
+			Namespace: clabernetesconstants.Clabernetes,
+			Name:      "node_restarts_total",
+			Help: "Number of node (launcher deployment) restarts the controller triggered due " +
+				"to configuration changes.",
+		},
+		[]string{"namespace", "topology"},
+	)
+
+	imagePulls = prometheus.NewCounterVec( //nolint:gochecknoglobals
+		prometheus.CounterOpts{
+			Namespace: clabernetesconstants.Clabernetes,
+			Name:      "image_pulls_total",
+			Help:      "Number of ImageRequest image pulls handled by the controller, by result.",
+		},
+		[]string{"result"},
+	)
+
+	imagePullDuration = prometheus.NewHistogramVec( //nolint:gochecknoglobals
+		prometheus.HistogramOpts{
+			Namespace: clabernetesconstants.Clabernetes,
+			Name:      "image_pull_duration_seconds",
+			Help: "Time from spawning an image puller pod until the pod left the pending state " +
+				"(i.e. the image has been pulled), by result.",
+			// 1s up to ~17 minutes, images for network operating systems can be *big*
+			Buckets: prometheus.ExponentialBuckets(1, 2, 11), //nolint:mnd
+		},
+		[]string{"result"},
+	)
+)
+
+// Register registers the clabernetes controller metrics with the controller-runtime metrics
+// registry -- which already holds the controller-runtime metrics (reconcile counts, durations and
+// errors per controller, work queue metrics and so on). The given client is used to gather the
+// topology metrics at scrape time. Calling Register more than once is a no-op.
+func Register(client ctrlruntimeclient.Client) {
+	registerOnce.Do(func() {
+		ctrlruntimemetrics.Registry.MustRegister(
+			nodeRestarts,
+			imagePulls,
+			imagePullDuration,
+			newTopologyCollector(client),
+		)
+	})
+}
+
+// Handler returns the http handler serving the metrics of the controller-runtime metrics registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(ctrlruntimemetrics.Registry, promhttp.HandlerOpts{})
+}
+
+// RecordNodeRestart records the controller restarting a node of the given topology.
+func RecordNodeRestart(namespace, topologyName string) {
+	nodeRestarts.WithLabelValues(namespace, topologyName).Inc()
+}
+
+// RecordImagePull records the outcome of an image pull -- a nil error means the image was pulled
+// successfully.
+func RecordImagePull(duration time.Duration, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+
+	imagePulls.WithLabelValues(result).Inc()
+	imagePullDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
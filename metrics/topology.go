@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const topologyCollectTimeout = 5 * time.Second
+
+// topologyCollector is a prometheus collector that reports the state of the topologies in the
+// cluster. Rather than keeping gauges up to date from within the reconcilers (and having to take
+// care of topologies going away) the topologies are simply listed (from the cache) at scrape time.
+type topologyCollector struct {
+	client ctrlruntimeclient.Client
+
+	topologies          *prometheus.Desc
+	topologyNodes       *prometheus.Desc
+	topologyReady       *prometheus.Desc
+	topologyCreated     *prometheus.Desc
+	topologyListFailure *prometheus.Desc
+}
+
+func newTopologyCollector(client ctrlruntimeclient.Client) *topologyCollector {
+	return &topologyCollector{
+		client: client,
+		topologies: prometheus.NewDesc(
+			prometheus.BuildFQName(clabernetesconstants.Clabernetes, "", "topologies"),
+			"Number of topologies per namespace.",
+			[]string{"namespace"},
+			nil,
+		),
+		topologyNodes: prometheus.NewDesc(
+			prometheus.BuildFQName(clabernetesconstants.Clabernetes, "topology", "nodes"),
+			"Number of nodes of a topology, by node readiness status (ready, notready, "+
+				"unknown, paused, deploymentDisabled).",
+			[]string{"namespace", "topology", "status"},
+			nil,
+		),
+		topologyReady: prometheus.NewDesc(
+			prometheus.BuildFQName(clabernetesconstants.Clabernetes, "topology", "ready"),
+			"Whether all nodes of a topology report ready (1) or not (0).",
+			[]string{"namespace", "topology"},
+			nil,
+		),
+		topologyCreated: prometheus.NewDesc(
+			prometheus.BuildFQName(
+				clabernetesconstants.Clabernetes,
+				"topology",
+				"created_timestamp_seconds",
+			),
+			"Unix creation timestamp of a topology.",
+			[]string{"namespace", "topology"},
+			nil,
+		),
+		topologyListFailure: prometheus.NewDesc(
+			prometheus.BuildFQName(
+				clabernetesconstants.Clabernetes,
+				"topology",
+				"scrape_error",
+			),
+			"Whether listing the topologies for this scrape failed (1) or not (0).",
+			nil,
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *topologyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.topologies
+
+	ch <- c.topologyNodes
+
+	ch <- c.topologyReady
+
+	ch <- c.topologyCreated
+
+	ch <- c.topologyListFailure
+}
+
+// Collect implements prometheus.Collector.
+func (c *topologyCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), topologyCollectTimeout)
+	defer cancel()
+
+	topologies := &clabernetesapisv1alpha1.TopologyList{}
+
+	err := c.client.List(ctx, topologies)
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(c.topologyListFailure, prometheus.GaugeValue, 1)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.topologyListFailure, prometheus.GaugeValue, 0)
+
+	namespaceTopologies := make(map[string]int)
+
+	for idx := range topologies.Items {
+		topology := &topologies.Items[idx]
+
+		namespaceTopologies[topology.Namespace]++
+
+		nodeStatuses := make(map[string]int)
+
+		for _, nodeStatus := range topology.Status.NodeReadiness {
+			nodeStatuses[nodeStatus]++
+		}
+
+		for nodeStatus, count := range nodeStatuses {
+			ch <- prometheus.MustNewConstMetric(
+				c.topologyNodes,
+				prometheus.GaugeValue,
+				float64(count),
+				topology.Namespace,
+				topology.Name,
+				nodeStatus,
+			)
+		}
+
+		var topologyReady float64
+		if topology.Status.TopologyReady {
+			topologyReady = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.topologyReady,
+			prometheus.GaugeValue,
+			topologyReady,
+			topology.Namespace,
+			topology.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.topologyCreated,
+			prometheus.GaugeValue,
+			float64(topology.CreationTimestamp.Unix()),
+			topology.Namespace,
+			topology.Name,
+		)
+	}
+
+	for namespace, count := range namespaceTopologies {
+		ch <- prometheus.MustNewConstMetric(
+			c.topologies,
+			prometheus.GaugeValue,
+			float64(count),
+			namespace,
+		)
+	}
+}
@@ -166,11 +166,15 @@ func NormalizeDeployment(t *testing.T, objectData []byte) []byte {
 }
 
 // NormalizeConnectivity normalizes a connectivity cr between ci and local or other folks
-// machines/clusters -- so we can compare results more easily. For now this is just replacing the
-// namespace in the destinations since those will be random(ish) per test run.
+// machines/clusters -- so we can compare results more easily. This drops the launcher reported
+// tunnel statuses and replaces the namespace in the destinations since those will be random(ish)
+// per test run.
 func NormalizeConnectivity(t *testing.T, objectData []byte) []byte {
 	t.Helper()
 
+	// tunnel statuses are reported by the launchers and carry timestamps/addresses, so we toss them
+	objectData = YQCommand(t, objectData, "del(.status.pointToPointTunnels)")
+
 	// replace the namespace in the connectivity destinations
 	return regexp.MustCompile(`\..*\.svc.cluster.local`).
 		ReplaceAll(objectData, []byte(".NAMESPACE.svc.cluster.local"))
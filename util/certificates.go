@@ -94,6 +94,15 @@ func CreateClientCertificate(name string) *x509.Certificate {
 	}
 }
 
+// LauncherCaptureServerName returns the name the capture server certificate of the launchers of
+// the given topology is issued for. The manager reaches launchers by pod address, so it verifies
+// them against this name rather than against the address it dialed.
+func LauncherCaptureServerName(namespace, topologyName string) string {
+	return fmt.Sprintf(
+		"%s.%s.%s-launcher", topologyName, namespace, clabernetesconstants.Clabernetes,
+	)
+}
+
 // MustGeneratePrivateKey generates a rsa private key of keySize or panics.
 func MustGeneratePrivateKey(keySize int) *rsa.PrivateKey {
 	key, err := rsa.GenerateKey(cryptorand.Reader, keySize)
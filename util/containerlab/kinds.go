@@ -0,0 +1,20 @@
+package containerlab
+
+import clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+
+// SupportsNativeMode returns true if nodes of the given containerlab kind can run in native mode,
+// that is, directly as a container of the launcher pod rather than via containerlab. This is only
+// the case for container native kinds -- kinds that are not virtual machines packed in a container
+// and that need no more setup than containerlab can be replaced with.
+func SupportsNativeMode(containerlabKind string) bool {
+	switch containerlabKind {
+	case clabernetesconstants.KindNokiaSRLinux,
+		clabernetesconstants.KindSRL,
+		clabernetesconstants.KindAristaCEOS,
+		clabernetesconstants.KindCEOS,
+		clabernetesconstants.KindLinux:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+package containerlab
+
+import (
+	"fmt"
+	"strings"
+
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const briefEndpointElementCount = 2
+
+// UnmarshalYAML unmarshals a link definition in either the brief or extended format.
+func (l *LinkDefinition) UnmarshalYAML(value *yaml.Node) error {
+	rawLink := &struct {
+		Type       string      `yaml:"type,omitempty"`
+		Endpoints  []yaml.Node `yaml:"endpoints,omitempty"`
+		LinkConfig `yaml:",inline"`
+	}{}
+
+	err := value.Decode(rawLink)
+	if err != nil {
+		return err
+	}
+
+	l.Type = rawLink.Type
+	l.LinkConfig = rawLink.LinkConfig
+
+	for idx := range rawLink.Endpoints {
+		endpointNode := &rawLink.Endpoints[idx]
+
+		switch endpointNode.Kind { //nolint:exhaustive
+		case yaml.ScalarNode:
+			var endpoint string
+
+			err = endpointNode.Decode(&endpoint)
+			if err != nil {
+				return err
+			}
+
+			l.Endpoints = append(l.Endpoints, endpoint)
+		case yaml.MappingNode:
+			endpoint := &LinkEndpoint{}
+
+			err = endpointNode.Decode(endpoint)
+			if err != nil {
+				return err
+			}
+
+			l.ExtendedEndpoints = append(l.ExtendedEndpoints, endpoint)
+		default:
+			return fmt.Errorf(
+				"%w: link endpoint on line %d is neither a string nor an object",
+				claberneteserrors.ErrParse,
+				endpointNode.Line,
+			)
+		}
+	}
+
+	return nil
+}
+
+// MarshalYAML marshals a link definition, emitting the "endpoints" key in the brief or extended
+// format depending on which endpoints are set.
+func (l *LinkDefinition) MarshalYAML() (interface{}, error) {
+	rawLink := struct {
+		Type       string      `yaml:"type,omitempty"`
+		Endpoints  interface{} `yaml:"endpoints,omitempty"`
+		LinkConfig `yaml:",inline"`
+	}{
+		Type:       l.Type,
+		LinkConfig: l.LinkConfig,
+	}
+
+	switch {
+	case len(l.ExtendedEndpoints) > 0:
+		rawLink.Endpoints = l.ExtendedEndpoints
+	case l.Endpoints != nil:
+		rawLink.Endpoints = l.Endpoints
+	}
+
+	return rawLink, nil
+}
+
+// IsReservedEndpointNode returns true if the given endpoint node name is one of the containerlab
+// reserved keywords ("host", "mgmt-net", "macvlan") rather than an actual node in the topology.
+func IsReservedEndpointNode(nodeName string) bool {
+	switch nodeName {
+	case clabernetesconstants.HostKeyword,
+		clabernetesconstants.MgmtNetKeyword,
+		clabernetesconstants.MacVLANKeyword:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsBrief returns true if the link is defined in the containerlab "brief" format.
+func (l *LinkDefinition) IsBrief() bool {
+	return l.Type == "" && len(l.ExtendedEndpoints) == 0
+}
+
+// ResolveEndpoints returns the endpoints of the link as LinkEndpoint objects regardless of the
+// format the link was defined in. Brief endpoints are split into their node and interface parts,
+// single endpoint link types return a slice with just their one endpoint.
+func (l *LinkDefinition) ResolveEndpoints() ([]*LinkEndpoint, error) {
+	switch l.Type {
+	case "", clabernetesconstants.LinkTypeVeth:
+		if len(l.ExtendedEndpoints) > 0 {
+			return l.ExtendedEndpoints, nil
+		}
+
+		endpoints := make([]*LinkEndpoint, len(l.Endpoints))
+
+		for idx, endpoint := range l.Endpoints {
+			endpointParts := strings.Split(endpoint, ":")
+
+			if len(endpointParts) != briefEndpointElementCount {
+				return nil, fmt.Errorf(
+					"%w: endpoint '%q' has wrong syntax, bad endpoint:interface config",
+					claberneteserrors.ErrParse,
+					l.Endpoints,
+				)
+			}
+
+			endpoints[idx] = &LinkEndpoint{
+				Node:      endpointParts[0],
+				Interface: endpointParts[1],
+			}
+		}
+
+		return endpoints, nil
+	case clabernetesconstants.LinkTypeMgmtNet,
+		clabernetesconstants.LinkTypeMacVLAN,
+		clabernetesconstants.LinkTypeHost,
+		clabernetesconstants.LinkTypeDummy,
+		clabernetesconstants.LinkTypeVXLAN,
+		clabernetesconstants.LinkTypeVXLANStitch:
+		if l.Endpoint == nil {
+			return nil, fmt.Errorf(
+				"%w: link of type %q has no endpoint", claberneteserrors.ErrParse, l.Type,
+			)
+		}
+
+		return []*LinkEndpoint{l.Endpoint}, nil
+	default:
+		return nil, fmt.Errorf(
+			"%w: unsupported link type %q", claberneteserrors.ErrParse, l.Type,
+		)
+	}
+}
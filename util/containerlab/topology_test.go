@@ -44,6 +44,39 @@ topology:
         retries: 1
         interval: 5
         timeout: 2
+`,
+		},
+		{
+			config: `
+name: topo03
+
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+    srl2:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+  links:
+    - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+    - type: veth
+      mtu: 9000
+      endpoints:
+        - node: srl1
+          interface: e1-2
+          mac: 02:00:00:00:00:01
+        - node: srl2
+          interface: e1-2
+    - type: mgmt-net
+      endpoint:
+        node: srl1
+        interface: e1-3
+      host-interface: srl1-e1-3
+    - type: dummy
+      endpoint:
+        node: srl2
+        interface: e1-3
 `,
 		},
 	}
@@ -66,6 +99,9 @@ func TestLoadContainerlabConfigFromConfigObjects(t *testing.T) {
 		{
 			config: getMinimalValidConfigObjectWithFullHealthcheck(),
 		},
+		{
+			config: getMinimalValidConfigObjectWithLinks(),
+		},
 	}
 
 	for _, testCase := range cases {
@@ -126,3 +162,146 @@ func getMinimalValidConfigObject() *clabernetesutilcontainerlab.Config {
 
 	return config
 }
+
+func getMinimalValidConfigObjectWithLinks() *clabernetesutilcontainerlab.Config {
+	config := getMinimalValidConfigObject()
+	config.Topology.Links = []*clabernetesutilcontainerlab.LinkDefinition{
+		{
+			LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+				Endpoints: []string{"srl1:e1-1", "host:srl1-e1-1"},
+			},
+		},
+		{
+			Type: "veth",
+			LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+				ExtendedEndpoints: []*clabernetesutilcontainerlab.LinkEndpoint{
+					{
+						Node:      "srl1",
+						Interface: "e1-2",
+						MAC:       "02:00:00:00:00:01",
+					},
+					{
+						Node:      "srl1",
+						Interface: "e1-3",
+					},
+				},
+				MTU: 9000,
+			},
+		},
+		{
+			Type: "macvlan",
+			LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+				Endpoint: &clabernetesutilcontainerlab.LinkEndpoint{
+					Node:      "srl1",
+					Interface: "e1-4",
+				},
+				HostInterface: "eth0",
+				Mode:          "bridge",
+			},
+		},
+	}
+
+	return config
+}
+
+func TestLinkDefinitionResolveEndpoints(t *testing.T) {
+	cases := []struct {
+		name      string
+		link      *clabernetesutilcontainerlab.LinkDefinition
+		expected  []*clabernetesutilcontainerlab.LinkEndpoint
+		expectErr bool
+	}{
+		{
+			name: "brief",
+			link: &clabernetesutilcontainerlab.LinkDefinition{
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					Endpoints: []string{"srl1:e1-1", "srl2:e1-1"},
+				},
+			},
+			expected: []*clabernetesutilcontainerlab.LinkEndpoint{
+				{Node: "srl1", Interface: "e1-1"},
+				{Node: "srl2", Interface: "e1-1"},
+			},
+		},
+		{
+			name: "brief-bad-endpoint",
+			link: &clabernetesutilcontainerlab.LinkDefinition{
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					Endpoints: []string{"srl1:e1-1", "srl2"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "veth-extended",
+			link: &clabernetesutilcontainerlab.LinkDefinition{
+				Type: "veth",
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					ExtendedEndpoints: []*clabernetesutilcontainerlab.LinkEndpoint{
+						{Node: "srl1", Interface: "e1-1", MAC: "02:00:00:00:00:01"},
+						{Node: "srl2", Interface: "e1-1"},
+					},
+				},
+			},
+			expected: []*clabernetesutilcontainerlab.LinkEndpoint{
+				{Node: "srl1", Interface: "e1-1", MAC: "02:00:00:00:00:01"},
+				{Node: "srl2", Interface: "e1-1"},
+			},
+		},
+		{
+			name: "dummy",
+			link: &clabernetesutilcontainerlab.LinkDefinition{
+				Type: "dummy",
+				LinkConfig: clabernetesutilcontainerlab.LinkConfig{
+					Endpoint: &clabernetesutilcontainerlab.LinkEndpoint{
+						Node:      "srl1",
+						Interface: "dummy0",
+					},
+				},
+			},
+			expected: []*clabernetesutilcontainerlab.LinkEndpoint{
+				{Node: "srl1", Interface: "dummy0"},
+			},
+		},
+		{
+			name: "host-missing-endpoint",
+			link: &clabernetesutilcontainerlab.LinkDefinition{
+				Type: "host",
+			},
+			expectErr: true,
+		},
+		{
+			name: "unknown-type",
+			link: &clabernetesutilcontainerlab.LinkDefinition{
+				Type: "wormhole",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				actual, err := testCase.link.ResolveEndpoints()
+				if testCase.expectErr {
+					if err == nil {
+						t.Fatalf("expected error, got nil")
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expected, actual); diff != "" {
+					t.Errorf("Endpoints not equal (-want +got):\n%s", diff)
+				}
+			},
+		)
+	}
+}
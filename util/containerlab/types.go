@@ -1,5 +1,7 @@
 package containerlab
 
+import "maps"
+
 // Config defines lab configuration as it is provided in the YAML file.
 type Config struct {
 	// Lab name
@@ -110,6 +112,114 @@ func (t *Topology) GetNodeLicense(nodeName string) string {
 	return t.Defaults.License
 }
 
+// GetNodeStartupConfig returns the resolved startup-config (path) for the given node.
+func (t *Topology) GetNodeStartupConfig(nodeName string) string {
+	containerlabKind, _ := t.GetNodeKindType(nodeName)
+
+	nodeDefinition, nodeDefinitionOk := t.Nodes[nodeName]
+	if nodeDefinitionOk {
+		if nodeDefinition.StartupConfig != "" {
+			return nodeDefinition.StartupConfig
+		}
+	}
+
+	kindDefinition, kindDefinitionOk := t.Kinds[containerlabKind]
+	if kindDefinitionOk {
+		if kindDefinition.StartupConfig != "" {
+			return kindDefinition.StartupConfig
+		}
+	}
+
+	return t.Defaults.StartupConfig
+}
+
+// GetNodeGroup returns the resolved (containerlab) group for the given node.
+func (t *Topology) GetNodeGroup(nodeName string) string {
+	containerlabKind, _ := t.GetNodeKindType(nodeName)
+
+	nodeDefinition, nodeDefinitionOk := t.Nodes[nodeName]
+	if nodeDefinitionOk && nodeDefinition != nil {
+		if nodeDefinition.Group != "" {
+			return nodeDefinition.Group
+		}
+	}
+
+	kindDefinition, kindDefinitionOk := t.Kinds[containerlabKind]
+	if kindDefinitionOk && kindDefinition != nil {
+		if kindDefinition.Group != "" {
+			return kindDefinition.Group
+		}
+	}
+
+	return t.Defaults.Group
+}
+
+// GetNodeImagePullPolicy returns the resolved image pull policy for the given node.
+func (t *Topology) GetNodeImagePullPolicy(nodeName string) string {
+	containerlabKind, _ := t.GetNodeKindType(nodeName)
+
+	nodeDefinition, nodeDefinitionOk := t.Nodes[nodeName]
+	if nodeDefinitionOk {
+		if nodeDefinition.ImagePullPolicy != "" {
+			return nodeDefinition.ImagePullPolicy
+		}
+	}
+
+	kindDefinition, kindDefinitionOk := t.Kinds[containerlabKind]
+	if kindDefinitionOk {
+		if kindDefinition.ImagePullPolicy != "" {
+			return kindDefinition.ImagePullPolicy
+		}
+	}
+
+	return t.Defaults.ImagePullPolicy
+}
+
+// GetNodeEnv returns the resolved environment variables for the given node -- just like
+// containerlab does it, the env vars of the defaults, the kind and the node are merged with the
+// most specific one winning.
+func (t *Topology) GetNodeEnv(nodeName string) map[string]string {
+	containerlabKind, _ := t.GetNodeKindType(nodeName)
+
+	env := make(map[string]string)
+
+	maps.Copy(env, t.Defaults.Env)
+
+	kindDefinition, kindDefinitionOk := t.Kinds[containerlabKind]
+	if kindDefinitionOk {
+		maps.Copy(env, kindDefinition.Env)
+	}
+
+	nodeDefinition, nodeDefinitionOk := t.Nodes[nodeName]
+	if nodeDefinitionOk {
+		maps.Copy(env, nodeDefinition.Env)
+	}
+
+	return env
+}
+
+// GetNodeBinds returns the resolved binds for the given node -- the binds of the node if it has
+// any, otherwise the binds of its kind or lastly of the defaults.
+func (t *Topology) GetNodeBinds(nodeName string) []string {
+	containerlabKind, _ := t.GetNodeKindType(nodeName)
+
+	nodeDefinition, nodeDefinitionOk := t.Nodes[nodeName]
+	if nodeDefinitionOk {
+		if len(nodeDefinition.Binds) > 0 {
+			return nodeDefinition.Binds
+		}
+	}
+
+	kindDefinition, kindDefinitionOk := t.Kinds[containerlabKind]
+	if kindDefinitionOk {
+		if len(kindDefinition.Binds) > 0 {
+			return kindDefinition.Binds
+		}
+	}
+
+	return t.Defaults.Binds
+}
+
 // NodeDefinition represents a configuration a given node can have in the lab definition file.
 type NodeDefinition struct {
 	Kind                 string            `yaml:"kind,omitempty"`
@@ -213,18 +323,41 @@ type CertificateConfig struct {
 	// different algos would be needed or so
 }
 
-// LinkDefinition represents a link definition in the topology file.
+// LinkDefinition represents a link definition in the topology file. Links can be defined in the
+// "brief" format (no type, endpoints as "node:interface" strings) or in the "extended" format where
+// the type is set explicitly and endpoints are provided as objects. See links.go for the custom
+// (un)marshal bits that handle both formats.
 type LinkDefinition struct {
 	Type       string `yaml:"type,omitempty"`
 	LinkConfig `yaml:",inline"`
 }
 
-// LinkConfig is the vendor'd (ish) clab link config object.
+// LinkConfig is the vendor'd (ish) clab link config object. Endpoints and ExtendedEndpoints both
+// map to the "endpoints" key in the topology file -- brief links populate Endpoints, extended veth
+// links populate ExtendedEndpoints. Single endpoint link types (mgmt-net, macvlan, host, dummy,
+// vxlan and vxlan-stitch) populate Endpoint instead.
 type LinkConfig struct {
-	Endpoints []string
-	Labels    map[string]string      `yaml:"labels,omitempty"`
-	Vars      map[string]interface{} `yaml:"vars,omitempty"`
-	MTU       int                    `yaml:"mtu,omitempty"`
+	Endpoints         []string        `yaml:"-"`
+	ExtendedEndpoints []*LinkEndpoint `yaml:"-"`
+	Endpoint          *LinkEndpoint   `yaml:"endpoint,omitempty"`
+	// host side interface name for host, mgmt-net and macvlan links
+	HostInterface string `yaml:"host-interface,omitempty"`
+	// macvlan mode
+	Mode string `yaml:"mode,omitempty"`
+	// remote vtep address, vni, and udp port for vxlan and vxlan-stitch links
+	Remote  string                 `yaml:"remote,omitempty"`
+	VNI     int                    `yaml:"vni,omitempty"`
+	UDPPort int                    `yaml:"udp-port,omitempty"`
+	Labels  map[string]string      `yaml:"labels,omitempty"`
+	Vars    map[string]interface{} `yaml:"vars,omitempty"`
+	MTU     int                    `yaml:"mtu,omitempty"`
+}
+
+// LinkEndpoint represents an endpoint of an "extended" format link.
+type LinkEndpoint struct {
+	Node      string `yaml:"node"`
+	Interface string `yaml:"interface,omitempty"`
+	MAC       string `yaml:"mac,omitempty"`
 }
 
 // HealthcheckConfig represents healthcheck options a node has.
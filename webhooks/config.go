@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeadmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// NewConfigDefaulter returns the defaulting (mutating) admission handler for Config objects.
+func NewConfigDefaulter() ctrlruntimeadmission.CustomDefaulter {
+	return &configDefaulter{}
+}
+
+type configDefaulter struct{}
+
+// Default sets the Config defaults for any fields left empty.
+func (d *configDefaulter) Default(_ context.Context, obj apimachineryruntime.Object) error {
+	config, err := asConfig(obj)
+	if err != nil {
+		return err
+	}
+
+	if config.Spec.Naming == "" {
+		config.Spec.Naming = clabernetesconstants.NamingModePrefixed
+	}
+
+	if config.Spec.Deployment.LauncherImagePullPolicy == "" {
+		config.Spec.Deployment.LauncherImagePullPolicy =
+			clabernetesconstants.KubernetesImagePullIfNotPresent
+	}
+
+	return nil
+}
+
+// NewConfigValidator returns the validating admission handler for Config objects.
+func NewConfigValidator() ctrlruntimeadmission.CustomValidator {
+	return &configValidator{}
+}
+
+type configValidator struct{}
+
+func (v *configValidator) ValidateCreate(
+	_ context.Context,
+	obj apimachineryruntime.Object,
+) (ctrlruntimeadmission.Warnings, error) {
+	config, err := asConfig(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, v.validate(config)
+}
+
+func (v *configValidator) ValidateUpdate(
+	_ context.Context,
+	_, newObj apimachineryruntime.Object,
+) (ctrlruntimeadmission.Warnings, error) {
+	config, err := asConfig(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, v.validate(config)
+}
+
+func (v *configValidator) ValidateDelete(
+	_ context.Context,
+	_ apimachineryruntime.Object,
+) (ctrlruntimeadmission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *configValidator) validate(config *clabernetesapisv1alpha1.Config) error {
+	specPath := field.NewPath("spec")
+
+	var errs field.ErrorList
+
+	inClusterDNSSuffix := config.Spec.InClusterDNSSuffix
+	if inClusterDNSSuffix != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(inClusterDNSSuffix) {
+			errs = append(
+				errs,
+				field.Invalid(specPath.Child("inClusterDNSSuffix"), inClusterDNSSuffix, msg),
+			)
+		}
+	}
+
+	criSockOverride := config.Spec.ImagePull.CRISockOverride
+	if criSockOverride != "" && !filepath.IsAbs(criSockOverride) {
+		errs = append(
+			errs,
+			field.Invalid(
+				specPath.Child("imagePull", "criSockOverride"),
+				criSockOverride,
+				"cri sock override must be an absolute path",
+			),
+		)
+	}
+
+	errs = append(
+		errs,
+		validateDuration(
+			specPath.Child("deployment", "containerlabTimeout"),
+			config.Spec.Deployment.ContainerlabTimeout,
+		)...,
+	)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apimachineryerrors.NewInvalid(
+		clabernetesapisv1alpha1.SchemeGroupVersion.WithKind("Config").GroupKind(),
+		config.GetName(),
+		errs,
+	)
+}
+
+func asConfig(obj apimachineryruntime.Object) (*clabernetesapisv1alpha1.Config, error) {
+	config, ok := obj.(*clabernetesapisv1alpha1.Config)
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: expected a Config object but got %T",
+			claberneteserrors.ErrInvalidData,
+			obj,
+		)
+	}
+
+	return config, nil
+}
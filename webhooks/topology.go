@@ -0,0 +1,1261 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	claberneteserrors "github.com/srl-labs/clabernetes/errors"
+	clabernetesgeneratedclientset "github.com/srl-labs/clabernetes/generated/clientset"
+	clabernetesutil "github.com/srl-labs/clabernetes/util"
+	clabernetesutilcontainerlab "github.com/srl-labs/clabernetes/util/containerlab"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	ctrlruntimeadmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const vethEndpointCount = 2
+
+// NewTopologyDefaulter returns the defaulting (mutating) admission handler for Topology objects.
+func NewTopologyDefaulter() ctrlruntimeadmission.CustomDefaulter {
+	return &topologyDefaulter{}
+}
+
+type topologyDefaulter struct{}
+
+// Default sets the defaults that the controller would otherwise only resolve at reconcile time so
+// that the stored Topology reflects what is actually deployed.
+func (d *topologyDefaulter) Default(_ context.Context, obj apimachineryruntime.Object) error {
+	topology, err := asTopology(obj)
+	if err != nil {
+		return err
+	}
+
+	if topology.Spec.Naming == "" {
+		topology.Spec.Naming = clabernetesconstants.NamingModeGlobal
+	}
+
+	if topology.Spec.Connectivity == "" {
+		topology.Spec.Connectivity = clabernetesconstants.ConnectivityVXLAN
+	}
+
+	if topology.Spec.Connectivity == clabernetesconstants.ConnectivityMultus {
+		if topology.Spec.Multus.Type == "" {
+			topology.Spec.Multus.Type = clabernetesconstants.MultusTypeVLAN
+		}
+
+		if topology.Spec.Multus.VLANBase == 0 {
+			topology.Spec.Multus.VLANBase = clabernetesconstants.MultusVLANBaseDefault
+		}
+	}
+
+	if topology.Spec.Deployment.Persistence.Enabled &&
+		topology.Spec.Deployment.Persistence.ClaimSize == "" {
+		topology.Spec.Deployment.Persistence.ClaimSize =
+			clabernetesconstants.PersistenceClaimSizeDefault
+	}
+
+	for nodeName, filesFromConfigMap := range topology.Spec.Deployment.FilesFromConfigMap {
+		for idx := range filesFromConfigMap {
+			if filesFromConfigMap[idx].Mode == "" {
+				topology.Spec.Deployment.FilesFromConfigMap[nodeName][idx].Mode =
+					clabernetesconstants.FileModeRead
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewTopologyValidator returns the validating admission handler for Topology objects. The kube
+// client is used to check that referenced objects (pull secrets) exist in the Topology namespace,
+// the clabernetes client to check the multus vlan ids against those of the other Topologies.
+func NewTopologyValidator(
+	kubeClient kubernetes.Interface,
+	kubeClabernetesClient clabernetesgeneratedclientset.Interface,
+) ctrlruntimeadmission.CustomValidator {
+	return &topologyValidator{
+		kubeClient:            kubeClient,
+		kubeClabernetesClient: kubeClabernetesClient,
+	}
+}
+
+type topologyValidator struct {
+	kubeClient            kubernetes.Interface
+	kubeClabernetesClient clabernetesgeneratedclientset.Interface
+}
+
+func (v *topologyValidator) ValidateCreate(
+	ctx context.Context,
+	obj apimachineryruntime.Object,
+) (ctrlruntimeadmission.Warnings, error) {
+	topology, err := asTopology(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, v.validate(ctx, nil, topology)
+}
+
+func (v *topologyValidator) ValidateUpdate(
+	ctx context.Context,
+	oldObj, newObj apimachineryruntime.Object,
+) (ctrlruntimeadmission.Warnings, error) {
+	oldTopology, err := asTopology(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	newTopology, err := asTopology(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if newTopology.DeletionTimestamp != nil {
+		// never block updates (finalizer removal, status) on a topology that is being deleted
+		return nil, nil
+	}
+
+	if equality.Semantic.DeepEqual(oldTopology.Spec, newTopology.Spec) {
+		// the controller updates the topology (status/finalizers) all the time, nothing to check
+		// if the spec didn't change -- this also keeps us from blocking topologies created before
+		// validation existed
+		return nil, nil
+	}
+
+	return nil, v.validate(ctx, oldTopology, newTopology)
+}
+
+func (v *topologyValidator) ValidateDelete(
+	_ context.Context,
+	_ apimachineryruntime.Object,
+) (ctrlruntimeadmission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *topologyValidator) validate(
+	ctx context.Context,
+	oldTopology, topology *clabernetesapisv1alpha1.Topology,
+) error {
+	specPath := field.NewPath("spec")
+
+	errs, nodeNames := validateDefinition(specPath.Child("definition"), topology)
+
+	if nodeNames != nil {
+		errs = append(errs, validateNodeReferences(specPath, topology, nodeNames)...)
+
+		errs = append(
+			errs,
+			validateNativeMode(
+				specPath.Child("deployment", "nativeMode", "nodes"),
+				topology,
+				nodeNames,
+			)...,
+		)
+	}
+
+	errs = append(
+		errs,
+		validatePersistence(
+			specPath.Child("deployment", "persistence"),
+			oldTopology,
+			topology,
+		)...,
+	)
+
+	errs = append(
+		errs,
+		validateDuration(
+			specPath.Child("deployment", "containerlabTimeout"),
+			topology.Spec.Deployment.ContainerlabTimeout,
+		)...,
+	)
+
+	errs = append(errs, v.validateMultus(ctx, specPath.Child("multus"), topology)...)
+
+	errs = append(
+		errs,
+		validateLinkImpairments(
+			specPath.Child("linkImpairments"),
+			topology,
+			nodeNames,
+		)...,
+	)
+
+	errs = append(
+		errs,
+		validateLinkAdminStates(
+			specPath.Child("linkAdminStates"),
+			topology,
+			nodeNames,
+		)...,
+	)
+
+	errs = append(
+		errs,
+		v.validatePullSecrets(
+			ctx,
+			specPath.Child("imagePull", "pullSecrets"),
+			topology,
+		)...,
+	)
+
+	errs = append(
+		errs,
+		v.validateProbeConfigurations(
+			ctx,
+			specPath.Child("statusProbes"),
+			topology,
+		)...,
+	)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apimachineryerrors.NewInvalid(
+		clabernetesapisv1alpha1.SchemeGroupVersion.WithKind("Topology").GroupKind(),
+		topology.GetName(),
+		errs,
+	)
+}
+
+// validateDefinition validates the containerlab definition of the topology, returning any errors
+// and, if the definition could be parsed, the set of node names in the definition.
+func validateDefinition(
+	definitionPath *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+) (field.ErrorList, clabernetesutil.StringSet) {
+	containerlabPath := definitionPath.Child("containerlab")
+
+	if topology.Spec.Definition.Containerlab == "" {
+		return field.ErrorList{
+			field.Required(containerlabPath, "a containerlab definition must be provided"),
+		}, nil
+	}
+
+	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		topology.Spec.Definition.Containerlab,
+	)
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(
+				containerlabPath,
+				field.OmitValueType{},
+				fmt.Sprintf("failed parsing containerlab definition: %s", err),
+			),
+		}, nil
+	}
+
+	nodeNames := clabernetesutil.NewStringSet()
+
+	for nodeName := range containerlabConfig.Topology.Nodes {
+		nodeNames.Add(nodeName)
+	}
+
+	var errs field.ErrorList
+
+	for idx, link := range containerlabConfig.Topology.Links {
+		if link == nil {
+			continue
+		}
+
+		for _, linkErr := range validateLink(link, nodeNames) {
+			errs = append(
+				errs,
+				field.Invalid(
+					containerlabPath,
+					field.OmitValueType{},
+					fmt.Sprintf("links[%d]: %s", idx, linkErr),
+				),
+			)
+		}
+	}
+
+	return errs, nodeNames
+}
+
+func validateLink(
+	link *clabernetesutilcontainerlab.LinkDefinition,
+	nodeNames clabernetesutil.StringSet,
+) []string {
+	endpoints, err := link.ResolveEndpoints()
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var errs []string
+
+	if link.Type == "" || link.Type == clabernetesconstants.LinkTypeVeth {
+		if len(endpoints) != vethEndpointCount {
+			errs = append(
+				errs,
+				fmt.Sprintf("veth link must have exactly two endpoints, got %d", len(endpoints)),
+			)
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint == nil {
+			errs = append(errs, "link has an empty endpoint")
+
+			continue
+		}
+
+		if endpoint.Interface == "" {
+			errs = append(
+				errs,
+				fmt.Sprintf("endpoint for node %q has no interface", endpoint.Node),
+			)
+		}
+
+		if clabernetesutilcontainerlab.IsReservedEndpointNode(endpoint.Node) {
+			continue
+		}
+
+		if !nodeNames.Contains(endpoint.Node) {
+			errs = append(
+				errs,
+				fmt.Sprintf("endpoint references unknown node %q", endpoint.Node),
+			)
+		}
+	}
+
+	return errs
+}
+
+// validateNodeReferences ensures all the node specific settings in the topology spec reference
+// nodes that actually exist in the containerlab definition.
+func validateNodeReferences(
+	specPath *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+	nodeNames clabernetesutil.StringSet,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	deploymentPath := specPath.Child("deployment")
+
+	errs = append(
+		errs,
+		validateNodeKeys(
+			deploymentPath.Child("filesFromConfigMap"),
+			topology.Spec.Deployment.FilesFromConfigMap,
+			nodeNames,
+		)...,
+	)
+
+	errs = append(
+		errs,
+		validateNodeKeys(
+			deploymentPath.Child("filesFromURL"),
+			topology.Spec.Deployment.FilesFromURL,
+			nodeNames,
+		)...,
+	)
+
+	for _, nodeName := range slices.Sorted(maps.Keys(topology.Spec.Deployment.Resources)) {
+		if nodeName == "default" {
+			continue
+		}
+
+		if !nodeNames.Contains(nodeName) {
+			errs = append(
+				errs,
+				field.NotFound(deploymentPath.Child("resources").Key(nodeName), nodeName),
+			)
+		}
+	}
+
+	errs = append(
+		errs,
+		validateNodeGroups(
+			deploymentPath.Child("nodeGrouping", "groups"),
+			topology.Spec.Deployment.NodeGrouping.Groups,
+			nodeNames,
+		)...,
+	)
+
+	statusProbesPath := specPath.Child("statusProbes")
+
+	for idx, nodeName := range topology.Spec.StatusProbes.ExcludedNodes {
+		if !nodeNames.Contains(nodeName) {
+			errs = append(
+				errs,
+				field.NotFound(statusProbesPath.Child("excludedNodes").Index(idx), nodeName),
+			)
+		}
+	}
+
+	errs = append(
+		errs,
+		validateNodeKeys(
+			statusProbesPath.Child("nodeProbeConfigurations"),
+			topology.Spec.StatusProbes.NodeProbeConfigurations,
+			nodeNames,
+		)...,
+	)
+
+	return errs
+}
+
+func validateNodeKeys[V any](
+	path *field.Path,
+	m map[string]V,
+	nodeNames clabernetesutil.StringSet,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, nodeName := range slices.Sorted(maps.Keys(m)) {
+		if !nodeNames.Contains(nodeName) {
+			errs = append(errs, field.NotFound(path.Key(nodeName), nodeName))
+		}
+	}
+
+	return errs
+}
+
+func validateNodeGroups(
+	path *field.Path,
+	groups map[string][]string,
+	nodeNames clabernetesutil.StringSet,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	groupedNodes := clabernetesutil.NewStringSet()
+
+	for _, groupName := range slices.Sorted(maps.Keys(groups)) {
+		if len(groups[groupName]) == 0 {
+			errs = append(
+				errs,
+				field.Required(path.Key(groupName), "node group must contain at least one node"),
+			)
+
+			continue
+		}
+
+		for idx, nodeName := range groups[groupName] {
+			switch {
+			case !nodeNames.Contains(nodeName):
+				errs = append(errs, field.NotFound(path.Key(groupName).Index(idx), nodeName))
+			case groupedNodes.Contains(nodeName):
+				errs = append(errs, field.Duplicate(path.Key(groupName).Index(idx), nodeName))
+			default:
+				groupedNodes.Add(nodeName)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateNativeMode ensures native mode nodes exist, support native mode and are not grouped.
+func validateNativeMode(
+	path *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+	nodeNames clabernetesutil.StringSet,
+) field.ErrorList {
+	if len(topology.Spec.Deployment.NativeMode.Nodes) == 0 {
+		return nil
+	}
+
+	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		topology.Spec.Definition.Containerlab,
+	)
+	if err != nil {
+		// already reported when validating the definition
+		return nil
+	}
+
+	groupedNodes := clabernetesutil.NewStringSet()
+
+	for _, groupNodes := range topology.Spec.Deployment.NodeGrouping.Groups {
+		groupedNodes.Extend(groupNodes)
+	}
+
+	var errs field.ErrorList
+
+	nativeModeNodes := clabernetesutil.NewStringSet()
+
+	for idx, nodeName := range topology.Spec.Deployment.NativeMode.Nodes {
+		if !nodeNames.Contains(nodeName) {
+			errs = append(errs, field.NotFound(path.Index(idx), nodeName))
+
+			continue
+		}
+
+		if nativeModeNodes.Contains(nodeName) {
+			errs = append(errs, field.Duplicate(path.Index(idx), nodeName))
+
+			continue
+		}
+
+		nativeModeNodes.Add(nodeName)
+
+		containerlabKind, _ := containerlabConfig.Topology.GetNodeKindType(nodeName)
+
+		if !clabernetesutilcontainerlab.SupportsNativeMode(containerlabKind) {
+			errs = append(
+				errs,
+				field.Invalid(
+					path.Index(idx),
+					nodeName,
+					fmt.Sprintf("kind %q cannot run in native mode", containerlabKind),
+				),
+			)
+		}
+
+		if groupedNodes.Contains(nodeName) {
+			errs = append(
+				errs,
+				field.Invalid(
+					path.Index(idx),
+					nodeName,
+					"native mode nodes cannot be members of a node group",
+				),
+			)
+		}
+	}
+
+	return errs
+}
+
+// validateLinkEndpoint ensures a link selecting endpoint is a well-formed "node:interface" endpoint
+// that was not already used (as tracked in seenEndpoints). The referenced node is only checked if
+// the definition could be parsed, that is, if nodeNames is not nil.
+func validateLinkEndpoint(
+	path *field.Path,
+	endpoint string,
+	nodeNames, seenEndpoints clabernetesutil.StringSet,
+) field.ErrorList {
+	nodeName, interfaceName, ok := strings.Cut(endpoint, ":")
+
+	switch {
+	case !ok || nodeName == "" || interfaceName == "":
+		return field.ErrorList{
+			field.Invalid(path, endpoint, "endpoint must be in \"node:interface\" format"),
+		}
+	case nodeNames != nil && !nodeNames.Contains(nodeName):
+		return field.ErrorList{field.NotFound(path, nodeName)}
+	case seenEndpoints.Contains(endpoint):
+		return field.ErrorList{field.Duplicate(path, endpoint)}
+	}
+
+	seenEndpoints.Add(endpoint)
+
+	return nil
+}
+
+// resolveNodeGroups returns the node group of every grouped node of the topology, the same way the
+// controller co-locates nodes in launchers: explicit groups take precedence over containerlab
+// groups, and native mode nodes are never part of a containerlab group.
+func resolveNodeGroups(
+	topology *clabernetesapisv1alpha1.Topology,
+	containerlabTopology *clabernetesutilcontainerlab.Topology,
+) map[string]string {
+	nodeGroups := make(map[string]string)
+
+	nodeGrouping := topology.Spec.Deployment.NodeGrouping
+
+	if len(nodeGrouping.Groups) > 0 {
+		for groupName, groupNodes := range nodeGrouping.Groups {
+			for _, nodeName := range groupNodes {
+				nodeGroups[nodeName] = groupName
+			}
+		}
+
+		return nodeGroups
+	}
+
+	if !nodeGrouping.ContainerlabGroups {
+		return nodeGroups
+	}
+
+	for nodeName := range containerlabTopology.Nodes {
+		if slices.Contains(topology.Spec.Deployment.NativeMode.Nodes, nodeName) {
+			continue
+		}
+
+		nodeGroup := containerlabTopology.GetNodeGroup(nodeName)
+		if nodeGroup != "" {
+			nodeGroups[nodeName] = nodeGroup
+		}
+	}
+
+	return nodeGroups
+}
+
+// validateLinkSpansLaunchers ensures the link selected by the given (well-formed) "node:interface"
+// endpoint exists and spans launchers -- link settings (impairments and admin states) are applied
+// to the host side of the tunneled node links, links that stay within a launcher (between nodes of
+// the same node group, to the host or any other non veth link) are not tunneled, so there is
+// nothing to apply the settings to.
+func validateLinkSpansLaunchers(
+	path *field.Path,
+	endpoint string,
+	containerlabTopology *clabernetesutilcontainerlab.Topology,
+	nodeGroups map[string]string,
+) field.ErrorList {
+	for _, link := range containerlabTopology.Links {
+		endpoints, err := link.ResolveEndpoints()
+		if err != nil {
+			// already reported when validating the definition
+			continue
+		}
+
+		selected := slices.ContainsFunc(
+			endpoints,
+			func(linkEndpoint *clabernetesutilcontainerlab.LinkEndpoint) bool {
+				return endpoint == fmt.Sprintf("%s:%s", linkEndpoint.Node, linkEndpoint.Interface)
+			},
+		)
+		if !selected {
+			continue
+		}
+
+		if (link.Type != "" && link.Type != clabernetesconstants.LinkTypeVeth) ||
+			len(endpoints) != vethEndpointCount ||
+			clabernetesutilcontainerlab.IsReservedEndpointNode(endpoints[0].Node) ||
+			clabernetesutilcontainerlab.IsReservedEndpointNode(endpoints[1].Node) {
+			return field.ErrorList{
+				field.Invalid(
+					path,
+					endpoint,
+					"link settings can only be set on veth links between nodes",
+				),
+			}
+		}
+
+		nodeGroup := nodeGroups[endpoints[0].Node]
+
+		if endpoints[0].Node == endpoints[1].Node ||
+			(nodeGroup != "" && nodeGroup == nodeGroups[endpoints[1].Node]) {
+			return field.ErrorList{
+				field.Invalid(
+					path,
+					endpoint,
+					"link stays within a single launcher, link settings can only be set on links"+
+						" between launchers",
+				),
+			}
+		}
+
+		return nil
+	}
+
+	return field.ErrorList{field.NotFound(path, endpoint)}
+}
+
+// linkSpansLaunchersValidator returns a func validating that the link selected by an endpoint
+// spans launchers (see validateLinkSpansLaunchers), or nil if the definition cannot be parsed.
+func linkSpansLaunchersValidator(
+	topology *clabernetesapisv1alpha1.Topology,
+) func(path *field.Path, endpoint string) field.ErrorList {
+	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		topology.Spec.Definition.Containerlab,
+	)
+	if err != nil || containerlabConfig.Topology == nil {
+		// already reported when validating the definition
+		return nil
+	}
+
+	nodeGroups := resolveNodeGroups(topology, containerlabConfig.Topology)
+
+	return func(path *field.Path, endpoint string) field.ErrorList {
+		return validateLinkSpansLaunchers(path, endpoint, containerlabConfig.Topology, nodeGroups)
+	}
+}
+
+// validateLinkImpairments ensures link impairments select links between launchers by a valid
+// endpoint and carry parsable delay/jitter values.
+func validateLinkImpairments(
+	path *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+	nodeNames clabernetesutil.StringSet,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	endpoints := clabernetesutil.NewStringSet()
+
+	validateSpansLaunchers := linkSpansLaunchersValidator(topology)
+
+	for idx, linkImpairment := range topology.Spec.LinkImpairments {
+		impairmentPath := path.Index(idx).Child("impairment")
+
+		endpointErrs := validateLinkEndpoint(
+			path.Index(idx).Child("endpoint"),
+			linkImpairment.Endpoint,
+			nodeNames,
+			endpoints,
+		)
+
+		if len(endpointErrs) == 0 && validateSpansLaunchers != nil {
+			endpointErrs = validateSpansLaunchers(
+				path.Index(idx).Child("endpoint"),
+				linkImpairment.Endpoint,
+			)
+		}
+
+		errs = append(errs, endpointErrs...)
+
+		errs = append(
+			errs,
+			validateDuration(impairmentPath.Child("delay"), linkImpairment.Impairment.Delay)...,
+		)
+
+		errs = append(
+			errs,
+			validateDuration(impairmentPath.Child("jitter"), linkImpairment.Impairment.Jitter)...,
+		)
+
+		if linkImpairment.Impairment.Jitter != "" && linkImpairment.Impairment.Delay == "" {
+			errs = append(
+				errs,
+				field.Required(impairmentPath.Child("delay"), "jitter requires a delay to be set"),
+			)
+		}
+	}
+
+	return errs
+}
+
+// validateLinkAdminStates ensures link admin states select links between launchers by a valid
+// endpoint.
+func validateLinkAdminStates(
+	path *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+	nodeNames clabernetesutil.StringSet,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	endpoints := clabernetesutil.NewStringSet()
+
+	validateSpansLaunchers := linkSpansLaunchersValidator(topology)
+
+	for idx, linkAdminState := range topology.Spec.LinkAdminStates {
+		endpointErrs := validateLinkEndpoint(
+			path.Index(idx).Child("endpoint"),
+			linkAdminState.Endpoint,
+			nodeNames,
+			endpoints,
+		)
+
+		if len(endpointErrs) == 0 && validateSpansLaunchers != nil {
+			endpointErrs = validateSpansLaunchers(
+				path.Index(idx).Child("endpoint"),
+				linkAdminState.Endpoint,
+			)
+		}
+
+		errs = append(errs, endpointErrs...)
+	}
+
+	return errs
+}
+
+// validateMultus validates the multus settings of the topology -- only if the topology actually
+// uses the multus connectivity flavor, the settings are ignored otherwise.
+func (v *topologyValidator) validateMultus(
+	ctx context.Context,
+	multusPath *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+) field.ErrorList {
+	if topology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus {
+		return nil
+	}
+
+	multus := topology.Spec.Multus
+
+	var errs field.ErrorList
+
+	if multus.Master == "" && multus.Type != clabernetesconstants.MultusTypeBridge {
+		errs = append(
+			errs,
+			field.Required(
+				multusPath.Child("master"),
+				"master interface is required unless using the bridge type",
+			),
+		)
+	}
+
+	if multus.MTU < 0 {
+		errs = append(
+			errs,
+			field.Invalid(multusPath.Child("mtu"), multus.MTU, "mtu must not be negative"),
+		)
+	}
+
+	return append(errs, v.validateMultusVLANRange(ctx, multusPath.Child("vlanBase"), topology)...)
+}
+
+// multusVLANBase returns the vlan base of the given (multus) topology.
+func multusVLANBase(topology *clabernetesapisv1alpha1.Topology) int {
+	if topology.Spec.Multus.VLANBase == 0 {
+		return clabernetesconstants.MultusVLANBaseDefault
+	}
+
+	return topology.Spec.Multus.VLANBase
+}
+
+// multusSharesVLANs returns true if the two (multus) topologies put their links on the same master
+// interface or bridge -- that is if their vlan ids must not overlap.
+func multusSharesVLANs(a, b *clabernetesapisv1alpha1.Topology) bool {
+	aBridge := a.Spec.Multus.Type == clabernetesconstants.MultusTypeBridge
+	bBridge := b.Spec.Multus.Type == clabernetesconstants.MultusTypeBridge
+
+	return aBridge == bBridge && a.Spec.Multus.Master == b.Spec.Multus.Master
+}
+
+// multusHighestTunnelID returns the highest tunnel id the links of the given topology (may) use.
+// Tunnel ids are only allocated by the controller and are kept for as long as the link exists, so
+// removing links leaves gaps -- new links fill the gaps first, so the highest id is either the
+// highest id allocated so far or the number of links of the definition, whichever is higher. All
+// links of the definition are counted, even though links between nodes of the same launcher need
+// no tunnel, so this errs on the safe side.
+func (v *topologyValidator) multusHighestTunnelID(
+	ctx context.Context,
+	topology *clabernetesapisv1alpha1.Topology,
+) (int, error) {
+	var highestTunnelID int
+
+	containerlabConfig, err := clabernetesutilcontainerlab.LoadContainerlabConfig(
+		topology.Spec.Definition.Containerlab,
+	)
+	if err == nil && containerlabConfig.Topology != nil {
+		highestTunnelID = len(containerlabConfig.Topology.Links)
+	}
+
+	connectivity, err := v.kubeClabernetesClient.ClabernetesV1alpha1().
+		Connectivities(topology.Namespace).
+		Get(ctx, topology.Name, metav1.GetOptions{})
+	if err != nil {
+		if apimachineryerrors.IsNotFound(err) {
+			// nothing allocated yet
+			return highestTunnelID, nil
+		}
+
+		return 0, err
+	}
+
+	for _, tunnels := range connectivity.Spec.PointToPointTunnels {
+		for _, tunnel := range tunnels {
+			highestTunnelID = max(highestTunnelID, tunnel.TunnelID)
+		}
+	}
+
+	return highestTunnelID, nil
+}
+
+// validateMultusVLANRange ensures every link of the topology gets a usable vlan id and that the
+// vlan ids do not overlap those of another topology on the same master interface (or bridge) --
+// the links get the vlan id "vlan base + tunnel id - 1", so the range of a topology runs from its
+// vlan base up to its highest tunnel id.
+func (v *topologyValidator) validateMultusVLANRange(
+	ctx context.Context,
+	vlanBasePath *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+) field.ErrorList {
+	vlanBase := multusVLANBase(topology)
+
+	if vlanBase < clabernetesconstants.MultusVLANBaseDefault ||
+		vlanBase > clabernetesconstants.MultusVLANIDMax {
+		return field.ErrorList{
+			field.Invalid(
+				vlanBasePath,
+				vlanBase,
+				fmt.Sprintf(
+					"vlan base must be between %d and %d",
+					clabernetesconstants.MultusVLANBaseDefault,
+					clabernetesconstants.MultusVLANIDMax,
+				),
+			),
+		}
+	}
+
+	highestTunnelID, err := v.multusHighestTunnelID(ctx, topology)
+	if err != nil {
+		return field.ErrorList{field.InternalError(vlanBasePath, err)}
+	}
+
+	vlanMax := vlanBase + max(highestTunnelID, 1) - 1
+
+	if vlanMax > clabernetesconstants.MultusVLANIDMax {
+		return field.ErrorList{
+			field.Invalid(
+				vlanBasePath,
+				vlanBase,
+				fmt.Sprintf(
+					"the links of the topology need vlan ids %d to %d, the highest vlan id is %d",
+					vlanBase,
+					vlanMax,
+					clabernetesconstants.MultusVLANIDMax,
+				),
+			),
+		}
+	}
+
+	otherTopologies, err := v.kubeClabernetesClient.ClabernetesV1alpha1().
+		Topologies(metav1.NamespaceAll).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return field.ErrorList{field.InternalError(vlanBasePath, err)}
+	}
+
+	var errs field.ErrorList
+
+	for idx := range otherTopologies.Items {
+		otherTopology := &otherTopologies.Items[idx]
+
+		if otherTopology.Namespace == topology.Namespace && otherTopology.Name == topology.Name {
+			continue
+		}
+
+		if otherTopology.Spec.Connectivity != clabernetesconstants.ConnectivityMultus ||
+			!multusSharesVLANs(topology, otherTopology) {
+			continue
+		}
+
+		otherHighestTunnelID, err := v.multusHighestTunnelID(ctx, otherTopology)
+		if err != nil {
+			return field.ErrorList{field.InternalError(vlanBasePath, err)}
+		}
+
+		otherVLANBase := multusVLANBase(otherTopology)
+		otherVLANMax := otherVLANBase + max(otherHighestTunnelID, 1) - 1
+
+		if vlanBase > otherVLANMax || vlanMax < otherVLANBase {
+			continue
+		}
+
+		errs = append(
+			errs,
+			field.Invalid(
+				vlanBasePath,
+				vlanBase,
+				fmt.Sprintf(
+					"vlan ids %d to %d overlap vlan ids %d to %d of topology %s/%s on the"+
+						" same master %q",
+					vlanBase,
+					vlanMax,
+					otherVLANBase,
+					otherVLANMax,
+					otherTopology.Namespace,
+					otherTopology.Name,
+					topology.Spec.Multus.Master,
+				),
+			),
+		)
+	}
+
+	return errs
+}
+
+func validatePersistence(
+	persistencePath *field.Path,
+	oldTopology, topology *clabernetesapisv1alpha1.Topology,
+) field.ErrorList {
+	claimSize := topology.Spec.Deployment.Persistence.ClaimSize
+	if claimSize == "" {
+		return nil
+	}
+
+	claimSizePath := persistencePath.Child("claimSize")
+
+	quantity, err := resource.ParseQuantity(claimSize)
+	if err != nil {
+		return field.ErrorList{field.Invalid(claimSizePath, claimSize, err.Error())}
+	}
+
+	if quantity.Sign() <= 0 {
+		return field.ErrorList{
+			field.Invalid(claimSizePath, claimSize, "claim size must be greater than zero"),
+		}
+	}
+
+	if oldTopology == nil ||
+		!oldTopology.Spec.Deployment.Persistence.Enabled ||
+		!topology.Spec.Deployment.Persistence.Enabled {
+		return nil
+	}
+
+	oldQuantity, err := resource.ParseQuantity(oldTopology.Spec.Deployment.Persistence.ClaimSize)
+	if err != nil {
+		// old value was never valid (or unset), nothing to compare against
+		return nil
+	}
+
+	if quantity.Cmp(oldQuantity) < 0 {
+		return field.ErrorList{
+			field.Forbidden(
+				claimSizePath,
+				fmt.Sprintf(
+					"claim size cannot be made smaller (current %s), delete and re-create the "+
+						"topology (or node) to shrink the claim",
+					oldQuantity.String(),
+				),
+			),
+		}
+	}
+
+	return nil
+}
+
+func (v *topologyValidator) validatePullSecrets(
+	ctx context.Context,
+	pullSecretsPath *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+) field.ErrorList {
+	if len(topology.Spec.ImagePull.PullSecrets) == 0 {
+		return nil
+	}
+
+	namespace := resolveNamespace(ctx, topology)
+
+	var errs field.ErrorList
+
+	for idx, pullSecret := range topology.Spec.ImagePull.PullSecrets {
+		err := v.validateSecretExists(ctx, pullSecretsPath.Index(idx), namespace, pullSecret)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// validateProbeConfigurations validates the default and the node specific probe configurations of
+// the topology. See validateProbeConfiguration for what is checked.
+func (v *topologyValidator) validateProbeConfigurations(
+	ctx context.Context,
+	statusProbesPath *field.Path,
+	topology *clabernetesapisv1alpha1.Topology,
+) field.ErrorList {
+	namespace := resolveNamespace(ctx, topology)
+
+	errs := v.validateProbeConfiguration(
+		ctx,
+		statusProbesPath.Child("probeConfiguration"),
+		namespace,
+		topology.Spec.StatusProbes.ProbeConfiguration,
+	)
+
+	nodeProbeConfigurations := topology.Spec.StatusProbes.NodeProbeConfigurations
+
+	for _, nodeName := range slices.Sorted(maps.Keys(nodeProbeConfigurations)) {
+		errs = append(
+			errs,
+			v.validateProbeConfiguration(
+				ctx,
+				statusProbesPath.Child("nodeProbeConfigurations").Key(nodeName),
+				namespace,
+				nodeProbeConfigurations[nodeName],
+			)...,
+		)
+	}
+
+	return errs
+}
+
+// validateProbeConfiguration validates a single probe configuration -- any credentials secret
+// must exist in the namespace of the topology, the ssh probe needs the credentials secret (plain
+// text passwords are rejected), the exec probe needs a command, and any regexes must compile.
+func (v *topologyValidator) validateProbeConfiguration(
+	ctx context.Context,
+	probeConfigurationPath *field.Path,
+	namespace string,
+	probeConfiguration clabernetesapisv1alpha1.ProbeConfiguration,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	if probeConfiguration.SSHProbeConfiguration != nil {
+		errs = append(
+			errs,
+			v.validateSSHProbeCredentials(
+				ctx,
+				probeConfigurationPath.Child("sshProbeConfiguration"),
+				namespace,
+				probeConfiguration.SSHProbeConfiguration,
+			)...,
+		)
+	}
+
+	gnmiProbeConfiguration := probeConfiguration.GNMIProbeConfiguration
+	if gnmiProbeConfiguration != nil && gnmiProbeConfiguration.CredentialsSecret != "" {
+		err := v.validateSecretExists(
+			ctx,
+			probeConfigurationPath.Child("gnmiProbeConfiguration", "credentialsSecret"),
+			namespace,
+			gnmiProbeConfiguration.CredentialsSecret,
+		)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	netconfProbeConfiguration := probeConfiguration.NETCONFProbe
+	if netconfProbeConfiguration != nil {
+		credentialsSecretPath := probeConfigurationPath.Child(
+			"netconfProbeConfiguration",
+			"credentialsSecret",
+		)
+
+		if netconfProbeConfiguration.CredentialsSecret == "" {
+			errs = append(
+				errs,
+				field.Required(credentialsSecretPath, "credentialsSecret is required"),
+			)
+		} else {
+			err := v.validateSecretExists(
+				ctx,
+				credentialsSecretPath,
+				namespace,
+				netconfProbeConfiguration.CredentialsSecret,
+			)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if probeConfiguration.HTTPProbeConfiguration != nil {
+		errs = append(
+			errs,
+			validateRegex(
+				probeConfigurationPath.Child("httpProbeConfiguration", "bodyRegex"),
+				probeConfiguration.HTTPProbeConfiguration.BodyRegex,
+			)...,
+		)
+	}
+
+	execProbeConfiguration := probeConfiguration.ExecProbeConfiguration
+	if execProbeConfiguration != nil {
+		execProbePath := probeConfigurationPath.Child("execProbeConfiguration")
+
+		if len(execProbeConfiguration.Command) == 0 {
+			errs = append(
+				errs,
+				field.Required(execProbePath.Child("command"), "command must not be empty"),
+			)
+		}
+
+		errs = append(
+			errs,
+			validateRegex(
+				execProbePath.Child("outputRegex"),
+				execProbeConfiguration.OutputRegex,
+			)...,
+		)
+	}
+
+	return errs
+}
+
+// validateSSHProbeCredentials validates the credentials of an ssh probe -- the credentials must be
+// sourced from a secret, the plain text password is rejected as it would end up in both the
+// Topology and the launcher Deployment.
+func (v *topologyValidator) validateSSHProbeCredentials(
+	ctx context.Context,
+	sshProbePath *field.Path,
+	namespace string,
+	sshProbeConfiguration *clabernetesapisv1alpha1.SSHProbeConfiguration,
+) field.ErrorList {
+	if sshProbeConfiguration.Password != "" {
+		return field.ErrorList{
+			field.Forbidden(
+				sshProbePath.Child("password"),
+				"plain text passwords are not supported, put the password in a secret and"+
+					" reference it via credentialsSecret",
+			),
+		}
+	}
+
+	if sshProbeConfiguration.CredentialsSecret == "" {
+		return field.ErrorList{
+			field.Required(
+				sshProbePath.Child("credentialsSecret"),
+				"credentialsSecret is required",
+			),
+		}
+	}
+
+	err := v.validateSecretExists(
+		ctx,
+		sshProbePath.Child("credentialsSecret"),
+		namespace,
+		sshProbeConfiguration.CredentialsSecret,
+	)
+	if err != nil {
+		return field.ErrorList{err}
+	}
+
+	return nil
+}
+
+func (v *topologyValidator) validateSecretExists(
+	ctx context.Context,
+	path *field.Path,
+	namespace,
+	secretName string,
+) *field.Error {
+	_, err := v.kubeClient.CoreV1().
+		Secrets(namespace).
+		Get(ctx, secretName, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if apimachineryerrors.IsNotFound(err) {
+		return field.NotFound(path, secretName)
+	}
+
+	return field.InternalError(path, err)
+}
+
+func validateRegex(path *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	_, err := regexp.Compile(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+
+	return nil
+}
+
+func validateDuration(path *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	_, err := time.ParseDuration(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+
+	return nil
+}
+
+func asTopology(obj apimachineryruntime.Object) (*clabernetesapisv1alpha1.Topology, error) {
+	topology, ok := obj.(*clabernetesapisv1alpha1.Topology)
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: expected a Topology object but got %T",
+			claberneteserrors.ErrInvalidData,
+			obj,
+		)
+	}
+
+	return topology, nil
+}
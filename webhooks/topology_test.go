@@ -0,0 +1,808 @@
+package webhooks_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	clabernetesapisv1alpha1 "github.com/srl-labs/clabernetes/apis/v1alpha1"
+	clabernetesconstants "github.com/srl-labs/clabernetes/constants"
+	clabernetesgeneratedclientsetfake "github.com/srl-labs/clabernetes/generated/clientset/fake"
+	clabernetestesthelper "github.com/srl-labs/clabernetes/testhelper"
+	claberneteswebhooks "github.com/srl-labs/clabernetes/webhooks"
+	k8scorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const webhookTestContainerlab = `---
+name: test
+topology:
+  nodes:
+    srl1:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+    srl2:
+      kind: srl
+      image: ghcr.io/nokia/srlinux
+  links:
+    - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+    - endpoints: ["srl1:e1-2", "host:srl1-e1-2"]
+`
+
+func webhookTestTopology(
+	mutate func(topology *clabernetesapisv1alpha1.Topology),
+) *clabernetesapisv1alpha1.Topology {
+	topology := &clabernetesapisv1alpha1.Topology{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-topology",
+			Namespace: "webhook-test",
+		},
+		Spec: clabernetesapisv1alpha1.TopologySpec{
+			Definition: clabernetesapisv1alpha1.Definition{
+				Containerlab: webhookTestContainerlab,
+			},
+		},
+	}
+
+	if mutate != nil {
+		mutate(topology)
+	}
+
+	return topology
+}
+
+// webhookTestConnectivity returns the Connectivity of the given topology with a tunnel allocated
+// the given tunnel id.
+func webhookTestConnectivity(
+	namespace, topologyName string,
+	tunnelID int,
+) *clabernetesapisv1alpha1.Connectivity {
+	return &clabernetesapisv1alpha1.Connectivity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      topologyName,
+			Namespace: namespace,
+		},
+		Spec: clabernetesapisv1alpha1.ConnectivitySpec{
+			PointToPointTunnels: map[string][]*clabernetesapisv1alpha1.PointToPointTunnel{
+				"srl1": {
+					{
+						TunnelID:        tunnelID,
+						LocalNode:       "srl1",
+						LocalInterface:  "e1-1",
+						RemoteNode:      "srl2",
+						RemoteInterface: "e1-1",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTopologyValidatorValidateCreate(t *testing.T) {
+	cases := []struct {
+		name          string
+		topology      *clabernetesapisv1alpha1.Topology
+		expectedError bool
+	}{
+		{
+			name:          "valid",
+			topology:      webhookTestTopology(nil),
+			expectedError: false,
+		},
+		{
+			name: "valid-node-references",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.FilesFromConfigMap = map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+					"srl1": {{FilePath: "/config", ConfigMapName: "srl1-config"}},
+				}
+				topology.Spec.StatusProbes.ExcludedNodes = []string{"srl2"}
+				topology.Spec.Deployment.Persistence.ClaimSize = "10Gi"
+				topology.Spec.ImagePull.PullSecrets = []string{"regcred"}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "missing-definition",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab = ""
+			}),
+			expectedError: true,
+		},
+		{
+			name: "unparsable-definition",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab = "topology: [this is: not valid"
+			}),
+			expectedError: true,
+		},
+		{
+			name: "definition-without-topology",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab = "name: test"
+			}),
+			expectedError: true,
+		},
+		{
+			name: "bad-link-endpoint-syntax",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab += `    - endpoints: ["srl1-e1-3", "srl2:e1-3"]
+`
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-to-unknown-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab += `    - endpoints: ["srl1:e1-3", "srl3:e1-3"]
+`
+			}),
+			expectedError: true,
+		},
+		{
+			name: "unknown-files-from-configmap-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.FilesFromConfigMap = map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+					"srl3": {{FilePath: "/config", ConfigMapName: "srl3-config"}},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "unknown-node-probe-configuration-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.NodeProbeConfigurations = map[string]clabernetesapisv1alpha1.ProbeConfiguration{
+					"srl3": {StartupSeconds: 10},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "unknown-excluded-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ExcludedNodes = []string{"srl3"}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-node-group",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NodeGrouping.Groups = map[string][]string{
+					"pod1": {"srl1", "srl2"},
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "unknown-node-group-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NodeGrouping.Groups = map[string][]string{
+					"pod1": {"srl1", "srl3"},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "node-in-multiple-node-groups",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NodeGrouping.Groups = map[string][]string{
+					"pod1": {"srl1", "srl2"},
+					"pod2": {"srl2"},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-native-mode",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NativeMode.Nodes = []string{"srl1"}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "unknown-native-mode-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NativeMode.Nodes = []string{"srl3"}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "unsupported-native-mode-kind",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab = strings.Replace(
+					webhookTestContainerlab,
+					"kind: srl",
+					"kind: vr-sros",
+					1,
+				)
+				topology.Spec.Deployment.NativeMode.Nodes = []string{"srl1"}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "grouped-native-mode-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NodeGrouping.Groups = map[string][]string{
+					"pod1": {"srl1", "srl2"},
+				}
+				topology.Spec.Deployment.NativeMode.Nodes = []string{"srl1"}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-multus",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth1"
+			}),
+			expectedError: false,
+		},
+		{
+			name: "valid-multus-bridge-without-master",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Type = clabernetesconstants.MultusTypeBridge
+			}),
+			expectedError: false,
+		},
+		{
+			name: "multus-without-master",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Type = clabernetesconstants.MultusTypeMACVLAN
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-multus-vlan-base",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth1"
+				topology.Spec.Multus.VLANBase = 4093
+			}),
+			expectedError: false,
+		},
+		{
+			name: "multus-default-vlan",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth1"
+				topology.Spec.Multus.VLANBase = 1
+			}),
+			expectedError: true,
+		},
+		{
+			name: "multus-links-exceed-vlan-range",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth1"
+				topology.Spec.Multus.VLANBase = 4094
+			}),
+			expectedError: true,
+		},
+		{
+			name: "multus-allocated-tunnel-ids-exceed-vlan-range",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				// two links, but the controller allocated tunnel id 3 to one of them already
+				topology.Name = "reshuffled-topology"
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth1"
+				topology.Spec.Multus.VLANBase = 4093
+			}),
+			expectedError: true,
+		},
+		{
+			name: "multus-vlan-overlaps-other-topology",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth2"
+				topology.Spec.Multus.VLANBase = 99
+			}),
+			expectedError: true,
+		},
+		{
+			name: "multus-vlan-overlaps-other-topology-allocated-tunnel-ids",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				// the other topology has two links but tunnel ids up to 5 allocated
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth2"
+				topology.Spec.Multus.VLANBase = 104
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-multus-vlan-after-other-topology",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth2"
+				topology.Spec.Multus.VLANBase = 105
+			}),
+			expectedError: false,
+		},
+		{
+			name: "valid-multus-vlan-other-master",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Master = "eth3"
+				topology.Spec.Multus.VLANBase = 100
+			}),
+			expectedError: false,
+		},
+		{
+			name: "valid-multus-vlan-bridge-named-like-other-master",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+				topology.Spec.Multus.Type = clabernetesconstants.MultusTypeBridge
+				topology.Spec.Multus.Master = "eth2"
+				topology.Spec.Multus.VLANBase = 100
+			}),
+			expectedError: false,
+		},
+		{
+			name: "invalid-claim-size",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.Persistence.ClaimSize = "five gigs"
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-link-impairment",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint: "srl1:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{
+							Delay:  "50ms",
+							Jitter: "5ms",
+							Loss:   "0.5",
+						},
+					},
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "bad-link-impairment-endpoint-syntax",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1-e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-impairment-unknown-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl3:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "duplicate-link-impairment-endpoint",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+					{
+						Endpoint:   "srl1:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Loss: "1"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "invalid-link-impairment-delay",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "fifty"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-impairment-jitter-without-delay",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Jitter: "5ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-impairment-unknown-link",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1:e1-9",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-impairment-host-link",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1:e1-2",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-impairment-grouped-nodes",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NodeGrouping.Groups = map[string][]string{
+					"pod1": {"srl1", "srl2"},
+				}
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl2:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-impairment-containerlab-grouped-nodes",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Definition.Containerlab = strings.ReplaceAll(
+					topology.Spec.Definition.Containerlab,
+					"kind: srl\n",
+					"kind: srl\n      group: spines\n",
+				)
+				topology.Spec.Deployment.NodeGrouping.ContainerlabGroups = true
+				topology.Spec.LinkImpairments = []clabernetesapisv1alpha1.LinkImpairment{
+					{
+						Endpoint:   "srl1:e1-1",
+						Impairment: clabernetesapisv1alpha1.Impairment{Delay: "50ms"},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-link-admin-state",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkAdminStates = []clabernetesapisv1alpha1.LinkAdminState{
+					{Endpoint: "srl2:e1-1", AdminState: "down"},
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "link-admin-state-unknown-node",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkAdminStates = []clabernetesapisv1alpha1.LinkAdminState{
+					{Endpoint: "srl3:e1-1", AdminState: "down"},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-admin-state-host-link",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkAdminStates = []clabernetesapisv1alpha1.LinkAdminState{
+					{Endpoint: "srl1:e1-2", AdminState: "down"},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "link-admin-state-grouped-nodes",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.NodeGrouping.Groups = map[string][]string{
+					"pod1": {"srl1", "srl2"},
+				}
+				topology.Spec.LinkAdminStates = []clabernetesapisv1alpha1.LinkAdminState{
+					{Endpoint: "srl2:e1-1", AdminState: "down"},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "duplicate-link-admin-state-endpoint",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.LinkAdminStates = []clabernetesapisv1alpha1.LinkAdminState{
+					{Endpoint: "srl2:e1-1", AdminState: "down"},
+					{Endpoint: "srl2:e1-1", AdminState: "up"},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "unknown-pull-secret",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.ImagePull.PullSecrets = []string{"regcred", "nope"}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-probe-credentials-secret",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration.SSHProbeConfiguration = &clabernetesapisv1alpha1.SSHProbeConfiguration{
+					CredentialsSecret: "probe-credentials",
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "unknown-probe-credentials-secret",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.NodeProbeConfigurations = map[string]clabernetesapisv1alpha1.ProbeConfiguration{
+					"srl1": {
+						SSHProbeConfiguration: &clabernetesapisv1alpha1.SSHProbeConfiguration{
+							CredentialsSecret: "nope",
+						},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "probe-password",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration.SSHProbeConfiguration = &clabernetesapisv1alpha1.SSHProbeConfiguration{
+					Username: "admin",
+					Password: "NokiaSrl1!",
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "probe-password-and-credentials-secret",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration.SSHProbeConfiguration = &clabernetesapisv1alpha1.SSHProbeConfiguration{
+					Username:          "admin",
+					Password:          "NokiaSrl1!",
+					CredentialsSecret: "probe-credentials",
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "probe-without-credentials",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration.SSHProbeConfiguration = &clabernetesapisv1alpha1.SSHProbeConfiguration{
+					Username: "admin",
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "valid-additional-probes",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration = clabernetesapisv1alpha1.ProbeConfiguration{
+					GNMIProbeConfiguration: &clabernetesapisv1alpha1.GNMIProbeConfiguration{
+						CredentialsSecret: "probe-credentials",
+					},
+					NETCONFProbe: &clabernetesapisv1alpha1.NETCONFProbeConfiguration{
+						CredentialsSecret: "probe-credentials",
+					},
+					HTTPProbeConfiguration: &clabernetesapisv1alpha1.HTTPProbeConfiguration{
+						Port:      443,
+						Scheme:    "HTTPS",
+						BodyRegex: "^ok$",
+					},
+					ExecProbeConfiguration: &clabernetesapisv1alpha1.ExecProbeConfiguration{
+						Command:     []string{"sr_cli", "info from state system"},
+						OutputRegex: "oper-state up",
+					},
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "unknown-netconf-probe-credentials-secret",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration.NETCONFProbe = &clabernetesapisv1alpha1.NETCONFProbeConfiguration{
+					CredentialsSecret: "nope",
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "invalid-http-probe-body-regex",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.NodeProbeConfigurations = map[string]clabernetesapisv1alpha1.ProbeConfiguration{
+					"srl1": {
+						HTTPProbeConfiguration: &clabernetesapisv1alpha1.HTTPProbeConfiguration{
+							Port:      80,
+							BodyRegex: "(unclosed",
+						},
+					},
+				}
+			}),
+			expectedError: true,
+		},
+		{
+			name: "empty-exec-probe-command",
+			topology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ProbeConfiguration.ExecProbeConfiguration = &clabernetesapisv1alpha1.ExecProbeConfiguration{}
+			}),
+			expectedError: true,
+		},
+	}
+
+	kubeClient := fake.NewClientset(
+		&k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "regcred",
+				Namespace: "webhook-test",
+			},
+		},
+		&k8scorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "probe-credentials",
+				Namespace: "webhook-test",
+			},
+			Type: k8scorev1.SecretTypeBasicAuth,
+		},
+	)
+
+	otherTopology := webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+		topology.Name = "other-topology"
+		topology.Namespace = "other-namespace"
+		topology.Spec.Connectivity = clabernetesconstants.ConnectivityMultus
+		topology.Spec.Multus.Type = clabernetesconstants.MultusTypeVLAN
+		topology.Spec.Multus.Master = "eth2"
+		topology.Spec.Multus.VLANBase = 100
+	})
+
+	kubeClabernetesClient := clabernetesgeneratedclientsetfake.NewSimpleClientset(
+		otherTopology,
+		webhookTestConnectivity("other-namespace", "other-topology", 5),
+		webhookTestConnectivity("webhook-test", "reshuffled-topology", 3),
+	)
+
+	validator := claberneteswebhooks.NewTopologyValidator(kubeClient, kubeClabernetesClient)
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				_, err := validator.ValidateCreate(context.Background(), testCase.topology)
+				if (err != nil) != testCase.expectedError {
+					clabernetestesthelper.FailOutput(t, err, testCase.expectedError)
+				}
+			})
+	}
+}
+
+func TestTopologyValidatorValidateUpdate(t *testing.T) {
+	cases := []struct {
+		name          string
+		oldTopology   *clabernetesapisv1alpha1.Topology
+		newTopology   *clabernetesapisv1alpha1.Topology
+		expectedError bool
+	}{
+		{
+			name: "unchanged-invalid-spec",
+			oldTopology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ExcludedNodes = []string{"srl3"}
+			}),
+			newTopology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.StatusProbes.ExcludedNodes = []string{"srl3"}
+				topology.Status.TopologyReady = true
+			}),
+			expectedError: false,
+		},
+		{
+			name: "shrink-claim",
+			oldTopology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.Persistence.Enabled = true
+				topology.Spec.Deployment.Persistence.ClaimSize = "10Gi"
+			}),
+			newTopology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.Persistence.Enabled = true
+				topology.Spec.Deployment.Persistence.ClaimSize = "5Gi"
+			}),
+			expectedError: true,
+		},
+		{
+			name: "grow-claim",
+			oldTopology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.Persistence.Enabled = true
+				topology.Spec.Deployment.Persistence.ClaimSize = "5Gi"
+			}),
+			newTopology: webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+				topology.Spec.Deployment.Persistence.Enabled = true
+				topology.Spec.Deployment.Persistence.ClaimSize = "10Gi"
+			}),
+			expectedError: false,
+		},
+	}
+
+	validator := claberneteswebhooks.NewTopologyValidator(
+		fake.NewClientset(),
+		clabernetesgeneratedclientsetfake.NewSimpleClientset(),
+	)
+
+	for _, testCase := range cases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				t.Logf("%s: starting", testCase.name)
+
+				_, err := validator.ValidateUpdate(
+					context.Background(),
+					testCase.oldTopology,
+					testCase.newTopology,
+				)
+				if (err != nil) != testCase.expectedError {
+					clabernetestesthelper.FailOutput(t, err, testCase.expectedError)
+				}
+			})
+	}
+}
+
+func TestTopologyDefaulterDefault(t *testing.T) {
+	topology := webhookTestTopology(func(topology *clabernetesapisv1alpha1.Topology) {
+		topology.Spec.Deployment.Persistence.Enabled = true
+		topology.Spec.Deployment.FilesFromConfigMap = map[string][]clabernetesapisv1alpha1.FileFromConfigMap{
+			"srl1": {{FilePath: "/config", ConfigMapName: "srl1-config"}},
+		}
+	})
+
+	err := claberneteswebhooks.NewTopologyDefaulter().Default(context.Background(), topology)
+	if err != nil {
+		t.Fatalf("failed defaulting topology, error: %s", err)
+	}
+
+	if topology.Spec.Naming != clabernetesconstants.NamingModeGlobal {
+		clabernetestesthelper.FailOutput(
+			t,
+			topology.Spec.Naming,
+			clabernetesconstants.NamingModeGlobal,
+		)
+	}
+
+	if topology.Spec.Connectivity != clabernetesconstants.ConnectivityVXLAN {
+		clabernetestesthelper.FailOutput(
+			t,
+			topology.Spec.Connectivity,
+			clabernetesconstants.ConnectivityVXLAN,
+		)
+	}
+
+	if topology.Spec.Deployment.Persistence.ClaimSize !=
+		clabernetesconstants.PersistenceClaimSizeDefault {
+		clabernetestesthelper.FailOutput(
+			t,
+			topology.Spec.Deployment.Persistence.ClaimSize,
+			clabernetesconstants.PersistenceClaimSizeDefault,
+		)
+	}
+
+	if topology.Spec.Deployment.FilesFromConfigMap["srl1"][0].Mode !=
+		clabernetesconstants.FileModeRead {
+		clabernetestesthelper.FailOutput(
+			t,
+			topology.Spec.Deployment.FilesFromConfigMap["srl1"][0].Mode,
+			clabernetesconstants.FileModeRead,
+		)
+	}
+}
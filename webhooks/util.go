@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeadmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// resolveNamespace returns the namespace of the given object, falling back to the namespace of
+// the admission request (objects being created may not have their namespace set yet).
+func resolveNamespace(ctx context.Context, obj metav1.Object) string {
+	if obj.GetNamespace() != "" {
+		return obj.GetNamespace()
+	}
+
+	req, err := ctrlruntimeadmission.RequestFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+
+	return req.Namespace
+}